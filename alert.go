@@ -0,0 +1,265 @@
+package pulse
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+)
+
+// AlertSeverity classifies how urgently an alert should be treated.
+type AlertSeverity string
+
+const (
+	AlertWarning  AlertSeverity = "warning"
+	AlertCritical AlertSeverity = "critical"
+)
+
+// Alert represents a single notable event raised by the AlertEngine, such as
+// a metric crossing into Yellow/Red or going stale.
+type Alert struct {
+	Reference string        `json:"reference"`
+	Category  string        `json:"category"`
+	Severity  AlertSeverity `json:"severity"`
+	Reason    string        `json:"reason"`
+	Score     int           `json:"score"`
+	Status    string        `json:"status"`
+	Timestamp time.Time     `json:"timestamp"`
+}
+
+// Sink delivers an Alert to some notification channel.
+type Sink interface {
+	Send(alert Alert) error
+}
+
+// StdoutSink writes alerts as a single human-readable line to stdout.
+type StdoutSink struct{}
+
+// NewStdoutSink creates a StdoutSink.
+func NewStdoutSink() *StdoutSink {
+	return &StdoutSink{}
+}
+
+// Send implements Sink.
+func (s *StdoutSink) Send(alert Alert) error {
+	_, err := fmt.Printf("[%s] %s %s: %s\n", alert.Severity, alert.Reference, alert.Status, alert.Reason)
+	return err
+}
+
+// FileSink appends each alert as a line of NDJSON to a file.
+type FileSink struct {
+	Path string
+}
+
+// NewFileSink creates a FileSink that appends to path, creating it if needed.
+func NewFileSink(path string) *FileSink {
+	return &FileSink{Path: path}
+}
+
+// Send implements Sink.
+func (s *FileSink) Send(alert Alert) error {
+	data, err := json.Marshal(alert)
+	if err != nil {
+		return fmt.Errorf("file sink: failed to marshal alert: %w", err)
+	}
+
+	f, err := os.OpenFile(s.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("file sink: failed to open %s: %w", s.Path, err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("file sink: failed to write alert: %w", err)
+	}
+
+	return nil
+}
+
+// WebhookSink POSTs each alert as JSON to a URL.
+type WebhookSink struct {
+	URL        string
+	HTTPClient *http.Client
+}
+
+// NewWebhookSink creates a WebhookSink with a sane request timeout.
+func NewWebhookSink(url string) *WebhookSink {
+	return &WebhookSink{
+		URL:        url,
+		HTTPClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Send implements Sink.
+func (s *WebhookSink) Send(alert Alert) error {
+	data, err := json.Marshal(alert)
+	if err != nil {
+		return fmt.Errorf("webhook sink: failed to marshal alert: %w", err)
+	}
+
+	resp, err := s.HTTPClient.Post(s.URL, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("webhook sink: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook sink: unexpected status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// AlertEngine watches successive OverallScore computations and raises alerts
+// when a metric transitions into a degraded state, remains degraded across
+// consecutive evaluations, or goes stale.
+type AlertEngine struct {
+	sinks             []Sink
+	breachThreshold   int           // consecutive degraded runs required before alerting
+	staleTTL          time.Duration // how long a metric can go without an update before it's considered stale
+	consecutiveBreach map[string]int
+	lastUpdated       map[string]time.Time
+}
+
+// NewAlertEngine creates an AlertEngine that dispatches to the given sinks.
+// breachThreshold of 1 alerts on the first Yellow/Red observation; staleTTL
+// of 0 disables staleness detection.
+func NewAlertEngine(breachThreshold int, staleTTL time.Duration, sinks ...Sink) *AlertEngine {
+	if breachThreshold < 1 {
+		breachThreshold = 1
+	}
+	return &AlertEngine{
+		sinks:             sinks,
+		breachThreshold:   breachThreshold,
+		staleTTL:          staleTTL,
+		consecutiveBreach: make(map[string]int),
+		lastUpdated:       make(map[string]time.Time),
+	}
+}
+
+// alertEngineState is the on-disk representation of an AlertEngine's
+// accrual counters, persisted so "N consecutive breaches" survives across
+// separate CLI invocations.
+type alertEngineState struct {
+	ConsecutiveBreach map[string]int       `json:"consecutive_breach"`
+	LastUpdated       map[string]time.Time `json:"last_updated"`
+}
+
+// LoadAlertEngineState restores accrual counters previously persisted by
+// SaveState. A missing file is not an error; the engine simply starts fresh.
+func (e *AlertEngine) LoadAlertEngineState(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to read alert engine state: %w", err)
+	}
+
+	var state alertEngineState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return fmt.Errorf("failed to parse alert engine state: %w", err)
+	}
+
+	if state.ConsecutiveBreach != nil {
+		e.consecutiveBreach = state.ConsecutiveBreach
+	}
+	if state.LastUpdated != nil {
+		e.lastUpdated = state.LastUpdated
+	}
+
+	return nil
+}
+
+// SaveState persists the engine's accrual counters to path so the next
+// invocation can resume the "N consecutive breaches" count.
+func (e *AlertEngine) SaveState(path string) error {
+	state := alertEngineState{
+		ConsecutiveBreach: e.consecutiveBreach,
+		LastUpdated:       e.lastUpdated,
+	}
+
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal alert engine state: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write alert engine state: %w", err)
+	}
+
+	return nil
+}
+
+// Evaluate inspects overall for degraded or stale metrics and dispatches any
+// resulting alerts to every configured sink, returning the alerts raised.
+func (e *AlertEngine) Evaluate(overall *OverallScore, metrics []Metric) ([]Alert, error) {
+	now := time.Now()
+
+	for _, m := range metrics {
+		e.lastUpdated[m.Reference] = m.Timestamp
+	}
+
+	var alerts []Alert
+
+	for _, category := range overall.Categories {
+		for _, metric := range category.Metrics {
+			if metric.Status == Green {
+				e.consecutiveBreach[metric.Reference] = 0
+				continue
+			}
+
+			e.consecutiveBreach[metric.Reference]++
+
+			if e.consecutiveBreach[metric.Reference] < e.breachThreshold {
+				continue
+			}
+
+			severity := AlertWarning
+			if metric.Status == Red {
+				severity = AlertCritical
+			}
+
+			reason := metric.Violation
+			if reason == "" {
+				reason = fmt.Sprintf("metric is in %s status for %d consecutive run(s)", metric.Status, e.consecutiveBreach[metric.Reference])
+			}
+
+			alerts = append(alerts, Alert{
+				Reference: metric.Reference,
+				Category:  category.ID,
+				Severity:  severity,
+				Reason:    reason,
+				Score:     metric.Score,
+				Status:    string(metric.Status),
+				Timestamp: now,
+			})
+
+			if e.staleTTL > 0 {
+				if last, ok := e.lastUpdated[metric.Reference]; ok && now.Sub(last) > e.staleTTL {
+					alerts = append(alerts, Alert{
+						Reference: metric.Reference,
+						Category:  category.ID,
+						Severity:  AlertWarning,
+						Reason:    fmt.Sprintf("metric has not been updated since %s", last.Format(time.RFC3339)),
+						Score:     metric.Score,
+						Status:    string(metric.Status),
+						Timestamp: now,
+					})
+				}
+			}
+		}
+	}
+
+	for _, alert := range alerts {
+		for _, sink := range e.sinks {
+			if err := sink.Send(alert); err != nil {
+				return alerts, fmt.Errorf("failed to dispatch alert for %s: %w", alert.Reference, err)
+			}
+		}
+	}
+
+	return alerts, nil
+}
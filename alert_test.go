@@ -0,0 +1,88 @@
+package pulse
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestAlertEngineConsecutiveBreach(t *testing.T) {
+	engine := NewAlertEngine(2, 0)
+
+	overall := &OverallScore{
+		Categories: []CategoryScore{
+			{
+				ID: "app_sec",
+				Metrics: []MetricScore{
+					{Reference: "app_sec.KRI.open_vulns", Score: 40, Status: Red, Violation: "value 40.00 is below target"},
+				},
+			},
+		},
+	}
+
+	alerts, err := engine.Evaluate(overall, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(alerts) != 0 {
+		t.Fatalf("expected no alert below breach threshold, got %d", len(alerts))
+	}
+
+	alerts, err = engine.Evaluate(overall, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(alerts) != 1 {
+		t.Fatalf("expected one alert once breach threshold is reached, got %d", len(alerts))
+	}
+	if alerts[0].Severity != AlertCritical {
+		t.Fatalf("expected critical severity for a Red status, got %s", alerts[0].Severity)
+	}
+}
+
+func TestAlertEngineStatePersistence(t *testing.T) {
+	dir := t.TempDir()
+	statePath := filepath.Join(dir, "alerts_state.json")
+
+	engine := NewAlertEngine(2, 0)
+	overall := &OverallScore{
+		Categories: []CategoryScore{
+			{
+				ID: "app_sec",
+				Metrics: []MetricScore{
+					{Reference: "app_sec.KRI.open_vulns", Score: 40, Status: Red},
+				},
+			},
+		},
+	}
+
+	if _, err := engine.Evaluate(overall, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := engine.SaveState(statePath); err != nil {
+		t.Fatalf("failed to save state: %v", err)
+	}
+	if _, err := os.Stat(statePath); err != nil {
+		t.Fatalf("expected state file to exist: %v", err)
+	}
+
+	restored := NewAlertEngine(2, 0)
+	if err := restored.LoadAlertEngineState(statePath); err != nil {
+		t.Fatalf("failed to load state: %v", err)
+	}
+
+	alerts, err := restored.Evaluate(overall, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(alerts) != 1 {
+		t.Fatalf("expected restored engine to alert on the second consecutive breach, got %d", len(alerts))
+	}
+}
+
+func TestAlertEngineLoadMissingState(t *testing.T) {
+	engine := NewAlertEngine(1, 0)
+	if err := engine.LoadAlertEngineState(filepath.Join(t.TempDir(), "missing.json")); err != nil {
+		t.Fatalf("expected missing state file to be a no-op, got error: %v", err)
+	}
+}
@@ -0,0 +1,311 @@
+package pulse
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// APIServer exposes pulse's report, metrics, categories, and levers data
+// (plus metric updates and config validation) as a JSON REST API under
+// /api, alongside the Prometheus /metrics and /healthz endpoints already
+// served by Exporter. Every request reloads config and data from disk, the
+// same way the CLI does, so the API always reflects the latest state.
+type APIServer struct {
+	configLoader  *ConfigLoader
+	scoringMethod ScoringMethod
+	authToken     string
+}
+
+// APIServerOption configures optional APIServer behavior.
+type APIServerOption func(*APIServer)
+
+// WithAuthToken requires every /api request to present the given bearer
+// token in its Authorization header, rejecting requests that don't with
+// 401 Unauthorized. The Prometheus /metrics and /healthz endpoints are
+// unaffected, matching scrape tooling that can't supply custom headers.
+func WithAuthToken(token string) APIServerOption {
+	return func(s *APIServer) {
+		s.authToken = token
+	}
+}
+
+// NewAPIServer creates an APIServer backed by configLoader, scoring reports
+// with the given ScoringMethod.
+func NewAPIServer(configLoader *ConfigLoader, scoringMethod ScoringMethod, opts ...APIServerOption) *APIServer {
+	s := &APIServer{configLoader: configLoader, scoringMethod: scoringMethod}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Handler returns an http.Handler serving the REST API under /api, as well
+// as exporter's Prometheus /metrics and /healthz endpoints.
+func (s *APIServer) Handler(exporter *Exporter) http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", exporter.MetricsHandler())
+	mux.Handle("/healthz", exporter.HealthzHandler())
+	mux.HandleFunc("/api/report", s.authed(s.handleReport))
+	mux.HandleFunc("/api/report/", s.authed(s.handleCategoryReport))
+	mux.HandleFunc("/api/metrics", s.authed(s.handleListMetrics))
+	mux.HandleFunc("/api/metrics/", s.authed(s.handleUpdateMetric))
+	mux.HandleFunc("/api/categories", s.authed(s.handleCategories))
+	mux.HandleFunc("/api/levers", s.authed(s.handleLevers))
+	mux.HandleFunc("/api/validate", s.authed(s.handleValidate))
+	return mux
+}
+
+// authed wraps next with bearer-token auth, when WithAuthToken was used.
+func (s *APIServer) authed(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if s.authToken != "" {
+			header := r.Header.Get("Authorization")
+			if header != "Bearer "+s.authToken {
+				writeAPIError(w, http.StatusUnauthorized, "missing or invalid bearer token")
+				return
+			}
+		}
+		next(w, r)
+	}
+}
+
+// load reads config and data from disk and builds a MetricsProcessor and
+// ScoreCalculator, mirroring what each CLI command does at the start of its
+// Run function.
+func (s *APIServer) load() (*ConfigLoader, *MetricsData, *ScoreCalculator, error) {
+	metricsConfig, err := s.configLoader.LoadMetricsConfig()
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to load metrics config: %w", err)
+	}
+
+	leversConfig, err := s.configLoader.LoadLeversConfig()
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to load levers config: %w", err)
+	}
+
+	metricsData, err := s.configLoader.LoadMetricsData()
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to load metrics data: %w", err)
+	}
+
+	processor := NewMetricsProcessor(metricsConfig, leversConfig, metricsData)
+	return s.configLoader, metricsData, NewScoreCalculator(processor, s.scoringMethod), nil
+}
+
+func (s *APIServer) handleReport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeAPIError(w, http.StatusMethodNotAllowed, "GET only")
+		return
+	}
+
+	_, _, scoreCalculator, err := s.load()
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	reportGenerator := NewReportGenerator(scoreCalculator, TextLabels)
+	output, err := reportGenerator.GenerateOverallReport(JSONFormat)
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeAPIJSON(w, http.StatusOK, output.Content)
+}
+
+func (s *APIServer) handleCategoryReport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeAPIError(w, http.StatusMethodNotAllowed, "GET only")
+		return
+	}
+
+	categoryID := strings.TrimPrefix(r.URL.Path, "/api/report/")
+	if categoryID == "" {
+		writeAPIError(w, http.StatusBadRequest, "missing category ID")
+		return
+	}
+
+	_, _, scoreCalculator, err := s.load()
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	reportGenerator := NewReportGenerator(scoreCalculator, TextLabels)
+	output, err := reportGenerator.GenerateCategoryReport(categoryID, JSONFormat)
+	if err != nil {
+		writeAPIError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	writeAPIJSON(w, http.StatusOK, output.Content)
+}
+
+func (s *APIServer) handleListMetrics(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeAPIError(w, http.StatusMethodNotAllowed, "GET only")
+		return
+	}
+
+	_, metricsData, _, err := s.load()
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeAPIValue(w, http.StatusOK, metricsData.Metrics)
+}
+
+func (s *APIServer) handleCategories(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeAPIError(w, http.StatusMethodNotAllowed, "GET only")
+		return
+	}
+
+	_, _, scoreCalculator, err := s.load()
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeAPIValue(w, http.StatusOK, scoreCalculator.metricsProcessor.GetAllCategories())
+}
+
+func (s *APIServer) handleLevers(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeAPIError(w, http.StatusMethodNotAllowed, "GET only")
+		return
+	}
+
+	leversConfig, err := s.configLoader.LoadLeversConfig()
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeAPIValue(w, http.StatusOK, leversConfig)
+}
+
+// apiUpdateMetricRequest is the POST /api/metrics/{ref} request body.
+type apiUpdateMetricRequest struct {
+	Value  float64 `json:"value"`
+	Author string  `json:"author,omitempty"`
+}
+
+// apiUpdateMetricResponse is the POST /api/metrics/{ref} response body.
+type apiUpdateMetricResponse struct {
+	Reference string  `json:"reference"`
+	Value     float64 `json:"value"`
+}
+
+func (s *APIServer) handleUpdateMetric(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeAPIError(w, http.StatusMethodNotAllowed, "POST only")
+		return
+	}
+
+	reference := strings.TrimPrefix(r.URL.Path, "/api/metrics/")
+	if reference == "" {
+		writeAPIError(w, http.StatusBadRequest, "missing metric reference")
+		return
+	}
+
+	var req apiUpdateMetricRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeAPIError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+		return
+	}
+
+	configLoader, metricsData, scoreCalculator, err := s.load()
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if err := scoreCalculator.metricsProcessor.UpdateMetricWithAuthor(reference, req.Value, req.Author); err != nil {
+		writeAPIError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := configLoader.SaveMetricsData(metricsData); err != nil {
+		writeAPIError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeAPIValue(w, http.StatusOK, apiUpdateMetricResponse{Reference: reference, Value: req.Value})
+}
+
+// apiValidationResponse is the POST /api/validate response body, mirroring
+// the CLI's "validate --format json" shape.
+type apiValidationResponse struct {
+	Status string            `json:"status"`
+	Checks []ValidationCheck `json:"checks"`
+}
+
+func (s *APIServer) handleValidate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeAPIError(w, http.StatusMethodNotAllowed, "POST only")
+		return
+	}
+
+	leversConfig, err := s.configLoader.LoadLeversConfig()
+	if err != nil {
+		writeAPIValue(w, http.StatusOK, apiValidationResponse{
+			Status: "fail",
+			Checks: []ValidationCheck{{Name: string(ErrConfigMissing), OK: false, Details: err.Error()}},
+		})
+		return
+	}
+
+	checks := append([]ValidationCheck{ValidateWeights(leversConfig)}, ValidateThresholds(leversConfig)...)
+	status := "pass"
+	for _, check := range checks {
+		if !check.OK {
+			status = "fail"
+			break
+		}
+	}
+	writeAPIValue(w, http.StatusOK, apiValidationResponse{Status: status, Checks: checks})
+}
+
+// writeAPIError writes a {"error": message} JSON body with the given status.
+func writeAPIError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(struct {
+		Error string `json:"error"`
+	}{Error: message})
+}
+
+// writeAPIJSON writes already-encoded JSON content with the given status.
+func writeAPIJSON(w http.ResponseWriter, status int, content []byte) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(content)
+}
+
+// writeAPIValue JSON-encodes value and writes it with the given status.
+func writeAPIValue(w http.ResponseWriter, status int, value interface{}) {
+	content, err := json.Marshal(value)
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeAPIJSON(w, status, content)
+}
+
+// Serve starts an HTTP(S) server exposing the REST API alongside exporter's
+// Prometheus /metrics and /healthz endpoints, and blocks until it returns
+// an error.
+func (s *APIServer) Serve(exporter *Exporter, cfg ServeConfig) error {
+	server := &http.Server{
+		Addr:    cfg.Addr,
+		Handler: s.Handler(exporter),
+	}
+
+	if cfg.CertFile != "" && cfg.KeyFile != "" {
+		server.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+		return server.ListenAndServeTLS(cfg.CertFile, cfg.KeyFile)
+	}
+
+	return server.ListenAndServe()
+}
@@ -0,0 +1,197 @@
+package pulse
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func newAPITestConfigLoader(t *testing.T) *ConfigLoader {
+	t.Helper()
+
+	configDir := t.TempDir()
+	dataDir := t.TempDir()
+
+	metricsYAML := `categories:
+  - id: app_sec
+    name: Application Security
+    kpis:
+      - id: coverage
+        name: Test Coverage
+        unit: percent
+        scoring_bands:
+          - min: 80
+            score: 100
+          - max: 79
+            score: 50
+`
+	if err := os.WriteFile(filepath.Join(configDir, "metrics.yaml"), []byte(metricsYAML), 0600); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+
+	leversYAML := `global:
+  thresholds:
+    green:
+      min: 80
+      max: 100
+    yellow:
+      min: 50
+      max: 79
+    red:
+      min: 0
+      max: 49
+  kpi_thresholds:
+    green:
+      min: 80
+      max: 100
+    yellow:
+      min: 50
+      max: 79
+    red:
+      min: 0
+      max: 49
+weights:
+  categories:
+    app_sec: 1.0
+`
+	if err := os.WriteFile(filepath.Join(configDir, "levers.yaml"), []byte(leversYAML), 0600); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+
+	dataYAML := `metrics:
+  - reference: app_sec.KPI.coverage
+    value: 87
+    timestamp: 2026-01-01T00:00:00Z
+`
+	if err := os.WriteFile(filepath.Join(dataDir, "app_sec.yaml"), []byte(dataYAML), 0600); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+
+	return NewConfigLoader(configDir, dataDir)
+}
+
+func TestAPIServerReportAndMetrics(t *testing.T) {
+	configLoader := newAPITestConfigLoader(t)
+	exporter := NewExporter(configLoader)
+	server := NewAPIServer(configLoader, MedianScoring)
+	handler := server.Handler(exporter)
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/report", nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if !strings.Contains(recorder.Body.String(), `"kpi_score"`) {
+		t.Fatalf("expected an overall report body, got: %s", recorder.Body.String())
+	}
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/report/app_sec", nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if !strings.Contains(recorder.Body.String(), `"category_id"`) {
+		t.Fatalf("expected a category report body, got: %s", recorder.Body.String())
+	}
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/report/bogus", nil))
+	if recorder.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for an unknown category, got %d", recorder.Code)
+	}
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/categories", nil))
+	if recorder.Code != http.StatusOK || !strings.Contains(recorder.Body.String(), "app_sec") {
+		t.Fatalf("expected categories JSON, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if recorder.Code != http.StatusOK || !strings.Contains(recorder.Body.String(), "pulse_kpi_value") {
+		t.Fatalf("expected the Prometheus exporter to still serve /metrics, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+}
+
+func TestAPIServerUpdateMetric(t *testing.T) {
+	configLoader := newAPITestConfigLoader(t)
+	exporter := NewExporter(configLoader)
+	server := NewAPIServer(configLoader, MedianScoring)
+	handler := server.Handler(exporter)
+
+	body := strings.NewReader(`{"value": 95, "author": "ci"}`)
+	request := httptest.NewRequest(http.MethodPost, "/api/metrics/app_sec.KPI.coverage", body)
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	var response apiUpdateMetricResponse
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	if response.Value != 95 {
+		t.Fatalf("expected the update to echo value 95, got %v", response.Value)
+	}
+
+	metricsData, err := configLoader.LoadMetricsData()
+	if err != nil {
+		t.Fatalf("failed to reload metrics data: %v", err)
+	}
+	if metricsData.Metrics[0].Value != 95 {
+		t.Fatalf("expected the metric update to be persisted, got %v", metricsData.Metrics[0].Value)
+	}
+}
+
+func TestAPIServerValidate(t *testing.T) {
+	configLoader := newAPITestConfigLoader(t)
+	exporter := NewExporter(configLoader)
+	server := NewAPIServer(configLoader, MedianScoring)
+	handler := server.Handler(exporter)
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/api/validate", nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	var response apiValidationResponse
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	if response.Status != "pass" {
+		t.Fatalf("expected validation to pass for a single 100%%-weighted category, got %q: %+v", response.Status, response.Checks)
+	}
+}
+
+func TestAPIServerRequiresAuthToken(t *testing.T) {
+	configLoader := newAPITestConfigLoader(t)
+	exporter := NewExporter(configLoader)
+	server := NewAPIServer(configLoader, MedianScoring, WithAuthToken("secret"))
+	handler := server.Handler(exporter)
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/report", nil))
+	if recorder.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 without a bearer token, got %d", recorder.Code)
+	}
+
+	request := httptest.NewRequest(http.MethodGet, "/api/report", nil)
+	request.Header.Set("Authorization", "Bearer secret")
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected 200 with a valid bearer token, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected /metrics to remain unauthenticated, got %d", recorder.Code)
+	}
+}
@@ -0,0 +1,176 @@
+package pulse
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// BatchFormat identifies how a batch of metric observations is encoded for
+// ParseBatch.
+type BatchFormat string
+
+const (
+	CSVBatch  BatchFormat = "csv"
+	JSONBatch BatchFormat = "json"
+	YAMLBatch BatchFormat = "yaml"
+)
+
+// BatchRecord is one metric observation read from a batch file: a metric
+// Reference, its Value, and an optional Timestamp. BatchIngester.Apply does
+// not use Timestamp: every applied record is stamped with the time
+// UpdateMetricWithAuthor runs, whether or not Timestamp is set.
+type BatchRecord struct {
+	Reference string    `json:"reference" yaml:"reference"`
+	Value     float64   `json:"value" yaml:"value"`
+	Timestamp time.Time `json:"timestamp,omitempty" yaml:"timestamp,omitempty"`
+}
+
+// ParseBatch reads a batch of BatchRecords from r in the given format. CSV
+// batches require a header row naming "reference" and "value", and may
+// optionally include a "timestamp" column in RFC3339 format.
+func ParseBatch(format BatchFormat, r io.Reader) ([]BatchRecord, error) {
+	switch format {
+	case JSONBatch:
+		var records []BatchRecord
+		if err := json.NewDecoder(r).Decode(&records); err != nil {
+			return nil, fmt.Errorf("failed to parse JSON batch: %w", err)
+		}
+		return records, nil
+	case YAMLBatch:
+		var records []BatchRecord
+		if err := yaml.NewDecoder(r).Decode(&records); err != nil {
+			return nil, fmt.Errorf("failed to parse YAML batch: %w", err)
+		}
+		return records, nil
+	case CSVBatch:
+		return parseCSVBatch(r)
+	default:
+		return nil, fmt.Errorf("unknown batch format: %s", format)
+	}
+}
+
+// parseCSVBatch implements ParseBatch for BatchFormat CSVBatch.
+func parseCSVBatch(r io.Reader) ([]BatchRecord, error) {
+	rows, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CSV batch: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	col := make(map[string]int, len(rows[0]))
+	for i, name := range rows[0] {
+		col[name] = i
+	}
+	refIdx, ok := col["reference"]
+	if !ok {
+		return nil, fmt.Errorf(`CSV batch header missing required "reference" column`)
+	}
+	valIdx, ok := col["value"]
+	if !ok {
+		return nil, fmt.Errorf(`CSV batch header missing required "value" column`)
+	}
+	tsIdx, hasTimestamp := col["timestamp"]
+
+	records := make([]BatchRecord, 0, len(rows)-1)
+	for _, row := range rows[1:] {
+		value, err := strconv.ParseFloat(row[valIdx], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value %q for reference %q: %w", row[valIdx], row[refIdx], err)
+		}
+
+		record := BatchRecord{Reference: row[refIdx], Value: value}
+		if hasTimestamp && row[tsIdx] != "" {
+			ts, err := time.Parse(time.RFC3339, row[tsIdx])
+			if err != nil {
+				return nil, fmt.Errorf("invalid timestamp %q for reference %q: %w", row[tsIdx], row[refIdx], err)
+			}
+			record.Timestamp = ts
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// BatchResult reports the validation outcome of one BatchRecord from a
+// BatchIngester.Validate or BatchIngester.Apply call. Error is nil when
+// the record passed validation.
+type BatchResult struct {
+	Record BatchRecord
+	Error  error
+}
+
+// BatchIngester applies a batch of BatchRecords to a MetricsProcessor
+// transactionally: every record must pass the same reference-format and
+// value-bounds validation already enforced by UpdateMetricWithAuthor
+// before any of them are applied, so a single bad row in a bulk telemetry
+// feed can't leave metricsData half-updated.
+type BatchIngester struct {
+	processor *MetricsProcessor
+}
+
+// NewBatchIngester creates a BatchIngester that applies batches to processor.
+func NewBatchIngester(processor *MetricsProcessor) *BatchIngester {
+	return &BatchIngester{processor: processor}
+}
+
+// Validate checks every record's reference format and value bounds without
+// applying any of them, returning one BatchResult per record in order.
+func (b *BatchIngester) Validate(records []BatchRecord) []BatchResult {
+	results := make([]BatchResult, len(records))
+	for i, record := range records {
+		results[i] = BatchResult{Record: record, Error: b.validateRecord(record)}
+	}
+	return results
+}
+
+// Apply validates every record and, only if all of them pass, applies each
+// via MetricsProcessor.UpdateMetricWithAuthor. If any record fails
+// validation, none are applied. The returned []BatchResult always has one
+// entry per input record, in order, reporting which passed validation.
+func (b *BatchIngester) Apply(records []BatchRecord, author string) ([]BatchResult, error) {
+	results := b.Validate(records)
+
+	var failed int
+	for _, result := range results {
+		if result.Error != nil {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return results, fmt.Errorf("batch rejected: %d of %d records failed validation", failed, len(results))
+	}
+
+	for _, record := range records {
+		if err := b.processor.UpdateMetricWithAuthor(record.Reference, record.Value, author); err != nil {
+			return results, fmt.Errorf("failed to apply record %s: %w", record.Reference, err)
+		}
+	}
+
+	return results, nil
+}
+
+// validateRecord applies the same reference-format and value-bounds checks
+// the CLI's non-batch "update" command enforces, validating the reference
+// against b.processor's configured ReferenceSchema.
+func (b *BatchIngester) validateRecord(record BatchRecord) error {
+	refErrors, err := b.processor.ValidateReference(record.Reference)
+	if err != nil {
+		return fmt.Errorf("invalid reference_schema configuration: %w", err)
+	}
+	if len(refErrors) > 0 {
+		return fmt.Errorf("invalid metric reference %q: %s", record.Reference, joinReferenceErrors(refErrors))
+	}
+	if math.IsNaN(record.Value) || math.IsInf(record.Value, 0) || record.Value < -1000000 || record.Value > 1000000 {
+		return fmt.Errorf("value %v out of reasonable bounds", record.Value)
+	}
+	return nil
+}
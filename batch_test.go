@@ -0,0 +1,82 @@
+package pulse
+
+import (
+	"strings"
+	"testing"
+)
+
+func newBatchTestProcessor(t *testing.T) *MetricsProcessor {
+	t.Helper()
+
+	metricsConfig := &MetricsConfig{Categories: []Category{{ID: "app_sec", KPIs: []KPI{{ID: "coverage"}}}}}
+	leversConfig := &LeversConfig{}
+	metricsData := &MetricsData{}
+	return NewMetricsProcessor(metricsConfig, leversConfig, metricsData)
+}
+
+func TestParseBatchJSON(t *testing.T) {
+	records, err := ParseBatch(JSONBatch, strings.NewReader(`[{"reference":"app_sec.KPI.coverage","value":95}]`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(records) != 1 || records[0].Reference != "app_sec.KPI.coverage" || records[0].Value != 95 {
+		t.Fatalf("unexpected records: %+v", records)
+	}
+}
+
+func TestParseBatchCSV(t *testing.T) {
+	records, err := ParseBatch(CSVBatch, strings.NewReader("reference,value\napp_sec.KPI.coverage,95\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(records) != 1 || records[0].Value != 95 {
+		t.Fatalf("unexpected records: %+v", records)
+	}
+}
+
+func TestParseBatchCSVMissingColumn(t *testing.T) {
+	if _, err := ParseBatch(CSVBatch, strings.NewReader("reference\napp_sec.KPI.coverage\n")); err == nil {
+		t.Fatal("expected an error for a missing value column")
+	}
+}
+
+func TestBatchIngesterApplyRejectsWholeBatchOnFailure(t *testing.T) {
+	processor := newBatchTestProcessor(t)
+	ingester := NewBatchIngester(processor)
+
+	records := []BatchRecord{
+		{Reference: "app_sec.KPI.coverage", Value: 95},
+		{Reference: "bogus", Value: 10},
+	}
+
+	results, err := ingester.Apply(records, "")
+	if err == nil {
+		t.Fatal("expected an error when one record fails validation")
+	}
+	if len(results) != 2 || results[0].Error != nil || results[1].Error == nil {
+		t.Fatalf("unexpected results: %+v", results)
+	}
+	if _, getErr := processor.GetMetricByReference("app_sec.KPI.coverage"); getErr == nil {
+		t.Fatal("expected no records to be applied when the batch is rejected")
+	}
+}
+
+func TestBatchIngesterApplySucceeds(t *testing.T) {
+	processor := newBatchTestProcessor(t)
+	ingester := NewBatchIngester(processor)
+
+	records := []BatchRecord{{Reference: "app_sec.KPI.coverage", Value: 95}}
+
+	results, err := ingester.Apply(records, "ci")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != 1 || results[0].Error != nil {
+		t.Fatalf("unexpected results: %+v", results)
+	}
+
+	metric, getErr := processor.GetMetricByReference("app_sec.KPI.coverage")
+	if getErr != nil || metric.Value != 95 {
+		t.Fatalf("expected the metric to be applied, got %+v, %v", metric, getErr)
+	}
+}
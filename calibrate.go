@@ -0,0 +1,209 @@
+package pulse
+
+import (
+	"fmt"
+	"sort"
+)
+
+// CalibrationMethod selects how CalibrateThresholds derives Green/Yellow/Red
+// cutoffs from a corpus of historical scores.
+type CalibrationMethod string
+
+const (
+	// QuantileCalibration places the Red/Yellow and Yellow/Green boundaries
+	// at the targetRedShare and targetRedShare+defaultTargetYellowShare
+	// percentiles of the score corpus. This is the default.
+	QuantileCalibration CalibrationMethod = "quantile"
+	// KMeansCalibration clusters the score corpus into 3 groups (k=3, 1D
+	// Lloyd's algorithm) and cuts at the midpoints between adjacent cluster
+	// centroids.
+	KMeansCalibration CalibrationMethod = "kmeans"
+	// JenksCalibration partitions the score corpus into 3 classes using
+	// Jenks natural breaks, the pair of cut points that minimizes
+	// within-class variance.
+	JenksCalibration CalibrationMethod = "jenks"
+)
+
+// defaultTargetRedShare is the fraction of the score corpus CalibrateThresholds
+// assigns to Red when called with a targetRedShare outside (0,1).
+const defaultTargetRedShare = 0.2
+
+// defaultTargetYellowShare is the fraction of the score corpus QuantileCalibration
+// assigns to Yellow above Red's share, leaving the remainder to Green.
+const defaultTargetYellowShare = 0.4
+
+// CalibrateThresholds proposes a Thresholds set from scores, an empirical
+// corpus of past metric/category/overall scores (e.g. gathered by replaying
+// a HistoryStore through the current scoring bands via
+// ScoreCalculator.CalculateScoreSeries), using method. targetRedShare is the
+// fraction of scores QuantileCalibration should assign to Red; values
+// outside (0,1) fall back to defaultTargetRedShare, and are ignored
+// entirely by KMeansCalibration and JenksCalibration, which derive their own
+// split from the distribution's natural clustering. It returns an error if
+// scores is empty.
+func CalibrateThresholds(scores []int, method CalibrationMethod, targetRedShare float64) (Thresholds, error) {
+	if len(scores) == 0 {
+		return Thresholds{}, fmt.Errorf("no scores to calibrate from")
+	}
+	if targetRedShare <= 0 || targetRedShare >= 1 {
+		targetRedShare = defaultTargetRedShare
+	}
+
+	sorted := append([]int(nil), scores...)
+	sort.Ints(sorted)
+
+	var redMax, yellowMax int
+	switch method {
+	case KMeansCalibration:
+		redMax, yellowMax = kMeansCutoffs(sorted)
+	case JenksCalibration:
+		redMax, yellowMax = jenksCutoffs(sorted)
+	default:
+		redMax, yellowMax = quantileCutoffs(sorted, targetRedShare)
+	}
+
+	if yellowMax <= redMax {
+		yellowMax = redMax + 1
+	}
+	if yellowMax > 99 {
+		yellowMax = 99
+	}
+
+	return Thresholds{
+		Red:    ThresholdRange{Min: 0, Max: redMax},
+		Yellow: ThresholdRange{Min: redMax + 1, Max: yellowMax},
+		Green:  ThresholdRange{Min: yellowMax + 1, Max: 100},
+	}, nil
+}
+
+// quantileCutoffs returns the Red/Yellow boundary (the score at the
+// targetRedShare percentile of sorted) and the Yellow/Green boundary (the
+// score at targetRedShare+defaultTargetYellowShare), each clamped to [0,99].
+func quantileCutoffs(sorted []int, targetRedShare float64) (redMax, yellowMax int) {
+	return clampScore(percentileOfScores(sorted, targetRedShare)), clampScore(percentileOfScores(sorted, targetRedShare+defaultTargetYellowShare))
+}
+
+// percentileOfScores returns the value at percentile p (0-1) of a pre-sorted
+// ascending slice, using nearest-rank interpolation.
+func percentileOfScores(sorted []int, p float64) int {
+	if p <= 0 {
+		return sorted[0]
+	}
+	if p >= 1 {
+		return sorted[len(sorted)-1]
+	}
+	idx := int(p * float64(len(sorted)-1))
+	return sorted[idx]
+}
+
+// clampScore restricts score to [0,99], the valid range for a band's Max
+// when another band must still occupy at least the score above it.
+func clampScore(score int) int {
+	if score < 0 {
+		return 0
+	}
+	if score > 99 {
+		return 99
+	}
+	return score
+}
+
+// kMeansCutoffs clusters sorted into 3 groups via 1D Lloyd's algorithm
+// (k-means), returning the midpoints between adjacent cluster centroids as
+// the Red/Yellow and Yellow/Green boundaries.
+func kMeansCutoffs(sorted []int) (redMax, yellowMax int) {
+	n := len(sorted)
+	if n < 3 {
+		return quantileCutoffs(sorted, 1.0/3)
+	}
+
+	centroids := []float64{float64(sorted[0]), float64(sorted[n/2]), float64(sorted[n-1])}
+
+	for iter := 0; iter < 25; iter++ {
+		var sums [3]float64
+		var counts [3]int
+		for _, v := range sorted {
+			c := nearestCentroid(float64(v), centroids)
+			sums[c] += float64(v)
+			counts[c]++
+		}
+
+		moved := false
+		for i := 0; i < 3; i++ {
+			if counts[i] == 0 {
+				continue
+			}
+			mean := sums[i] / float64(counts[i])
+			if mean != centroids[i] {
+				moved = true
+			}
+			centroids[i] = mean
+		}
+		if !moved {
+			break
+		}
+	}
+
+	sort.Float64s(centroids)
+	return clampScore(int((centroids[0] + centroids[1]) / 2)), clampScore(int((centroids[1] + centroids[2]) / 2))
+}
+
+// nearestCentroid returns the index of the centroid closest to v.
+func nearestCentroid(v float64, centroids []float64) int {
+	best, bestDist := 0, -1.0
+	for i, c := range centroids {
+		dist := v - c
+		if dist < 0 {
+			dist = -dist
+		}
+		if bestDist < 0 || dist < bestDist {
+			best, bestDist = i, dist
+		}
+	}
+	return best
+}
+
+// jenksCutoffs partitions sorted into 3 classes using Jenks natural breaks,
+// an exhaustive search (O(n^2), fine for the modest score corpora pulse
+// deals with) for the pair of cut points that minimizes total within-class
+// variance, returning them as the Red/Yellow and Yellow/Green boundaries.
+func jenksCutoffs(sorted []int) (redMax, yellowMax int) {
+	n := len(sorted)
+	if n < 3 {
+		return quantileCutoffs(sorted, 1.0/3)
+	}
+
+	prefixSum := make([]float64, n+1)
+	prefixSumSq := make([]float64, n+1)
+	for i, v := range sorted {
+		fv := float64(v)
+		prefixSum[i+1] = prefixSum[i] + fv
+		prefixSumSq[i+1] = prefixSumSq[i] + fv*fv
+	}
+
+	variance := func(from, to int) float64 {
+		count := float64(to - from)
+		if count == 0 {
+			return 0
+		}
+		sum := prefixSum[to] - prefixSum[from]
+		sumSq := prefixSumSq[to] - prefixSumSq[from]
+		mean := sum / count
+		return sumSq - 2*mean*sum + count*mean*mean
+	}
+
+	bestScore := -1.0
+	bestI, bestJ := n/3, 2*n/3
+
+	for i := 1; i < n-1; i++ {
+		for j := i + 1; j < n; j++ {
+			total := variance(0, i) + variance(i, j) + variance(j, n)
+			if bestScore < 0 || total < bestScore {
+				bestScore = total
+				bestI, bestJ = i, j
+			}
+		}
+	}
+
+	return clampScore(sorted[bestI-1]), clampScore(sorted[bestJ-1])
+}
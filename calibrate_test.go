@@ -0,0 +1,64 @@
+package pulse
+
+import "testing"
+
+func TestCalibrateThresholdsQuantile(t *testing.T) {
+	var scores []int
+	for i := 0; i < 100; i++ {
+		scores = append(scores, i)
+	}
+
+	thresholds, err := CalibrateThresholds(scores, QuantileCalibration, 0.2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if thresholds.Red.Max >= thresholds.Yellow.Min {
+		t.Fatalf("expected Red to end before Yellow starts, got Red.Max=%d Yellow.Min=%d", thresholds.Red.Max, thresholds.Yellow.Min)
+	}
+	if thresholds.Yellow.Max >= thresholds.Green.Min {
+		t.Fatalf("expected Yellow to end before Green starts, got Yellow.Max=%d Green.Min=%d", thresholds.Yellow.Max, thresholds.Green.Min)
+	}
+	if thresholds.Green.Max != 100 {
+		t.Fatalf("expected Green to reach 100, got %d", thresholds.Green.Max)
+	}
+
+	checks := ValidateThresholds(&LeversConfig{Global: Global{Thresholds: thresholds}})
+	for _, check := range checks {
+		if !check.OK {
+			t.Errorf("expected calibrated thresholds to pass %s, got %+v", check.Name, check)
+		}
+	}
+}
+
+func TestCalibrateThresholdsRejectsEmptyCorpus(t *testing.T) {
+	if _, err := CalibrateThresholds(nil, QuantileCalibration, 0.2); err == nil {
+		t.Fatal("expected an error for an empty score corpus")
+	}
+}
+
+func TestCalibrateThresholdsKMeansSeparatesClusters(t *testing.T) {
+	scores := []int{5, 8, 10, 12, 50, 55, 52, 90, 95, 98}
+
+	thresholds, err := CalibrateThresholds(scores, KMeansCalibration, 0.2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if thresholds.Red.Max >= 50 || thresholds.Green.Min <= 55 {
+		t.Fatalf("expected k-means to separate the three clusters around the gaps, got %+v", thresholds)
+	}
+}
+
+func TestCalibrateThresholdsJenks(t *testing.T) {
+	scores := []int{1, 2, 3, 40, 41, 42, 90, 91, 92}
+
+	thresholds, err := CalibrateThresholds(scores, JenksCalibration, 0.2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if thresholds.Red.Max >= 40 || thresholds.Green.Min <= 42 {
+		t.Fatalf("expected jenks breaks to fall in the gaps between clusters, got %+v", thresholds)
+	}
+}
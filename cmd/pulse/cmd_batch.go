@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/scottbrown/pulse"
+)
+
+// runUpdateBatch reads a batch of metric observations from --from-file (or
+// stdin, when it's "-") and either previews them with --dry-run or applies
+// them transactionally through a pulse.BatchIngester.
+func runUpdateBatch(configLoader *pulse.ConfigLoader, metricsProcessor *pulse.MetricsProcessor, metricsData *pulse.MetricsData) {
+	var reader io.Reader
+	if updateFromFile == "-" {
+		reader = os.Stdin
+	} else {
+		file, err := os.Open(updateFromFile)
+		if err != nil {
+			fmt.Printf("Error opening batch file: %v\n", err)
+			os.Exit(1)
+		}
+		defer file.Close()
+		reader = file
+	}
+
+	format := pulse.BatchFormat(updateBatchFormat)
+	if format == "" {
+		format = inferBatchFormat(updateFromFile)
+	}
+
+	records, err := pulse.ParseBatch(format, reader)
+	if err != nil {
+		fmt.Printf("Error parsing batch: %v\n", err)
+		os.Exit(1)
+	}
+
+	ingester := pulse.NewBatchIngester(metricsProcessor)
+
+	if updateDryRun {
+		printBatchResults(ingester.Validate(records), true)
+		return
+	}
+
+	results, err := ingester.Apply(records, updateAuthor)
+	printBatchResults(results, false)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	if err := configLoader.SaveMetricsData(metricsData); err != nil {
+		fmt.Printf("Error saving metrics data: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Applied %d metric updates\n", len(records))
+}
+
+// inferBatchFormat guesses a pulse.BatchFormat from path's extension,
+// defaulting to JSONBatch (e.g. for stdin, where there is no extension).
+func inferBatchFormat(path string) pulse.BatchFormat {
+	switch filepath.Ext(path) {
+	case ".csv":
+		return pulse.CSVBatch
+	case ".yaml", ".yml":
+		return pulse.YAMLBatch
+	default:
+		return pulse.JSONBatch
+	}
+}
+
+// printBatchResults prints one accepted/rejected line per pulse.BatchResult.
+func printBatchResults(results []pulse.BatchResult, dryRun bool) {
+	label := "Accepted"
+	if dryRun {
+		label = "Would accept"
+	}
+
+	var rejected int
+	for _, result := range results {
+		if result.Error != nil {
+			rejected++
+			fmt.Printf("❌ %s: %v\n", result.Record.Reference, result.Error)
+		} else {
+			fmt.Printf("✅ %s %s = %v\n", label, result.Record.Reference, result.Record.Value)
+		}
+	}
+
+	fmt.Printf("%d accepted, %d rejected\n", len(results)-rejected, rejected)
+}
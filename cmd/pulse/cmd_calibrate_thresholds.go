@@ -0,0 +1,110 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/scottbrown/pulse"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// runCalibrateThresholdsCmd replays every known metric's recorded history
+// through the current scoring bands to build an empirical score corpus,
+// then proposes Green/Yellow/Red cutoffs from that corpus via
+// pulse.CalibrateThresholds. With --write it patches levers.yaml in place
+// and re-runs the validator to confirm the emitted thresholds are
+// internally consistent; otherwise it just prints the recommended fragment.
+func runCalibrateThresholdsCmd(cmd *cobra.Command, args []string) {
+	configLoader := pulse.NewConfigLoader(configDir, dataDir)
+
+	metricsConfig, err := configLoader.LoadMetricsConfig()
+	if err != nil {
+		fmt.Printf("Error loading metrics config: %v\n", err)
+		os.Exit(1)
+	}
+
+	leversConfig, err := configLoader.LoadLeversConfig()
+	if err != nil {
+		fmt.Printf("Error loading levers config: %v\n", err)
+		os.Exit(1)
+	}
+
+	metricsData, err := configLoader.LoadMetricsData()
+	if err != nil {
+		fmt.Printf("Error loading metrics data: %v\n", err)
+		os.Exit(1)
+	}
+
+	metricsProcessor := pulse.NewMetricsProcessor(metricsConfig, leversConfig, metricsData)
+	metricsProcessor.SetHistoryStore(pulse.NewHistoryStore(filepath.Join(dataDir, "history")))
+
+	var scoringMethodEnum pulse.ScoringMethod
+	if scoringMethod == "average" {
+		scoringMethodEnum = pulse.AverageScoring
+	} else {
+		scoringMethodEnum = pulse.MedianScoring
+	}
+	scoreCalculator := pulse.NewScoreCalculator(metricsProcessor, scoringMethodEnum)
+
+	to := time.Now()
+	from := to.AddDate(0, 0, -calibrateDays)
+
+	var scores []int
+	for _, metric := range metricsData.Metrics {
+		series, err := scoreCalculator.CalculateScoreSeries(metric.Reference, from, to, 24*time.Hour)
+		if err != nil {
+			// No history recorded for this metric; skip it rather than
+			// failing the whole calibration.
+			continue
+		}
+		for _, point := range series {
+			scores = append(scores, point.Score)
+		}
+	}
+
+	if len(scores) == 0 {
+		fmt.Println("Error: no historical scores found under --data-dir/history; run with history recording enabled first")
+		os.Exit(1)
+	}
+
+	thresholds, err := pulse.CalibrateThresholds(scores, pulse.CalibrationMethod(calibrateMethod), calibrateTargetRedShare)
+	if err != nil {
+		fmt.Printf("Error calibrating thresholds: %v\n", err)
+		os.Exit(1)
+	}
+
+	fragment, err := yaml.Marshal(struct {
+		Thresholds pulse.Thresholds `yaml:"thresholds"`
+	}{Thresholds: thresholds})
+	if err != nil {
+		fmt.Printf("Error rendering recommended thresholds: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Calibrated from %d scores (method=%s, target_red_share=%.2f):\n\n", len(scores), calibrateMethod, calibrateTargetRedShare)
+	fmt.Print(string(fragment))
+
+	if !calibrateWrite {
+		return
+	}
+
+	leversConfig.Global.Thresholds = thresholds
+
+	checks := append(pulse.ValidateThresholds(leversConfig), pulse.ValidateHysteresis(leversConfig))
+	for _, check := range checks {
+		if !check.OK {
+			fmt.Printf("Error: calibrated thresholds failed validation (%s): %s\n", check.Name, check.Details)
+			os.Exit(pulse.ValidationCode(check.Name).ExitCode())
+		}
+	}
+
+	if err := configLoader.SaveLeversConfig(leversConfig); err != nil {
+		fmt.Printf("Error writing levers config: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Println("\nWrote calibrated thresholds to levers.yaml")
+}
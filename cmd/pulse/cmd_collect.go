@@ -0,0 +1,83 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/scottbrown/pulse"
+	"github.com/spf13/cobra"
+)
+
+func runCollectCmd(cmd *cobra.Command, args []string) {
+	// Initialize the config loader
+	configLoader := pulse.NewConfigLoader(configDir, dataDir)
+
+	// Load configurations
+	metricsConfig, err := configLoader.LoadMetricsConfig()
+	if err != nil {
+		fmt.Printf("Error loading metrics config: %v\n", err)
+		os.Exit(1)
+	}
+
+	leversConfig, err := configLoader.LoadLeversConfig()
+	if err != nil {
+		fmt.Printf("Error loading levers config: %v\n", err)
+		os.Exit(1)
+	}
+
+	metricsData, err := configLoader.LoadMetricsData()
+	if err != nil {
+		fmt.Printf("Error loading metrics data: %v\n", err)
+		os.Exit(1)
+	}
+
+	collectorsConfig, err := configLoader.LoadCollectorsConfig()
+	if err != nil {
+		fmt.Printf("Error loading collectors config: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Initialize the metrics processor
+	metricsProcessor := pulse.NewMetricsProcessor(metricsConfig, leversConfig, metricsData)
+	metricsProcessor.SetHistoryStore(pulse.NewHistoryStore(filepath.Join(dataDir, "history")))
+
+	collector := pulse.NewFileCollector(metricsProcessor, collectorsConfig.Rules)
+
+	if !collectWatch {
+		if err := collector.CollectOnce(); err != nil {
+			fmt.Printf("Error collecting metrics: %v\n", err)
+			os.Exit(1)
+		}
+		if err := configLoader.SaveMetricsData(metricsData); err != nil {
+			fmt.Printf("Error saving metrics data: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println("Metrics collected")
+		return
+	}
+
+	interval := time.Duration(collectInterval) * time.Second
+	stop := make(chan struct{})
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, os.Interrupt, syscall.SIGTERM)
+
+	go func() {
+		<-sigs
+		close(stop)
+	}()
+
+	fmt.Printf("Watching for metric changes every %s (Ctrl+C to stop)\n", interval)
+	if err := collector.Watch(interval, stop); err != nil {
+		fmt.Printf("Error watching for metrics: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := configLoader.SaveMetricsData(metricsData); err != nil {
+		fmt.Printf("Error saving metrics data: %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/scottbrown/pulse"
+	"github.com/spf13/cobra"
+	"github.com/spf13/cobra/doc"
+)
+
+// completionMetrics returns every metric reference known to the
+// configuration at configDir/dataDir, for use as shell tab-completion
+// suggestions. It fails open (returning nil) rather than erroring, since a
+// broken completion lookup shouldn't block the shell from completing at
+// all.
+func completionMetrics() []string {
+	configLoader := pulse.NewConfigLoader(configDir, dataDir)
+
+	metricsData, err := configLoader.LoadMetricsData()
+	if err != nil {
+		return nil
+	}
+
+	refs := make([]string, 0, len(metricsData.Metrics))
+	for _, metric := range metricsData.Metrics {
+		refs = append(refs, metric.Reference)
+	}
+	return refs
+}
+
+// completionCategories returns every category ID known to the configuration
+// at configDir/dataDir, for use as shell tab-completion suggestions.
+func completionCategories() []string {
+	configLoader := pulse.NewConfigLoader(configDir, dataDir)
+
+	metricsConfig, err := configLoader.LoadMetricsConfig()
+	if err != nil {
+		return nil
+	}
+
+	ids := make([]string, 0, len(metricsConfig.Categories))
+	for _, category := range metricsConfig.Categories {
+		ids = append(ids, category.ID)
+	}
+	return ids
+}
+
+// completeMetricRefs is a cobra ValidArgsFunction/flag completion func
+// suggesting known metric references.
+func completeMetricRefs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return completionMetrics(), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeCategories is a cobra ValidArgsFunction/flag completion func
+// suggesting known category IDs.
+func completeCategories(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return completionCategories(), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeScoringMethods is a cobra flag completion func suggesting the
+// valid --scoring-method values.
+func completeScoringMethods(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	methods := make([]string, len(pulse.SupportedScoringMethods))
+	for i, method := range pulse.SupportedScoringMethods {
+		methods[i] = string(method)
+	}
+	return methods, cobra.ShellCompDirectiveNoFileComp
+}
+
+// registerCompletions wires dynamic completion functions for --metric,
+// --category, and --scoring-method onto every command that defines them, so
+// tab-completion suggests real values loaded via pulse.NewConfigLoader
+// instead of nothing.
+func registerCompletions(cmds ...*cobra.Command) {
+	for _, cmd := range cmds {
+		if cmd.Flags().Lookup("metric") != nil {
+			_ = cmd.RegisterFlagCompletionFunc("metric", completeMetricRefs)
+		}
+		if cmd.Flags().Lookup("category") != nil {
+			_ = cmd.RegisterFlagCompletionFunc("category", completeCategories)
+		}
+		if cmd.Flags().Lookup("scoring-method") != nil {
+			_ = cmd.RegisterFlagCompletionFunc("scoring-method", completeScoringMethods)
+		}
+	}
+}
+
+// newCompletionCmd builds the top-level "completion" command, with one
+// subcommand per shell cobra supports generating a completion script for.
+func newCompletionCmd(rootCmd *cobra.Command) *cobra.Command {
+	completionCmd := &cobra.Command{
+		Use:   "completion",
+		Short: "Generate shell completion scripts",
+		Long:  `Generate a completion script for bash, zsh, fish, or powershell and print it to stdout.`,
+	}
+
+	bashCmd := &cobra.Command{
+		Use:   "bash",
+		Short: "Generate the bash completion script",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := rootCmd.GenBashCompletionV2(os.Stdout, true); err != nil {
+				fmt.Printf("Error generating bash completion: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	zshCmd := &cobra.Command{
+		Use:   "zsh",
+		Short: "Generate the zsh completion script",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := rootCmd.GenZshCompletion(os.Stdout); err != nil {
+				fmt.Printf("Error generating zsh completion: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	fishCmd := &cobra.Command{
+		Use:   "fish",
+		Short: "Generate the fish completion script",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := rootCmd.GenFishCompletion(os.Stdout, true); err != nil {
+				fmt.Printf("Error generating fish completion: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	powershellCmd := &cobra.Command{
+		Use:   "powershell",
+		Short: "Generate the powershell completion script",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := rootCmd.GenPowerShellCompletionWithDesc(os.Stdout); err != nil {
+				fmt.Printf("Error generating powershell completion: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	var manpagesDir string
+	manpagesCmd := &cobra.Command{
+		Use:   "manpages",
+		Short: "Generate manpages for the pulse CLI",
+		Long:  `Generate roff manpages for pulse and every subcommand into --dir.`,
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := os.MkdirAll(manpagesDir, 0750); err != nil {
+				fmt.Printf("Error creating manpages directory: %v\n", err)
+				os.Exit(1)
+			}
+
+			header := &doc.GenManHeader{
+				Title:   "PULSE",
+				Section: "1",
+				Source:  fmt.Sprintf("pulse %s", version),
+			}
+			if err := doc.GenManTree(rootCmd, header, manpagesDir); err != nil {
+				fmt.Printf("Error generating manpages: %v\n", err)
+				os.Exit(1)
+			}
+
+			fmt.Printf("Manpages written to %s\n", manpagesDir)
+		},
+	}
+	manpagesCmd.Flags().StringVar(&manpagesDir, "dir", "./man", "Directory to write manpages into")
+
+	completionCmd.AddCommand(bashCmd, zshCmd, fishCmd, powershellCmd, manpagesCmd)
+	return completionCmd
+}
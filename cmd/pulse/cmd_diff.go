@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/scottbrown/pulse"
+	"github.com/spf13/cobra"
+)
+
+// loadSnapshotMetrics reads a metrics snapshot file - the same
+// {reference, value, timestamp?} batch shape accepted by `pulse update
+// --from-file` - and returns it as a pulse.MetricsData ready to score.
+func loadSnapshotMetrics(path string) (*pulse.MetricsData, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer file.Close()
+
+	records, err := pulse.ParseBatch(inferBatchFormat(path), file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	metrics := make([]pulse.Metric, len(records))
+	for i, record := range records {
+		metrics[i] = pulse.Metric{Reference: record.Reference, Value: record.Value, Timestamp: record.Timestamp}
+	}
+	return &pulse.MetricsData{Metrics: metrics}, nil
+}
+
+// scoreSnapshot builds a ScoreCalculator against metricsConfig/leversConfig
+// and the metrics loaded from path, and returns its CalculateOverallScore.
+func scoreSnapshot(path string, metricsConfig *pulse.MetricsConfig, leversConfig *pulse.LeversConfig) (*pulse.OverallScore, error) {
+	metricsData, err := loadSnapshotMetrics(path)
+	if err != nil {
+		return nil, err
+	}
+
+	processor := pulse.NewMetricsProcessor(metricsConfig, leversConfig, metricsData)
+	calculator := pulse.NewScoreCalculator(processor, pulse.MedianScoring)
+	return calculator.CalculateOverallScore()
+}
+
+func runDiffCmd(cmd *cobra.Command, args []string) {
+	configLoader := pulse.NewConfigLoader(configDir, dataDir)
+
+	metricsConfig, err := configLoader.LoadMetricsConfig()
+	if err != nil {
+		fmt.Printf("Error loading metrics config: %v\n", err)
+		os.Exit(1)
+	}
+
+	leversConfig, err := configLoader.LoadLeversConfig()
+	if err != nil {
+		fmt.Printf("Error loading levers config: %v\n", err)
+		os.Exit(1)
+	}
+
+	oldScore, err := scoreSnapshot(args[0], metricsConfig, leversConfig)
+	if err != nil {
+		fmt.Printf("Error scoring %s: %v\n", args[0], err)
+		os.Exit(1)
+	}
+
+	newScore, err := scoreSnapshot(args[1], metricsConfig, leversConfig)
+	if err != nil {
+		fmt.Printf("Error scoring %s: %v\n", args[1], err)
+		os.Exit(1)
+	}
+
+	reportFormat := pulse.TextFormat
+	if format == "json" {
+		reportFormat = pulse.JSONFormat
+	}
+
+	scoreCalculator := pulse.NewScoreCalculator(pulse.NewMetricsProcessor(metricsConfig, leversConfig, &pulse.MetricsData{}), pulse.MedianScoring)
+	reportGenerator := pulse.NewReportGenerator(scoreCalculator, pulse.TextLabels)
+
+	output, err := reportGenerator.GenerateDiffReport(*oldScore, *newScore, reportFormat)
+	if err != nil {
+		fmt.Printf("Error generating diff report: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Println(string(output.Content))
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/scottbrown/pulse"
+	"github.com/spf13/cobra"
+)
+
+// runImportCmd bulk-ingests metric observations from args[0] in the format
+// named by --format (prom, openmetrics, or csv), applying every reference
+// that passes schema validation and printing a per-row error for every one
+// that doesn't, rather than aborting the whole import.
+func runImportCmd(cmd *cobra.Command, args []string) {
+	format := pulse.ImportFormat(importFormat)
+
+	file, err := os.Open(args[0])
+	if err != nil {
+		fmt.Printf("Error opening import file: %v\n", err)
+		os.Exit(1)
+	}
+	defer file.Close()
+
+	configLoader := pulse.NewConfigLoader(configDir, dataDir)
+
+	metricsConfig, err := configLoader.LoadMetricsConfig()
+	if err != nil {
+		fmt.Printf("Error loading metrics config: %v\n", err)
+		os.Exit(1)
+	}
+
+	leversConfig, err := configLoader.LoadLeversConfig()
+	if err != nil {
+		fmt.Printf("Error loading levers config: %v\n", err)
+		os.Exit(1)
+	}
+
+	metricsData, err := configLoader.LoadMetricsData()
+	if err != nil {
+		fmt.Printf("Error loading metrics data: %v\n", err)
+		os.Exit(1)
+	}
+
+	metricsProcessor := pulse.NewMetricsProcessor(metricsConfig, leversConfig, metricsData)
+	metricsProcessor.SetHistoryStore(pulse.NewHistoryStore(filepath.Join(dataDir, "history")))
+
+	result, err := metricsProcessor.ImportFrom(file, format)
+	if err != nil {
+		fmt.Printf("Error importing metrics: %v\n", err)
+		os.Exit(1)
+	}
+
+	for _, importErr := range result.Errors {
+		fmt.Printf("❌ %s\n", importErr)
+	}
+	fmt.Printf("%d applied, %d rejected\n", result.Applied, len(result.Errors))
+
+	if err := configLoader.SaveMetricsData(metricsData); err != nil {
+		fmt.Printf("Error saving metrics data: %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -34,6 +34,14 @@ func runListMetricsCmd(cmd *cobra.Command, args []string) {
 	// Initialize the metrics processor
 	metricsProcessor := pulse.NewMetricsProcessor(metricsConfig, leversConfig, metricsData)
 
+	// Resolve live values for metrics with a configured query/endpoint
+	if live {
+		if err := metricsProcessor.RefreshLiveMetrics(); err != nil {
+			fmt.Printf("Error refreshing live metrics: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
 	// Get all metrics
 	metrics := metricsProcessor.GetAllMetrics()
 
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/scottbrown/pulse"
+	"github.com/spf13/cobra"
+)
+
+// buildNotificationEngine loads notifications.yaml and constructs its
+// named Notifiers, returning a NotificationEngine ready to Evaluate.
+func buildNotificationEngine(configLoader *pulse.ConfigLoader) (*pulse.NotificationEngine, error) {
+	notificationsConfig, err := configLoader.LoadNotificationsConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load notifications config: %w", err)
+	}
+
+	notifiers := make(map[string]pulse.Notifier, len(notificationsConfig.Notifiers))
+	for name, cfg := range notificationsConfig.Notifiers {
+		notifier, err := pulse.NotifierFromConfig(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("channel %q: %w", name, err)
+		}
+		notifiers[name] = notifier
+	}
+
+	return pulse.NewNotificationEngine(notificationsConfig.Rules, notifiers), nil
+}
+
+// evaluateNotifications computes scoreCalculator's OverallScore and feeds
+// it to a NotificationEngine restored from the data directory, persisting
+// the resulting last-seen statuses so transitions are detected across
+// invocations.
+func evaluateNotifications(scoreCalculator *pulse.ScoreCalculator) error {
+	overallScore, err := scoreCalculator.CalculateOverallScore()
+	if err != nil {
+		return fmt.Errorf("failed to calculate overall score: %w", err)
+	}
+
+	configLoader := pulse.NewConfigLoader(configDir, dataDir)
+	engine, err := buildNotificationEngine(configLoader)
+	if err != nil {
+		return err
+	}
+
+	statePath := filepath.Join(dataDir, "notifications_state.json")
+	if err := engine.LoadState(statePath); err != nil {
+		return fmt.Errorf("failed to load notification state: %w", err)
+	}
+
+	events, err := engine.Evaluate(overallScore)
+	if err != nil {
+		return fmt.Errorf("failed to evaluate notifications: %w", err)
+	}
+
+	if err := engine.SaveState(statePath); err != nil {
+		return fmt.Errorf("failed to save notification state: %w", err)
+	}
+
+	for _, event := range events {
+		fmt.Println(event.String())
+	}
+
+	return nil
+}
+
+func runNotifyCmd(cmd *cobra.Command, args []string) {
+	configLoader := pulse.NewConfigLoader(configDir, dataDir)
+
+	metricsConfig, err := configLoader.LoadMetricsConfig()
+	if err != nil {
+		fmt.Printf("Error loading metrics config: %v\n", err)
+		os.Exit(1)
+	}
+
+	leversConfig, err := configLoader.LoadLeversConfig()
+	if err != nil {
+		fmt.Printf("Error loading levers config: %v\n", err)
+		os.Exit(1)
+	}
+
+	metricsData, err := configLoader.LoadMetricsData()
+	if err != nil {
+		fmt.Printf("Error loading metrics data: %v\n", err)
+		os.Exit(1)
+	}
+
+	metricsProcessor := pulse.NewMetricsProcessor(metricsConfig, leversConfig, metricsData)
+	scoreCalculator := pulse.NewScoreCalculator(metricsProcessor, pulse.MedianScoring)
+
+	if err := evaluateNotifications(scoreCalculator); err != nil {
+		fmt.Printf("Error evaluating notifications: %v\n", err)
+		os.Exit(1)
+	}
+}
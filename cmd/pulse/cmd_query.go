@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/scottbrown/pulse"
+	"github.com/spf13/cobra"
+)
+
+// runQueryCmd evaluates the PromQL-lite expression in args[0] (see
+// pulse.MetricsProcessor.Query) against the currently configured metrics,
+// printing either a single scalar or, for a "by category" aggregation, one
+// line per category.
+func runQueryCmd(cmd *cobra.Command, args []string) {
+	configLoader := pulse.NewConfigLoader(configDir, dataDir)
+
+	metricsConfig, err := configLoader.LoadMetricsConfig()
+	if err != nil {
+		fmt.Printf("Error loading metrics config: %v\n", err)
+		os.Exit(1)
+	}
+
+	leversConfig, err := configLoader.LoadLeversConfig()
+	if err != nil {
+		fmt.Printf("Error loading levers config: %v\n", err)
+		os.Exit(1)
+	}
+
+	metricsData, err := configLoader.LoadMetricsData()
+	if err != nil {
+		fmt.Printf("Error loading metrics data: %v\n", err)
+		os.Exit(1)
+	}
+
+	metricsProcessor := pulse.NewMetricsProcessor(metricsConfig, leversConfig, metricsData)
+	metricsProcessor.SetHistoryStore(pulse.NewHistoryStore(filepath.Join(dataDir, "history")))
+
+	result, err := metricsProcessor.Query(args[0])
+	if err != nil {
+		fmt.Printf("Error evaluating query: %v\n", err)
+		os.Exit(1)
+	}
+
+	if !result.Grouped {
+		fmt.Printf("%g\n", result.Scalar)
+		return
+	}
+
+	keys := make([]string, 0, len(result.Series))
+	for category := range result.Series {
+		keys = append(keys, category)
+	}
+	sort.Strings(keys)
+
+	for _, category := range keys {
+		fmt.Printf("%s: %g\n", category, result.Series[category])
+	}
+}
@@ -1,14 +1,22 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
+	"time"
 
 	"github.com/scottbrown/pulse"
 	"github.com/spf13/cobra"
 )
 
 func runReportCmd(cmd *cobra.Command, args []string) {
+	if reportThresholdsOnly {
+		runThresholdsOnlyReport()
+		return
+	}
+
 	// Initialize the config loader
 	configLoader := pulse.NewConfigLoader(configDir, dataDir)
 
@@ -34,6 +42,14 @@ func runReportCmd(cmd *cobra.Command, args []string) {
 	// Initialize the metrics processor
 	metricsProcessor := pulse.NewMetricsProcessor(metricsConfig, leversConfig, metricsData)
 
+	// Resolve live values for metrics with a configured query/endpoint
+	if live {
+		if err := metricsProcessor.RefreshLiveMetrics(); err != nil {
+			fmt.Printf("Error refreshing live metrics: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
 	// Initialize the score calculator with the specified scoring method
 	var scoringMethodEnum pulse.ScoringMethod
 	if scoringMethod == "average" {
@@ -43,30 +59,82 @@ func runReportCmd(cmd *cobra.Command, args []string) {
 		scoringMethodEnum = pulse.MedianScoring
 	}
 
-	scoreCalculator := pulse.NewScoreCalculator(metricsProcessor, scoringMethodEnum)
-
-	// Initialize the report generator
-	reportGenerator := pulse.NewReportGenerator(scoreCalculator)
+	var scoreOpts []pulse.ScoreCalculatorOption
+	if reportNoThresholds {
+		scoreOpts = append(scoreOpts, pulse.WithNoThresholds())
+	}
+	scoreCalculator := pulse.NewScoreCalculator(metricsProcessor, scoringMethodEnum, scoreOpts...)
 
-	// Generate the report
-	var reportContent string
-	var reportErr error
+	// Initialize the report generator, optionally attaching history so each
+	// metric's line includes a sparkline, 7/30/90-day deltas, and a trend
+	// arrow (or, in JSON format, a history array).
+	var reportOpts []pulse.ReportGeneratorOption
+	if reportWithTrend {
+		history := pulse.NewHistoryStore(filepath.Join(dataDir, "history"))
+		metricsProcessor.SetHistoryStore(history)
+		reportOpts = append(reportOpts, pulse.WithTrend(history))
+	}
+	reportOpts = append(reportOpts, pulse.WithColor(resolveReportColor()))
+	if reportPrintReady {
+		reportOpts = append(reportOpts, pulse.WithPrintReady(true))
+	}
+	reportGenerator := pulse.NewReportGenerator(scoreCalculator, pulse.TextLabels, reportOpts...)
 
-	reportFormat := pulse.TextFormat
-	if format == "json" {
+	var reportFormat pulse.ReportFormat
+	switch format {
+	case "json":
 		reportFormat = pulse.JSONFormat
+	case "table":
+		reportFormat = pulse.TableFormat
+	case "pdf":
+		reportFormat = pulse.PDFFormat
+	default:
+		reportFormat = pulse.TextFormat
 	}
 
+	var reportOutput *pulse.ReportOutput
+	var reportErr error
 	if category != "" {
-		reportContent, reportErr = reportGenerator.GenerateCategoryReport(category, reportFormat)
+		reportOutput, reportErr = reportGenerator.GenerateCategoryReport(category, reportFormat)
 	} else {
-		reportContent, reportErr = reportGenerator.GenerateOverallReport(reportFormat)
+		reportOutput, reportErr = reportGenerator.GenerateOverallReport(reportFormat)
 	}
 
 	if reportErr != nil {
 		fmt.Printf("Error generating report: %v\n", reportErr)
 		os.Exit(1)
 	}
+	reportContent := string(reportOutput.Content)
+
+	// Persist this report's OverallScore for later replay with "score-trend"
+	if reportSaveSnapshot {
+		overallScore, err := scoreCalculator.CalculateOverallScore()
+		if err != nil {
+			fmt.Printf("Error calculating overall score for --save-snapshot: %v\n", err)
+			os.Exit(1)
+		}
+		snapshotStore := pulse.NewSnapshotStore(filepath.Join(dataDir, "snapshots"))
+		if err := snapshotStore.Save(*overallScore, time.Now()); err != nil {
+			fmt.Printf("Error saving snapshot: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	// Evaluate and dispatch alerts for degraded or stale metrics
+	if alerts {
+		if err := evaluateAlerts(scoreCalculator, metricsData); err != nil {
+			fmt.Printf("Error evaluating alerts: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	// Evaluate and dispatch notifications for overall/category status transitions
+	if notify {
+		if err := evaluateNotifications(scoreCalculator); err != nil {
+			fmt.Printf("Error evaluating notifications: %v\n", err)
+			os.Exit(1)
+		}
+	}
 
 	// Output the report
 	if outputFile != "" {
@@ -80,3 +148,107 @@ func runReportCmd(cmd *cobra.Command, args []string) {
 		fmt.Println(reportContent)
 	}
 }
+
+// runThresholdsOnlyReport re-evaluates a previously emitted --format json
+// report (--input) against the current levers.yaml, without reloading
+// metrics.yaml or recomputing any scores. It decouples
+// slow data collection from fast threshold re-evaluation: a dataset can be
+// frozen and levers.yaml tuned iteratively against it, or a CI gate can be
+// added/removed without recomputing metrics. Exits 1 if any metric lands in
+// Red.
+func runThresholdsOnlyReport() {
+	if reportInput == "" {
+		fmt.Println("Error: --thresholds-only requires --input <path to a --format json report>")
+		os.Exit(1)
+	}
+
+	leversConfig, err := pulse.NewConfigLoader(configDir, dataDir).LoadLeversConfig()
+	if err != nil {
+		fmt.Printf("Error loading levers config: %v\n", err)
+		os.Exit(1)
+	}
+
+	raw, err := os.ReadFile(reportInput)
+	if err != nil {
+		fmt.Printf("Error reading --input report: %v\n", err)
+		os.Exit(1)
+	}
+
+	var snapshot pulse.ReportSnapshot
+	if err := json.Unmarshal(raw, &snapshot); err != nil {
+		fmt.Printf("Error parsing --input report: %v\n", err)
+		os.Exit(1)
+	}
+
+	reevaluations, err := pulse.ReevaluateThresholds(snapshot, leversConfig)
+	if err != nil {
+		fmt.Printf("Error re-evaluating thresholds: %v\n", err)
+		os.Exit(1)
+	}
+
+	anyRed := false
+	for _, r := range reevaluations {
+		fmt.Printf("%s: score=%d %s -> %s\n", r.Reference, r.Score, r.OldStatus, r.NewStatus)
+		if r.NewStatus == pulse.Red {
+			anyRed = true
+		}
+	}
+
+	if anyRed {
+		os.Exit(1)
+	}
+}
+
+// resolveReportColor decides whether --format table should colorize status
+// cells: --no-color always wins, --color always forces it on, and absent
+// either flag it defaults to on only when stdout is a terminal and the
+// report isn't being written to a file (an --output pipe to a file should
+// stay plain, like any well-behaved CLI's auto-color detection).
+func resolveReportColor() bool {
+	if reportNoColor {
+		return false
+	}
+	if reportColor {
+		return true
+	}
+	if outputFile != "" {
+		return false
+	}
+	info, err := os.Stdout.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// evaluateAlerts computes the overall score, runs it through an AlertEngine
+// restored from the data directory, and persists the resulting accrual state
+// so consecutive-breach counting survives across invocations.
+func evaluateAlerts(scoreCalculator *pulse.ScoreCalculator, metricsData *pulse.MetricsData) error {
+	overallScore, err := scoreCalculator.CalculateOverallScore()
+	if err != nil {
+		return fmt.Errorf("failed to calculate overall score: %w", err)
+	}
+
+	sinks := []pulse.Sink{pulse.NewStdoutSink()}
+	if alertWebhook != "" {
+		sinks = append(sinks, pulse.NewWebhookSink(alertWebhook))
+	}
+
+	engine := pulse.NewAlertEngine(3, 24*time.Hour, sinks...)
+
+	statePath := filepath.Join(dataDir, "alerts_state.json")
+	if err := engine.LoadAlertEngineState(statePath); err != nil {
+		return fmt.Errorf("failed to load alert state: %w", err)
+	}
+
+	if _, err := engine.Evaluate(overallScore, metricsData.Metrics); err != nil {
+		return fmt.Errorf("failed to evaluate alerts: %w", err)
+	}
+
+	if err := engine.SaveState(statePath); err != nil {
+		return fmt.Errorf("failed to save alert state: %w", err)
+	}
+
+	return nil
+}
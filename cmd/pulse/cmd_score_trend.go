@@ -0,0 +1,40 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/scottbrown/pulse"
+	"github.com/spf13/cobra"
+)
+
+func runScoreTrendCmd(cmd *cobra.Command, args []string) {
+	to := time.Now()
+	from := to.AddDate(0, 0, -scoreTrendDays)
+	if scoreTrendSince != "" {
+		since, err := parseSince(scoreTrendSince)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		from = to.Add(-since)
+	}
+
+	snapshotStore := pulse.NewSnapshotStore(filepath.Join(dataDir, "snapshots"))
+	reportGenerator := pulse.NewReportGenerator(pulse.NewScoreCalculator(nil, pulse.MedianScoring), pulse.TextLabels)
+
+	reportFormat := pulse.TextFormat
+	if format == "json" {
+		reportFormat = pulse.JSONFormat
+	}
+
+	output, err := reportGenerator.GenerateScoreTrendReport(snapshotStore, from, to, reportFormat)
+	if err != nil {
+		fmt.Printf("Error generating score trend report: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Println(string(output.Content))
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/scottbrown/pulse"
+	"github.com/spf13/cobra"
+)
+
+func runServeCmd(cmd *cobra.Command, args []string) {
+	configLoader := pulse.NewConfigLoader(configDir, dataDir)
+	exporter := pulse.NewExporter(configLoader)
+
+	// Reload configuration on SIGHUP, so a long-running server can pick up
+	// edited config without a restart
+	sighup := make(chan os.Signal, 1)
+	signal.Notify(sighup, syscall.SIGHUP)
+	go func() {
+		for range sighup {
+			if err := exporter.Reload(); err != nil {
+				fmt.Printf("Error reloading config: %v\n", err)
+			}
+		}
+	}()
+
+	var apiOpts []pulse.APIServerOption
+	if serveAuthToken != "" {
+		apiOpts = append(apiOpts, pulse.WithAuthToken(serveAuthToken))
+	}
+	apiServer := pulse.NewAPIServer(configLoader, pulse.MedianScoring, apiOpts...)
+
+	fmt.Printf("Serving Prometheus metrics and REST API on %s (/metrics, /healthz, /api/*)\n", serveAddr)
+
+	err := apiServer.Serve(exporter, pulse.ServeConfig{
+		Addr:     serveAddr,
+		CertFile: serveCertFile,
+		KeyFile:  serveKeyFile,
+	})
+	if err != nil {
+		fmt.Printf("Error serving metrics: %v\n", err)
+		os.Exit(1)
+	}
+}
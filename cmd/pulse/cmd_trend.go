@@ -0,0 +1,132 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/scottbrown/pulse"
+	"github.com/spf13/cobra"
+)
+
+// parseSince parses a duration like "30d", "12h", or "45m" into a
+// time.Duration. Cobra's built-in duration flag has no concept of days, so
+// --since accepts a "d" suffix in addition to Go's standard units.
+func parseSince(since string) (time.Duration, error) {
+	if strings.HasSuffix(since, "d") {
+		days, err := strconv.Atoi(strings.TrimSuffix(since, "d"))
+		if err != nil {
+			return 0, fmt.Errorf("invalid --since value %q: %w", since, err)
+		}
+		return time.Duration(days) * 24 * time.Hour, nil
+	}
+	return time.ParseDuration(since)
+}
+
+func runTrendCmd(cmd *cobra.Command, args []string) {
+	// Initialize the config loader
+	configLoader := pulse.NewConfigLoader(configDir, dataDir)
+
+	// Load configurations
+	metricsConfig, err := configLoader.LoadMetricsConfig()
+	if err != nil {
+		fmt.Printf("Error loading metrics config: %v\n", err)
+		os.Exit(1)
+	}
+
+	leversConfig, err := configLoader.LoadLeversConfig()
+	if err != nil {
+		fmt.Printf("Error loading levers config: %v\n", err)
+		os.Exit(1)
+	}
+
+	metricsData, err := configLoader.LoadMetricsData()
+	if err != nil {
+		fmt.Printf("Error loading metrics data: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Initialize the metrics processor
+	metricsProcessor := pulse.NewMetricsProcessor(metricsConfig, leversConfig, metricsData)
+	metricsProcessor.SetHistoryStore(pulse.NewHistoryStore(filepath.Join(dataDir, "history")))
+
+	if metricRef == "" {
+		fmt.Println("Error: --metric is required")
+		os.Exit(1)
+	}
+
+	to := time.Now()
+	from := to.AddDate(0, 0, -trendDays)
+	if trendSince != "" {
+		since, err := parseSince(trendSince)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		from = to.Add(-since)
+	}
+
+	step := 24 * time.Hour
+	if to.Sub(from) <= 24*time.Hour {
+		step = time.Hour
+	}
+
+	scoreCalculator := pulse.NewScoreCalculator(metricsProcessor, pulse.MedianScoring)
+	reportGenerator := pulse.NewReportGenerator(scoreCalculator, pulse.TextLabels)
+
+	reportFormat := pulse.TextFormat
+	if format == "json" {
+		reportFormat = pulse.JSONFormat
+	}
+
+	output, err := reportGenerator.GenerateTrendReport(metricRef, from, to, step, reportFormat)
+	if err != nil {
+		fmt.Printf("Error generating trend report: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Println(string(output.Content))
+
+	if reportFormat == pulse.TextFormat {
+		printTrendStats(configLoader, metricRef, from)
+	}
+}
+
+// printTrendStats prints the slope (value change per day) and p50/p95 of a
+// metric's history since from, below its sparkline.
+func printTrendStats(configLoader *pulse.ConfigLoader, reference string, from time.Time) {
+	points, err := configLoader.LoadMetricHistory(reference, from)
+	if err != nil || len(points) == 0 {
+		return
+	}
+
+	first, last := points[0], points[len(points)-1]
+	days := last.Timestamp.Sub(first.Timestamp).Hours() / 24
+	var slope float64
+	if days > 0 {
+		slope = (last.Value - first.Value) / days
+	}
+
+	values := make([]float64, len(points))
+	for i, p := range points {
+		values[i] = p.Value
+	}
+	sort.Float64s(values)
+
+	fmt.Printf("slope: %.3f/day  p50: %.2f  p95: %.2f  (n=%d)\n",
+		slope, percentile(values, 0.5), percentile(values, 0.95), len(values))
+}
+
+// percentile returns the value at the given percentile (0-1) of a
+// pre-sorted ascending slice, using nearest-rank interpolation.
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	idx := int(p * float64(len(sorted)-1))
+	return sorted[idx]
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"math"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 
@@ -36,6 +37,25 @@ func runUpdateCmd(cmd *cobra.Command, args []string) {
 
 	// Initialize the metrics processor
 	metricsProcessor := pulse.NewMetricsProcessor(metricsConfig, leversConfig, metricsData)
+	metricsProcessor.SetHistoryStore(pulse.NewHistoryStore(filepath.Join(dataDir, "history")))
+
+	if updateFromFile != "" {
+		runUpdateBatch(configLoader, metricsProcessor, metricsData)
+		return
+	}
+
+	if updateInteractive {
+		if err := runUpdateWizard(configLoader, metricsProcessor, metricsData); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if metricRef == "" || metricVal == "" {
+		fmt.Println("Error: --metric and --value are required (or pass --interactive)")
+		os.Exit(1)
+	}
 
 	// Validate metric reference format
 	if !strings.Contains(metricRef, ".") || len(strings.Split(metricRef, ".")) != 3 {
@@ -57,7 +77,7 @@ func runUpdateCmd(cmd *cobra.Command, args []string) {
 	}
 
 	// Update the metric
-	err = metricsProcessor.UpdateMetric(metricRef, value)
+	err = metricsProcessor.UpdateMetricWithAuthor(metricRef, value, updateAuthor)
 	if err != nil {
 		fmt.Printf("Error updating metric: %v\n", err)
 		os.Exit(1)
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"time"
+
+	"github.com/scottbrown/pulse"
+	"github.com/spf13/cobra"
+)
+
+// runValidateConfigCmd runs pulse.Validator's full check suite: everything
+// validate-levers covers, plus metric reference resolution, data-point
+// coverage, data timestamp sanity, and the --scoring-method value.
+func runValidateConfigCmd(cmd *cobra.Command, args []string) {
+	configLoader := pulse.NewConfigLoader(configDir, dataDir)
+
+	leversConfig, err := configLoader.LoadLeversConfig()
+	if err != nil {
+		emitConfigMissing(err)
+		return
+	}
+
+	metricsConfig, err := configLoader.LoadMetricsConfig()
+	if err != nil {
+		emitConfigMissing(err)
+		return
+	}
+
+	metricsData, err := configLoader.LoadMetricsData()
+	if err != nil {
+		emitConfigMissing(err)
+		return
+	}
+
+	validator := pulse.NewValidator(leversConfig, metricsConfig, metricsData, scoringMethod, time.Now())
+	emitValidationChecks(validator.Validate().Checks)
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 
@@ -8,189 +9,176 @@ import (
 	"github.com/spf13/cobra"
 )
 
-// runValidateLeversCmd validates both category weights and threshold configurations
-func runValidateLeversCmd(cmd *cobra.Command, args []string) {
-	// Initialize the config loader
-	configLoader := pulse.NewConfigLoader(configDir, dataDir)
-
-	// Load levers configuration
-	leversConfig, err := configLoader.LoadLeversConfig()
-	if err != nil {
-		fmt.Printf("Error loading levers config: %v\n", err)
-		os.Exit(1)
-	}
-
-	fmt.Println("Running complete validation of levers configuration...")
-	fmt.Println()
-
-	// First validate weights
-	fmt.Println("=== Category Weights Validation ===")
-
-	// Sum up the category weights
-	var totalWeight float64
-	for _, weight := range leversConfig.Weights.Categories {
-		totalWeight += weight
+// emitValidationChecks renders checks as text, or with --format json as
+// {"status":"pass"|"fail","checks":[...]}, with --format sarif as a
+// SARIF 2.1.0 log (see pulse.FormatValidationSARIF), or with --format
+// junit as a JUnit XML document (see pulse.FormatValidationJUnit), then
+// exits with the ExitCode of the first failing check's pulse.ValidationCode
+// (or 0 if every check passed), so CI pipelines can distinguish failure
+// reasons without parsing text output.
+func emitValidationChecks(checks []pulse.ValidationCheck) {
+	failed := false
+	exitCode := 0
+	for _, check := range checks {
+		if !check.OK && !failed {
+			failed = true
+			exitCode = pulse.ValidationCode(check.Name).ExitCode()
+		}
 	}
 
-	// Check if the weights add up to 100% (1.0)
-	if len(leversConfig.Weights.Categories) == 0 {
-		fmt.Println("No category weights defined.")
-		os.Exit(1)
-	}
+	switch validateFormat {
+	case "json":
+		status := "pass"
+		if failed {
+			status = "fail"
+		}
 
-	// Display all category weights
-	for category, weight := range leversConfig.Weights.Categories {
-		fmt.Printf("%s: %.2f (%.0f%%)\n", category, weight, weight*100)
-	}
-	fmt.Println()
+		payload := struct {
+			Status string                  `json:"status"`
+			Checks []pulse.ValidationCheck `json:"checks"`
+		}{Status: status, Checks: checks}
 
-	// Display the total and validation result
-	fmt.Printf("Total weight: %.2f (%.0f%%)\n", totalWeight, totalWeight*100)
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(payload); err != nil {
+			fmt.Printf("Error encoding validation result: %v\n", err)
+			os.Exit(1)
+		}
+	case "sarif":
+		var issues []pulse.ValidationIssue
+		for _, check := range checks {
+			if check.OK {
+				continue
+			}
+			issues = append(issues, pulse.ValidationIssue{
+				Code:     pulse.ValidationCode(check.Name),
+				Message:  check.Details,
+				Severity: pulse.SeverityError,
+			})
+		}
 
-	// Use a small epsilon for floating point comparison
-	const epsilon = 0.0001
-	weightsValid := totalWeight >= 1.0-epsilon && totalWeight <= 1.0+epsilon
+		sarif, err := pulse.FormatValidationSARIF(pulse.ValidationReport{Checks: checks, Issues: issues})
+		if err != nil {
+			fmt.Printf("Error encoding SARIF validation result: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println(string(sarif))
+	case "junit":
+		var issues []pulse.ValidationIssue
+		for _, check := range checks {
+			if check.OK {
+				continue
+			}
+			issues = append(issues, pulse.ValidationIssue{
+				Code:     pulse.ValidationCode(check.Name),
+				Message:  check.Details,
+				Severity: pulse.SeverityError,
+			})
+		}
 
-	if weightsValid {
-		fmt.Println("✅ Weights validation PASSED: Category weights add up to 100%")
-	} else {
-		fmt.Printf("❌ Weights validation FAILED: Category weights add up to %.0f%%, expected 100%%\n", totalWeight*100)
+		junitXML, err := pulse.FormatValidationJUnit(pulse.ValidationReport{Checks: checks, Issues: issues})
+		if err != nil {
+			fmt.Printf("Error encoding JUnit validation result: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println(string(junitXML))
+	default:
+		for _, check := range checks {
+			symbol := "✅"
+			if !check.OK {
+				symbol = "❌"
+			}
+			if check.Details != "" {
+				fmt.Printf("%s %s: %s\n", symbol, check.Name, check.Details)
+			} else {
+				fmt.Printf("%s %s\n", symbol, check.Name)
+			}
+		}
 	}
 
-	fmt.Println()
-	fmt.Println("=== Threshold Ranges Validation ===")
-
-	// Display the current thresholds
-	fmt.Printf("Global Thresholds:\n")
-	fmt.Printf("Green:  %d-%d\n", leversConfig.Global.Thresholds.Green.Min, leversConfig.Global.Thresholds.Green.Max)
-	fmt.Printf("Yellow: %d-%d\n", leversConfig.Global.Thresholds.Yellow.Min, leversConfig.Global.Thresholds.Yellow.Max)
-	fmt.Printf("Red:    %d-%d\n", leversConfig.Global.Thresholds.Red.Min, leversConfig.Global.Thresholds.Red.Max)
-	fmt.Println()
-
-	// Validate thresholds
-	thresholdsValid := true
-	var errors []string
-
-	// Validate global thresholds
-	// 1. Check that min <= max for each range
-	if leversConfig.Global.Thresholds.Green.Min > leversConfig.Global.Thresholds.Green.Max {
-		thresholdsValid = false
-		errors = append(errors, fmt.Sprintf("Green threshold min (%d) must be less than or equal to max (%d)",
-			leversConfig.Global.Thresholds.Green.Min, leversConfig.Global.Thresholds.Green.Max))
+	if failed {
+		os.Exit(exitCode)
 	}
+}
 
-	if leversConfig.Global.Thresholds.Yellow.Min > leversConfig.Global.Thresholds.Yellow.Max {
-		thresholdsValid = false
-		errors = append(errors, fmt.Sprintf("Yellow threshold min (%d) must be less than or equal to max (%d)",
-			leversConfig.Global.Thresholds.Yellow.Min, leversConfig.Global.Thresholds.Yellow.Max))
-	}
+// emitConfigMissing reports that the levers configuration itself could not
+// be loaded, as a single failing "config_missing" check, then exits with
+// pulse.ErrConfigMissing's code.
+func emitConfigMissing(err error) {
+	emitValidationChecks([]pulse.ValidationCheck{
+		{Name: string(pulse.ErrConfigMissing), OK: false, Details: err.Error()},
+	})
+}
 
-	if leversConfig.Global.Thresholds.Red.Min > leversConfig.Global.Thresholds.Red.Max {
-		thresholdsValid = false
-		errors = append(errors, fmt.Sprintf("Red threshold min (%d) must be less than or equal to max (%d)",
-			leversConfig.Global.Thresholds.Red.Min, leversConfig.Global.Thresholds.Red.Max))
+// printTransitionMatrices prints pulse.FormatTransitionMatrix for the global
+// thresholds and any category override that configures Hysteresis, so
+// operators can see the effective enter/exit cutoffs without doing the
+// margin arithmetic themselves. It's a no-op in JSON format and for
+// thresholds that don't configure Hysteresis.
+func printTransitionMatrices(leversConfig *pulse.LeversConfig) {
+	if validateFormat == "json" {
+		return
 	}
 
-	// 2. Check that ranges don't overlap
-	if leversConfig.Global.Thresholds.Yellow.Max >= leversConfig.Global.Thresholds.Green.Min {
-		thresholdsValid = false
-		errors = append(errors, fmt.Sprintf("Yellow threshold max (%d) must be less than Green threshold min (%d)",
-			leversConfig.Global.Thresholds.Yellow.Max, leversConfig.Global.Thresholds.Green.Min))
+	if matrix := pulse.FormatTransitionMatrix(leversConfig.Global.Thresholds); matrix != "" {
+		fmt.Printf("global:\n%s", matrix)
 	}
-
-	if leversConfig.Global.Thresholds.Red.Max >= leversConfig.Global.Thresholds.Yellow.Min {
-		thresholdsValid = false
-		errors = append(errors, fmt.Sprintf("Red threshold max (%d) must be less than Yellow threshold min (%d)",
-			leversConfig.Global.Thresholds.Red.Max, leversConfig.Global.Thresholds.Yellow.Min))
+	for category, thresholds := range leversConfig.Weights.CategoryThresholds {
+		if matrix := pulse.FormatTransitionMatrix(thresholds); matrix != "" {
+			fmt.Printf("category:%s:\n%s", category, matrix)
+		}
 	}
+}
 
-	// 3. Check that ranges cover the entire range from 0 to 100
-	if leversConfig.Global.Thresholds.Red.Min > 0 {
-		thresholdsValid = false
-		errors = append(errors, fmt.Sprintf("Red threshold min (%d) should be 0 to cover the entire range",
-			leversConfig.Global.Thresholds.Red.Min))
-	}
+// runValidateLeversCmd validates category weights, threshold
+// configurations, and any user-defined rules, reporting
+// pulse.ValidateWeights, pulse.ValidateThresholds, pulse.ValidateHysteresis,
+// and pulse.ValidateCustomRules as a combined set of checks.
+func runValidateLeversCmd(cmd *cobra.Command, args []string) {
+	configLoader := pulse.NewConfigLoader(configDir, dataDir)
 
-	if leversConfig.Global.Thresholds.Green.Max < 100 {
-		thresholdsValid = false
-		errors = append(errors, fmt.Sprintf("Green threshold max (%d) should be 100 to cover the entire range",
-			leversConfig.Global.Thresholds.Green.Max))
+	leversConfig, err := configLoader.LoadLeversConfig()
+	if err != nil {
+		emitConfigMissing(err)
+		return
 	}
 
-	// Also validate category-specific thresholds if they exist
-	if len(leversConfig.Weights.CategoryThresholds) > 0 {
-		fmt.Println("Category-Specific Thresholds:")
-
-		for category, thresholds := range leversConfig.Weights.CategoryThresholds {
-			fmt.Printf("%s:\n", category)
-			fmt.Printf("  Green:  %d-%d\n", thresholds.Green.Min, thresholds.Green.Max)
-			fmt.Printf("  Yellow: %d-%d\n", thresholds.Yellow.Min, thresholds.Yellow.Max)
-			fmt.Printf("  Red:    %d-%d\n", thresholds.Red.Min, thresholds.Red.Max)
-
-			// 1. Check that min <= max for each range
-			if thresholds.Green.Min > thresholds.Green.Max {
-				thresholdsValid = false
-				errors = append(errors, fmt.Sprintf("Category '%s': Green threshold min (%d) must be less than or equal to max (%d)",
-					category, thresholds.Green.Min, thresholds.Green.Max))
-			}
-
-			if thresholds.Yellow.Min > thresholds.Yellow.Max {
-				thresholdsValid = false
-				errors = append(errors, fmt.Sprintf("Category '%s': Yellow threshold min (%d) must be less than or equal to max (%d)",
-					category, thresholds.Yellow.Min, thresholds.Yellow.Max))
-			}
-
-			if thresholds.Red.Min > thresholds.Red.Max {
-				thresholdsValid = false
-				errors = append(errors, fmt.Sprintf("Category '%s': Red threshold min (%d) must be less than or equal to max (%d)",
-					category, thresholds.Red.Min, thresholds.Red.Max))
-			}
-
-			// 2. Check that ranges don't overlap
-			if thresholds.Yellow.Max >= thresholds.Green.Min {
-				thresholdsValid = false
-				errors = append(errors, fmt.Sprintf("Category '%s': Yellow threshold max (%d) must be less than Green threshold min (%d)",
-					category, thresholds.Yellow.Max, thresholds.Green.Min))
-			}
+	checks := append([]pulse.ValidationCheck{pulse.ValidateWeights(leversConfig)}, pulse.ValidateThresholds(leversConfig)...)
+	checks = append(checks, pulse.ValidateHysteresis(leversConfig))
+	checks = append(checks, pulse.ValidateCustomRules(leversConfig)...)
+	printTransitionMatrices(leversConfig)
+	emitValidationChecks(checks)
+}
 
-			if thresholds.Red.Max >= thresholds.Yellow.Min {
-				thresholdsValid = false
-				errors = append(errors, fmt.Sprintf("Category '%s': Red threshold max (%d) must be less than Yellow threshold min (%d)",
-					category, thresholds.Red.Max, thresholds.Yellow.Min))
-			}
+// runValidateRulesCmd evaluates every levers.yaml metric_rules entry
+// against the currently loaded metric data, reporting one ValidationCheck
+// per rule via pulse.ValidateMetricRules.
+func runValidateRulesCmd(cmd *cobra.Command, args []string) {
+	configLoader := pulse.NewConfigLoader(configDir, dataDir)
 
-			// 3. Check that ranges cover the entire range from 0 to 100
-			if thresholds.Red.Min > 0 {
-				thresholdsValid = false
-				errors = append(errors, fmt.Sprintf("Category '%s': Red threshold min (%d) should be 0 to cover the entire range",
-					category, thresholds.Red.Min))
-			}
+	leversConfig, err := configLoader.LoadLeversConfig()
+	if err != nil {
+		emitConfigMissing(err)
+		return
+	}
 
-			if thresholds.Green.Max < 100 {
-				thresholdsValid = false
-				errors = append(errors, fmt.Sprintf("Category '%s': Green threshold max (%d) should be 100 to cover the entire range",
-					category, thresholds.Green.Max))
-			}
-		}
+	metricsConfig, err := configLoader.LoadMetricsConfig()
+	if err != nil {
+		emitConfigMissing(err)
+		return
 	}
 
-	// Display threshold validation result
-	if thresholdsValid {
-		fmt.Println("✅ Thresholds validation PASSED: All threshold ranges are valid and don't overlap")
-	} else {
-		fmt.Println("❌ Thresholds validation FAILED:")
-		for _, err := range errors {
-			fmt.Printf("   - %s\n", err)
-		}
+	metricsData, err := configLoader.LoadMetricsData()
+	if err != nil {
+		emitConfigMissing(err)
+		return
 	}
 
-	// Overall validation result
-	fmt.Println()
-	fmt.Println("=== Overall Validation Result ===")
-	if weightsValid && thresholdsValid {
-		fmt.Println("✅ All validations PASSED")
-	} else {
-		fmt.Println("❌ Some validations FAILED")
-		os.Exit(1)
+	processor := pulse.NewMetricsProcessor(metricsConfig, leversConfig, metricsData)
+	checks, err := pulse.ValidateMetricRules(processor)
+	if err != nil {
+		emitValidationChecks([]pulse.ValidationCheck{{Name: "metric_rules", OK: false, Details: err.Error()}})
+		return
 	}
+	emitValidationChecks(checks)
 }
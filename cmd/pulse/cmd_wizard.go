@@ -0,0 +1,123 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/scottbrown/pulse"
+)
+
+// promptWizard prints "label [default]: ", reads one line from reader, and
+// returns it trimmed. An empty line returns defaultValue instead.
+func promptWizard(reader *bufio.Reader, label, defaultValue string) (string, error) {
+	if defaultValue != "" {
+		fmt.Printf("%s [%s]: ", label, defaultValue)
+	} else {
+		fmt.Printf("%s: ", label)
+	}
+
+	line, err := reader.ReadString('\n')
+	if err != nil && line == "" {
+		return "", fmt.Errorf("failed to read input: %w", err)
+	}
+
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return defaultValue, nil
+	}
+	return line, nil
+}
+
+// runUpdateWizard interactively walks the user through picking a known
+// metric reference (or typing a new one) and entering its value, applying
+// the same reference-format and value-bounds checks as the non-interactive
+// "update" command, then saves through configLoader.
+func runUpdateWizard(configLoader *pulse.ConfigLoader, metricsProcessor *pulse.MetricsProcessor, metricsData *pulse.MetricsData) error {
+	reader := bufio.NewReader(os.Stdin)
+
+	refs := make([]string, 0, len(metricsData.Metrics))
+	for _, metric := range metricsData.Metrics {
+		refs = append(refs, metric.Reference)
+	}
+	sort.Strings(refs)
+
+	fmt.Println("Known metrics:")
+	for i, ref := range refs {
+		fmt.Printf("  %d) %s\n", i+1, ref)
+	}
+	fmt.Println("Enter a number to update a known metric, or type a new reference (category.TYPE.name):")
+
+	reference, err := promptWizard(reader, "Metric", "")
+	if err != nil {
+		return err
+	}
+	if index, convErr := strconv.Atoi(reference); convErr == nil && index >= 1 && index <= len(refs) {
+		reference = refs[index-1]
+	}
+
+	if !strings.Contains(reference, ".") || len(strings.Split(reference, ".")) != 3 {
+		return fmt.Errorf("invalid metric reference format. Expected format: category.TYPE.name")
+	}
+
+	valueInput, err := promptWizard(reader, "Value", "")
+	if err != nil {
+		return err
+	}
+
+	value, err := strconv.ParseFloat(valueInput, 64)
+	if err != nil {
+		return fmt.Errorf("error parsing metric value: %w", err)
+	}
+	if math.IsNaN(value) || math.IsInf(value, 0) || value < -1000000 || value > 1000000 {
+		return fmt.Errorf("metric value out of reasonable bounds")
+	}
+
+	author, err := promptWizard(reader, "Author (optional)", updateAuthor)
+	if err != nil {
+		return err
+	}
+
+	if err := metricsProcessor.UpdateMetricWithAuthor(reference, value, author); err != nil {
+		return fmt.Errorf("error updating metric: %w", err)
+	}
+
+	if err := configLoader.SaveMetricsData(metricsData); err != nil {
+		return fmt.Errorf("error saving metrics data: %w", err)
+	}
+
+	fmt.Printf("Metric %s updated to %s\n", reference, valueInput)
+	return nil
+}
+
+// runInitWizard interactively confirms the target config/data directories
+// before writing default configuration files, so a first-time user sees
+// where files will land rather than having to already know --config-dir
+// and --data-dir.
+func runInitWizard(defaultConfigDir, defaultDataDir string) error {
+	reader := bufio.NewReader(os.Stdin)
+
+	targetConfigDir, err := promptWizard(reader, "Config directory", defaultConfigDir)
+	if err != nil {
+		return err
+	}
+
+	targetDataDir, err := promptWizard(reader, "Data directory", defaultDataDir)
+	if err != nil {
+		return err
+	}
+
+	configLoader := pulse.NewConfigLoader(targetConfigDir, targetDataDir)
+	if err := configLoader.CreateDefaultConfigFiles(); err != nil {
+		return fmt.Errorf("error creating default configuration files: %w", err)
+	}
+
+	fmt.Printf("Default configuration files created in:\n")
+	fmt.Printf("  Config directory: %s\n", targetConfigDir)
+	fmt.Printf("  Data directory: %s\n", targetDataDir)
+	return nil
+}
@@ -2,13 +2,12 @@ package main
 
 import (
 	"fmt"
-	"math"
 	"os"
 	"path/filepath"
-	"strconv"
 	"strings"
 
 	"github.com/scottbrown/pulse"
+	"github.com/scottbrown/pulse/output"
 	"github.com/spf13/cobra"
 )
 
@@ -19,14 +18,50 @@ var (
 )
 
 var (
-	configDir     string
-	dataDir       string
-	category      string
-	format        string
-	outputFile    string
-	metricRef     string
-	metricVal     string
-	scoringMethod string
+	configDir               string
+	dataDir                 string
+	category                string
+	format                  string
+	outputFile              string
+	metricRef               string
+	metricVal               string
+	updateAuthor            string
+	scoringMethod           string
+	live                    bool
+	alerts                  bool
+	alertWebhook            string
+	notify                  bool
+	trendDays               int
+	trendSince              string
+	collectWatch            bool
+	collectInterval         int
+	serveAddr               string
+	serveCertFile           string
+	serveKeyFile            string
+	outputFormat            string
+	outputTemplate          string
+	updateInteractive       bool
+	initInteractive         bool
+	validateFormat          string
+	updateFromFile          string
+	updateBatchFormat       string
+	updateDryRun            bool
+	reportWithTrend         bool
+	serveAuthToken          string
+	calibrateMethod         string
+	calibrateTargetRedShare float64
+	calibrateDays           int
+	calibrateWrite          bool
+	reportColor             bool
+	reportNoColor           bool
+	reportNoThresholds      bool
+	reportThresholdsOnly    bool
+	reportInput             string
+	reportSaveSnapshot      bool
+	reportPrintReady        bool
+	scoreTrendDays          int
+	scoreTrendSince         string
+	importFormat            string
 )
 
 func main() {
@@ -52,6 +87,13 @@ func main() {
 	rootCmd.PersistentFlags().StringVar(&configDir, "config-dir", defaultConfigDir, "Directory containing configuration files")
 	rootCmd.PersistentFlags().StringVar(&dataDir, "data-dir", defaultDataDir, "Directory containing data files")
 
+	// Persistent output-rendering flags, consulted by commands that print a
+	// typed output.Result (currently "list categories").
+	// Named --output-format rather than --output/-o to avoid colliding with
+	// reportCmd's existing --output/-o (a file path, not a format).
+	rootCmd.PersistentFlags().StringVar(&outputFormat, "output-format", "table", "Output format: table, wide, json, yaml, csv, or template")
+	rootCmd.PersistentFlags().StringVar(&outputTemplate, "template", "", "Go text/template source to execute when --output-format=template")
+
 	// Add report command
 	reportCmd := &cobra.Command{
 		Use:   "report",
@@ -61,9 +103,21 @@ func main() {
 	}
 
 	reportCmd.Flags().StringVarP(&category, "category", "c", "", "Generate report for a specific category")
-	reportCmd.Flags().StringVarP(&format, "format", "f", "text", "Report format (text or json)")
+	reportCmd.Flags().StringVarP(&format, "format", "f", "text", "Report format (text, json, table, or pdf)")
 	reportCmd.Flags().StringVarP(&outputFile, "output", "o", "", "Output file (default: stdout)")
+	reportCmd.Flags().BoolVar(&reportColor, "color", false, "Force colorized status cells in --format table output")
+	reportCmd.Flags().BoolVar(&reportNoColor, "no-color", false, "Disable colorized status cells in --format table output")
 	reportCmd.Flags().StringVar(&scoringMethod, "scoring-method", "median", "Scoring method to use (median or average)")
+	reportCmd.Flags().BoolVar(&live, "live", false, "Resolve KPI/KRI values live via their configured query/endpoint before scoring")
+	reportCmd.Flags().BoolVar(&reportWithTrend, "with-trend", false, "Include a sparkline, 7/30/90-day delta, and trend arrow per metric (a history array in JSON)")
+	reportCmd.Flags().BoolVar(&alerts, "alerts", false, "Evaluate degraded/stale metrics and dispatch alerts to stdout (and --alert-webhook, if set)")
+	reportCmd.Flags().StringVar(&alertWebhook, "alert-webhook", "", "Webhook URL to POST alerts to in addition to stdout, requires --alerts")
+	reportCmd.Flags().BoolVar(&notify, "notify", false, "Dispatch notifications.yaml channels for any overall/category status transition since the last report")
+	reportCmd.Flags().BoolVar(&reportNoThresholds, "no-thresholds", false, "Compute scores but skip Green/Yellow/Red classification and coloring")
+	reportCmd.Flags().BoolVar(&reportThresholdsOnly, "thresholds-only", false, "Re-evaluate thresholds from a previously emitted --format json report (see --input) instead of recomputing scores; exits 1 if any metric is Red")
+	reportCmd.Flags().StringVar(&reportInput, "input", "", "Path to a JSON report (as emitted by --format json) to re-evaluate, required with --thresholds-only")
+	reportCmd.Flags().BoolVar(&reportSaveSnapshot, "save-snapshot", false, "Persist this report's OverallScore to the snapshot store (<data-dir>/snapshots), for later replay with \"score-trend\"")
+	reportCmd.Flags().BoolVar(&reportPrintReady, "print-ready", false, "In --format pdf, render status cells as CMYK spot-colored circles instead of RGB status text, for accurate reproduction on an offset press")
 
 	// Add update command
 	updateCmd := &cobra.Command{
@@ -75,8 +129,99 @@ func main() {
 
 	updateCmd.Flags().StringVarP(&metricRef, "metric", "m", "", "Metric reference (e.g., app_sec.KPI.vuln_remediation_time)")
 	updateCmd.Flags().StringVarP(&metricVal, "value", "v", "", "Metric value")
-	updateCmd.MarkFlagRequired("metric")
-	updateCmd.MarkFlagRequired("value")
+	updateCmd.Flags().StringVar(&updateAuthor, "author", "", "Who or what recorded this observation, for history attribution")
+	updateCmd.Flags().BoolVarP(&updateInteractive, "interactive", "i", false, "Walk through picking a metric and entering its value interactively instead of passing --metric/--value")
+	updateCmd.Flags().StringVar(&updateFromFile, "from-file", "", "Batch-update metrics from a CSV/JSON/YAML file of {reference, value, timestamp?} records (\"-\" reads stdin)")
+	updateCmd.Flags().StringVar(&updateBatchFormat, "batch-format", "", "Format of --from-file: csv, json, or yaml (default: inferred from the file extension)")
+	updateCmd.Flags().BoolVar(&updateDryRun, "dry-run", false, "With --from-file, validate and print the batch without saving")
+
+	// Add trend command
+	trendCmd := &cobra.Command{
+		Use:   "trend",
+		Short: "Show how a metric's score has moved over time",
+		Long:  `Replay a metric's recorded history through the current scoring bands and render it as a sparkline or, with --format json, a series of {timestamp, value, score, status} points.`,
+		Run:   runTrendCmd,
+	}
+
+	trendCmd.Flags().StringVarP(&metricRef, "metric", "m", "", "Metric reference (e.g., app_sec.KPI.vuln_remediation_time)")
+	trendCmd.Flags().IntVar(&trendDays, "days", 30, "Number of days of history to include")
+	trendCmd.Flags().StringVar(&trendSince, "since", "", "How far back to look, as a duration like 30d or 12h (overrides --days)")
+	trendCmd.Flags().StringVarP(&format, "format", "f", "text", "Report format (text or json)")
+	trendCmd.MarkFlagRequired("metric")
+
+	// Add score-trend command
+	scoreTrendCmd := &cobra.Command{
+		Use:   "score-trend",
+		Short: "Show how each category's KPI/KRI scores have moved over time",
+		Long:  `Replay the OverallScore snapshots saved by "report --save-snapshot" and report, per category, its score delta, status transitions, and a min/max/mean per metric, with an ASCII sparkline of its KRI trajectory.`,
+		Run:   runScoreTrendCmd,
+	}
+
+	scoreTrendCmd.Flags().IntVar(&scoreTrendDays, "days", 30, "Number of days of snapshots to include")
+	scoreTrendCmd.Flags().StringVar(&scoreTrendSince, "since", "", "How far back to look, as a duration like 30d or 12h (overrides --days)")
+	scoreTrendCmd.Flags().StringVarP(&format, "format", "f", "text", "Report format (text or json)")
+
+	// Add query command
+	queryCmd := &cobra.Command{
+		Use:   "query <expression>",
+		Short: "Evaluate an aggregation query over metrics",
+		Long:  `Evaluate a PromQL-lite expression against the currently loaded metrics, e.g. "avg(SEC.KPI.*)", "max(OPS.KRI.*) by category", "rate(FIN.KPI.revenue[30d])", or "sum(*.KPI.*) by category * weight(category)".`,
+		Args:  cobra.ExactArgs(1),
+		Run:   runQueryCmd,
+	}
+
+	// Add import command
+	importCmd := &cobra.Command{
+		Use:   "import <file>",
+		Short: "Bulk-ingest metric observations from a Prometheus/OpenMetrics or CSV feed",
+		Long:  `Read a bulk feed of metric observations from a Prometheus text exposition or OpenMetrics scrape (each sample's reference built from its category/type/id labels) or a "reference,value,timestamp" CSV, validate every reference, and apply the ones that pass. A bad line is reported and skipped rather than aborting the whole import.`,
+		Args:  cobra.ExactArgs(1),
+		Run:   runImportCmd,
+	}
+	importCmd.Flags().StringVar(&importFormat, "format", "csv", "Import format: prom, openmetrics, or csv")
+
+	// Add diff command
+	diffCmd := &cobra.Command{
+		Use:   "diff <old-file> <new-file>",
+		Short: "Compare two metrics snapshots",
+		Long:  `Score two metrics snapshot files (the {reference, value, timestamp?} batch shape accepted by "update --from-file") and report the categories and metrics whose status or score changed between them, along with the net posture change.`,
+		Args:  cobra.ExactArgs(2),
+		Run:   runDiffCmd,
+	}
+
+	diffCmd.Flags().StringVarP(&format, "format", "f", "text", "Report format (text or json)")
+
+	// Add notify command
+	notifyCmd := &cobra.Command{
+		Use:   "notify",
+		Short: "Dispatch notifications for status transitions",
+		Long:  `Compute the current OverallScore and dispatch notifications.yaml channels for any overall or category status transition since the last "pulse report --notify" or "pulse notify" run.`,
+		Run:   runNotifyCmd,
+	}
+
+	// Add collect command
+	collectCmd := &cobra.Command{
+		Use:   "collect",
+		Short: "Derive metric values from build logs and test reports",
+		Long:  `Extract metric values from files using rules configured in collectors.yaml (regex, JSONPath, or key=value) and update the corresponding metrics.`,
+		Run:   runCollectCmd,
+	}
+
+	collectCmd.Flags().BoolVar(&collectWatch, "watch", false, "Keep running and re-collect on an interval instead of exiting after one pass")
+	collectCmd.Flags().IntVar(&collectInterval, "interval", 30, "Seconds between collection passes in --watch mode")
+
+	// Add serve command
+	serveCmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Expose metrics for Prometheus and a JSON REST API",
+		Long:  `Start an HTTP server exposing loaded KPIs/KRIs and their scoring bands at /metrics in Prometheus text exposition format, a /healthz endpoint, and a JSON REST API under /api (report, report/{category}, metrics, metrics/{ref}, categories, levers, validate). Config is reloaded on every request and on SIGHUP. Set --auth-token to require a bearer token on /api requests.`,
+		Run:   runServeCmd,
+	}
+
+	serveCmd.Flags().StringVar(&serveAddr, "addr", ":9090", "Address to listen on")
+	serveCmd.Flags().StringVar(&serveCertFile, "tls-cert", "", "TLS certificate file (requires --tls-key)")
+	serveCmd.Flags().StringVar(&serveKeyFile, "tls-key", "", "TLS private key file (requires --tls-cert)")
+	serveCmd.Flags().StringVar(&serveAuthToken, "auth-token", "", "Require this bearer token on /api requests (unset disables auth)")
 
 	// Add list command
 	listCmd := &cobra.Command{
@@ -131,7 +276,7 @@ func main() {
 	validateCmd := &cobra.Command{
 		Use:   "validate",
 		Short: "Validate all lever configurations",
-		Long:  `Validate both category weights and threshold configurations.`,
+		Long:  `Validate both category weights and threshold configurations. Exits non-zero with a code identifying the failing check (see "pulse validate --format json"), so it can gate a CI pipeline.`,
 		Run:   runValidateLeversCmd,
 	}
 
@@ -149,8 +294,45 @@ func main() {
 		Run:   runValidateThresholdsCmd,
 	}
 
+	validateConfigCmd := &cobra.Command{
+		Use:   "validate-config",
+		Short: "Validate the full configuration: levers, metric references, and data",
+		Long:  `Run pulse's full validation suite: everything "validate" covers (weights, thresholds, hysteresis, custom rules), plus whether every data point's metric reference resolves and matches the configured reference_schema, every defined KPI/KRI has at least one data point, no data timestamp is in the future, and --scoring-method names a supported method.`,
+		Run:   runValidateConfigCmd,
+	}
+
+	validateRulesCmd := &cobra.Command{
+		Use:   "validate-rules",
+		Short: "Validate metric threshold rules",
+		Long:  `Evaluate every levers.yaml metric_rules entry against the loaded metric data, failing CI when a metric's (or aggregate's) current value crosses its configured lower_bound floor or ceiling.`,
+		Run:   runValidateRulesCmd,
+	}
+
+	// --format json prints {"status":"pass"|"fail","checks":[...]} and
+	// --format sarif prints a SARIF 2.1.0 log instead of text, and the
+	// process exits with a code identifying which check failed (see
+	// pulse.ValidationCode.ExitCode), so CI pipelines can gate on lever
+	// configuration without parsing text output.
+	validateCmd.Flags().StringVar(&validateFormat, "format", "text", "Output format: text, json, sarif, or junit")
+	validateWeightsCmd.Flags().StringVar(&validateFormat, "format", "text", "Output format: text, json, sarif, or junit")
+	validateThresholdsCmd.Flags().StringVar(&validateFormat, "format", "text", "Output format: text, json, sarif, or junit")
+	validateConfigCmd.Flags().StringVar(&validateFormat, "format", "text", "Output format: text, json, sarif, or junit")
+	validateConfigCmd.Flags().StringVar(&scoringMethod, "scoring-method", "median", "Scoring method to validate (median, average, percentile, or weighted)")
+	validateRulesCmd.Flags().StringVar(&validateFormat, "format", "text", "Output format: text, json, sarif, or junit")
+
 	// Add subcommands to levers command
-	leversCmd.AddCommand(allLeversCmd, globalThresholdsCmd, scoringBandsCmd, categoryWeightsCmd, categoryThresholdsCmd, validateCmd, validateWeightsCmd, validateThresholdsCmd)
+	leversCmd.AddCommand(allLeversCmd, globalThresholdsCmd, scoringBandsCmd, categoryWeightsCmd, categoryThresholdsCmd, validateCmd, validateWeightsCmd, validateThresholdsCmd, validateConfigCmd, validateRulesCmd)
+
+	calibrateThresholdsCmd := &cobra.Command{
+		Use:   "calibrate-thresholds",
+		Short: "Propose Green/Yellow/Red thresholds from historical scores",
+		Long:  `Replay every metric's recorded history through the current scoring bands to build an empirical score corpus, then propose Green/Yellow/Red cutoffs from its distribution. Prints the recommended levers.yaml fragment; with --write, patches levers.yaml in place after confirming the result still passes validation.`,
+		Run:   runCalibrateThresholdsCmd,
+	}
+	calibrateThresholdsCmd.Flags().StringVar(&calibrateMethod, "method", "quantile", "Calibration method: quantile, kmeans, or jenks")
+	calibrateThresholdsCmd.Flags().Float64Var(&calibrateTargetRedShare, "target-red-share", 0.2, "Fraction of the score corpus quantile calibration assigns to Red")
+	calibrateThresholdsCmd.Flags().IntVar(&calibrateDays, "days", 90, "Number of days of history to calibrate from")
+	calibrateThresholdsCmd.Flags().BoolVar(&calibrateWrite, "write", false, "Patch levers.yaml in place instead of just printing the recommended fragment")
 
 	// Add metrics subcommand
 	metricsCmd := &cobra.Command{
@@ -166,6 +348,7 @@ func main() {
 		Long:  `List all available metrics with their current values.`,
 		Run:   runListMetricsCmd,
 	}
+	listMetricsCmd.Flags().BoolVar(&live, "live", false, "Resolve KPI/KRI values live via their configured query/endpoint before listing")
 
 	// Add list-files subcommand
 	listFilesCmd := &cobra.Command{
@@ -204,6 +387,8 @@ func main() {
 		Run:   runInitCmd,
 	}
 
+	initCmd.Flags().BoolVarP(&initInteractive, "interactive", "i", false, "Walk through confirming the config/data directories interactively")
+
 	// Add subcommands to list command
 	listCmd.AddCommand(categoriesCmd)
 
@@ -218,7 +403,15 @@ func main() {
 	}
 
 	// Add commands to root command
-	rootCmd.AddCommand(reportCmd, updateCmd, listCmd, metricsCmd, leversCmd, initCmd, versionCmd)
+	rootCmd.AddCommand(reportCmd, updateCmd, trendCmd, scoreTrendCmd, queryCmd, importCmd, diffCmd, notifyCmd, collectCmd, serveCmd, listCmd, metricsCmd, leversCmd, initCmd, versionCmd, calibrateThresholdsCmd)
+
+	// Add completion and manpages command; registered last since it needs
+	// rootCmd fully assembled to walk the command tree.
+	rootCmd.AddCommand(newCompletionCmd(rootCmd))
+
+	// Wire dynamic tab-completion for --metric/--category/--scoring-method
+	// onto every command that defines them.
+	registerCompletions(reportCmd, updateCmd, trendCmd, validateConfigCmd)
 
 	// Execute the root command
 	if err := rootCmd.Execute(); err != nil {
@@ -227,139 +420,20 @@ func main() {
 	}
 }
 
-func runReportCmd(cmd *cobra.Command, args []string) {
-	// Initialize the config loader
-	configLoader := pulse.NewConfigLoader(configDir, dataDir)
-
-	// Load configurations
-	metricsConfig, err := configLoader.LoadMetricsConfig()
-	if err != nil {
-		fmt.Printf("Error loading metrics config: %v\n", err)
-		os.Exit(1)
-	}
-
-	leversConfig, err := configLoader.LoadLeversConfig()
-	if err != nil {
-		fmt.Printf("Error loading levers config: %v\n", err)
-		os.Exit(1)
-	}
-
-	metricsData, err := configLoader.LoadMetricsData()
-	if err != nil {
-		fmt.Printf("Error loading metrics data: %v\n", err)
-		os.Exit(1)
-	}
-
-	// Initialize the metrics processor
-	metricsProcessor := pulse.NewMetricsProcessor(metricsConfig, leversConfig, metricsData)
-
-	// Initialize the score calculator with the specified scoring method
-	var scoringMethodEnum pulse.ScoringMethod
-	if scoringMethod == "average" {
-		scoringMethodEnum = pulse.AverageScoring
-	} else {
-		// Default to median scoring
-		scoringMethodEnum = pulse.MedianScoring
-	}
-
-	scoreCalculator := pulse.NewScoreCalculator(metricsProcessor, scoringMethodEnum)
-
-	// Initialize the report generator
-	reportGenerator := pulse.NewReportGenerator(scoreCalculator)
-
-	// Generate the report
-	var reportContent string
-	var reportErr error
-
-	reportFormat := pulse.TextFormat
-	if format == "json" {
-		reportFormat = pulse.JSONFormat
-	}
-
-	if category != "" {
-		reportContent, reportErr = reportGenerator.GenerateCategoryReport(category, reportFormat)
-	} else {
-		reportContent, reportErr = reportGenerator.GenerateOverallReport(reportFormat)
-	}
-
-	if reportErr != nil {
-		fmt.Printf("Error generating report: %v\n", reportErr)
-		os.Exit(1)
-	}
-
-	// Output the report
-	if outputFile != "" {
-		err := os.WriteFile(outputFile, []byte(reportContent), 0600)
-		if err != nil {
-			fmt.Printf("Error writing report to file: %v\n", err)
-			os.Exit(1)
-		}
-		fmt.Printf("Report written to %s\n", outputFile)
-	} else {
-		fmt.Println(reportContent)
-	}
-}
-
-func runUpdateCmd(cmd *cobra.Command, args []string) {
-	// Initialize the config loader
-	configLoader := pulse.NewConfigLoader(configDir, dataDir)
-
-	// Load configurations
-	metricsConfig, err := configLoader.LoadMetricsConfig()
+// printResult renders result to stdout using the Printer selected by
+// --output-format (and --template, when that format is "template"), exiting
+// with an error if the format is invalid or rendering fails.
+func printResult(result output.Result) {
+	printer, err := output.NewPrinter(output.Format(outputFormat), outputTemplate)
 	if err != nil {
-		fmt.Printf("Error loading metrics config: %v\n", err)
-		os.Exit(1)
-	}
-
-	leversConfig, err := configLoader.LoadLeversConfig()
-	if err != nil {
-		fmt.Printf("Error loading levers config: %v\n", err)
-		os.Exit(1)
-	}
-
-	metricsData, err := configLoader.LoadMetricsData()
-	if err != nil {
-		fmt.Printf("Error loading metrics data: %v\n", err)
-		os.Exit(1)
-	}
-
-	// Initialize the metrics processor
-	metricsProcessor := pulse.NewMetricsProcessor(metricsConfig, leversConfig, metricsData)
-
-	// Validate metric reference format
-	if !strings.Contains(metricRef, ".") || len(strings.Split(metricRef, ".")) != 3 {
-		fmt.Printf("Error: Invalid metric reference format. Expected format: category.TYPE.name\n")
-		os.Exit(1)
-	}
-
-	// Parse and validate the metric value
-	value, err := strconv.ParseFloat(metricVal, 64)
-	if err != nil {
-		fmt.Printf("Error parsing metric value: %v\n", err)
-		os.Exit(1)
-	}
-
-	// Check for reasonable bounds on the value
-	if math.IsNaN(value) || math.IsInf(value, 0) || value < -1000000 || value > 1000000 {
-		fmt.Printf("Error: Metric value out of reasonable bounds\n")
-		os.Exit(1)
-	}
-
-	// Update the metric
-	err = metricsProcessor.UpdateMetric(metricRef, value)
-	if err != nil {
-		fmt.Printf("Error updating metric: %v\n", err)
+		fmt.Println(err)
 		os.Exit(1)
 	}
 
-	// Save the updated metrics data
-	err = configLoader.SaveMetricsData(metricsData)
-	if err != nil {
-		fmt.Printf("Error saving metrics data: %v\n", err)
+	if err := printer.Print(os.Stdout, result); err != nil {
+		fmt.Printf("Error printing output: %v\n", err)
 		os.Exit(1)
 	}
-
-	fmt.Printf("Metric %s updated to %s\n", metricRef, metricVal)
 }
 
 func runListMetricFilesCmd(cmd *cobra.Command, args []string) {
@@ -404,58 +478,6 @@ func runListMetricFilesCmd(cmd *cobra.Command, args []string) {
 	}
 }
 
-func runCreateMetricFileCmd(cmd *cobra.Command, args []string) {
-	// Initialize the config loader
-	configLoader := pulse.NewConfigLoader(configDir, dataDir)
-
-	// Create the metric file
-	fileName := args[0]
-	err := configLoader.CreateMetricFile(fileName)
-	if err != nil {
-		fmt.Printf("Error creating metric file: %v\n", err)
-		os.Exit(1)
-	}
-
-	fmt.Printf("Metric file '%s' created successfully.\n", fileName)
-}
-
-func runListMetricsCmd(cmd *cobra.Command, args []string) {
-	// Initialize the config loader
-	configLoader := pulse.NewConfigLoader(configDir, dataDir)
-
-	// Load configurations
-	metricsConfig, err := configLoader.LoadMetricsConfig()
-	if err != nil {
-		fmt.Printf("Error loading metrics config: %v\n", err)
-		os.Exit(1)
-	}
-
-	leversConfig, err := configLoader.LoadLeversConfig()
-	if err != nil {
-		fmt.Printf("Error loading levers config: %v\n", err)
-		os.Exit(1)
-	}
-
-	metricsData, err := configLoader.LoadMetricsData()
-	if err != nil {
-		fmt.Printf("Error loading metrics data: %v\n", err)
-		os.Exit(1)
-	}
-
-	// Initialize the metrics processor
-	metricsProcessor := pulse.NewMetricsProcessor(metricsConfig, leversConfig, metricsData)
-
-	// Get all metrics
-	metrics := metricsProcessor.GetAllMetrics()
-
-	// Display metrics
-	fmt.Println("Available Metrics:")
-	fmt.Println("------------------")
-	for _, metric := range metrics {
-		fmt.Printf("%s: %.2f (as of %s)\n", metric.Reference, metric.Value, metric.Timestamp.Format("2006-01-02"))
-	}
-}
-
 func runListCategoriesCmd(cmd *cobra.Command, args []string) {
 	// Initialize the config loader
 	configLoader := pulse.NewConfigLoader(configDir, dataDir)
@@ -473,27 +495,36 @@ func runListCategoriesCmd(cmd *cobra.Command, args []string) {
 	// Get all categories
 	categories := metricsProcessor.GetAllCategories()
 
-	// Display categories
-	fmt.Println("Available Categories:")
-	fmt.Println("--------------------")
+	// Categories nest KPIs/KRIs, which a flat Result row can't represent
+	// directly; list gives the structured summary (counts, description) a
+	// script or --output-format json/csv consumer needs, while "report" and
+	// "levers" remain the place to inspect individual KPIs/KRIs in depth.
+	result := output.Result{
+		Headers:     []string{"id", "name", "description"},
+		WideHeaders: []string{"kpi_count", "kri_count"},
+	}
 	for _, category := range categories {
-		fmt.Printf("%s (%s): %s\n", category.Name, category.ID, category.Description)
+		result.Rows = append(result.Rows, map[string]interface{}{
+			"id":          category.ID,
+			"name":        category.Name,
+			"description": category.Description,
+			"kpi_count":   len(category.KPIs),
+			"kri_count":   len(category.KRIs),
+		})
+	}
 
-		fmt.Println("  KPIs:")
-		for _, kpi := range category.KPIs {
-			fmt.Printf("  - %s (%s): %s [Target: %.2f %s]\n", kpi.Name, kpi.ID, kpi.Description, kpi.Target, kpi.Unit)
-		}
+	printResult(result)
+}
 
-		fmt.Println("  KRIs:")
-		for _, kri := range category.KRIs {
-			fmt.Printf("  - %s (%s): %s [Threshold: %.2f %s]\n", kri.Name, kri.ID, kri.Description, kri.Threshold, kri.Unit)
+func runInitCmd(cmd *cobra.Command, args []string) {
+	if initInteractive {
+		if err := runInitWizard(configDir, dataDir); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
 		}
-
-		fmt.Println()
+		return
 	}
-}
 
-func runInitCmd(cmd *cobra.Command, args []string) {
 	var targetConfigDir, targetDataDir string
 
 	if len(args) > 0 {
@@ -642,414 +673,41 @@ func runViewCategoryWeightsCmd(cmd *cobra.Command, args []string) {
 	}
 }
 
-// runViewCategoryThresholdsCmd displays category-specific thresholds
-func runViewCategoryThresholdsCmd(cmd *cobra.Command, args []string) {
-	// Initialize the config loader
-	configLoader := pulse.NewConfigLoader(configDir, dataDir)
-
-	// Load levers configuration
-	leversConfig, err := configLoader.LoadLeversConfig()
-	if err != nil {
-		fmt.Printf("Error loading levers config: %v\n", err)
-		os.Exit(1)
-	}
-
-	// Display category-specific thresholds
-	fmt.Println("Category-Specific Thresholds:")
-	fmt.Println("----------------------------")
-	if len(leversConfig.Weights.CategoryThresholds) == 0 {
-		fmt.Println("No category-specific thresholds defined.")
-	} else {
-		for category, thresholds := range leversConfig.Weights.CategoryThresholds {
-			fmt.Printf("%s:\n", category)
-			fmt.Printf("  Green:  %d-%d\n", thresholds.Green.Min, thresholds.Green.Max)
-			fmt.Printf("  Yellow: %d-%d\n", thresholds.Yellow.Min, thresholds.Yellow.Max)
-			fmt.Printf("  Red:    %d-%d\n", thresholds.Red.Min, thresholds.Red.Max)
-		}
-	}
-}
-
-// runValidateWeightsCmd validates that category weights add up to 100%
+// runValidateWeightsCmd validates that category weights add up to 100%, and
+// that any category with explicitly-weighted KPIs or KRIs has those sum to
+// 100% too.
 func runValidateWeightsCmd(cmd *cobra.Command, args []string) {
-	// Initialize the config loader
 	configLoader := pulse.NewConfigLoader(configDir, dataDir)
 
-	// Load levers configuration
 	leversConfig, err := configLoader.LoadLeversConfig()
 	if err != nil {
-		fmt.Printf("Error loading levers config: %v\n", err)
-		os.Exit(1)
-	}
-
-	// Sum up the category weights
-	var totalWeight float64
-	for _, weight := range leversConfig.Weights.Categories {
-		totalWeight += weight
-	}
-
-	// Check if the weights add up to 100% (1.0)
-	fmt.Println("Category Weights Validation:")
-	fmt.Println("--------------------------")
-
-	if len(leversConfig.Weights.Categories) == 0 {
-		fmt.Println("No category weights defined.")
-		os.Exit(1)
-	}
-
-	// Display all category weights
-	for category, weight := range leversConfig.Weights.Categories {
-		fmt.Printf("%s: %.2f (%.0f%%)\n", category, weight, weight*100)
-	}
-	fmt.Println()
-
-	// Display the total and validation result
-	fmt.Printf("Total weight: %.2f (%.0f%%)\n", totalWeight, totalWeight*100)
-
-	// Use a small epsilon for floating point comparison
-	const epsilon = 0.0001
-	if totalWeight >= 1.0-epsilon && totalWeight <= 1.0+epsilon {
-		fmt.Println("✅ Validation PASSED: Category weights add up to 100%")
-	} else {
-		fmt.Printf("❌ Validation FAILED: Category weights add up to %.0f%%, expected 100%%\n", totalWeight*100)
-		os.Exit(1)
+		emitConfigMissing(err)
+		return
 	}
-}
-
-// runValidateLeversCmd validates both category weights and threshold configurations
-func runValidateLeversCmd(cmd *cobra.Command, args []string) {
-	// Initialize the config loader
-	configLoader := pulse.NewConfigLoader(configDir, dataDir)
 
-	// Load levers configuration
-	leversConfig, err := configLoader.LoadLeversConfig()
+	metricsConfig, err := configLoader.LoadMetricsConfig()
 	if err != nil {
-		fmt.Printf("Error loading levers config: %v\n", err)
-		os.Exit(1)
-	}
-
-	fmt.Println("Running complete validation of levers configuration...")
-	fmt.Println()
-
-	// First validate weights
-	fmt.Println("=== Category Weights Validation ===")
-
-	// Sum up the category weights
-	var totalWeight float64
-	for _, weight := range leversConfig.Weights.Categories {
-		totalWeight += weight
-	}
-
-	// Check if the weights add up to 100% (1.0)
-	if len(leversConfig.Weights.Categories) == 0 {
-		fmt.Println("No category weights defined.")
-		os.Exit(1)
-	}
-
-	// Display all category weights
-	for category, weight := range leversConfig.Weights.Categories {
-		fmt.Printf("%s: %.2f (%.0f%%)\n", category, weight, weight*100)
-	}
-	fmt.Println()
-
-	// Display the total and validation result
-	fmt.Printf("Total weight: %.2f (%.0f%%)\n", totalWeight, totalWeight*100)
-
-	// Use a small epsilon for floating point comparison
-	const epsilon = 0.0001
-	weightsValid := totalWeight >= 1.0-epsilon && totalWeight <= 1.0+epsilon
-
-	if weightsValid {
-		fmt.Println("✅ Weights validation PASSED: Category weights add up to 100%")
-	} else {
-		fmt.Printf("❌ Weights validation FAILED: Category weights add up to %.0f%%, expected 100%%\n", totalWeight*100)
-	}
-
-	fmt.Println()
-	fmt.Println("=== Threshold Ranges Validation ===")
-
-	// Display the current thresholds
-	fmt.Printf("Global Thresholds:\n")
-	fmt.Printf("Green:  %d-%d\n", leversConfig.Global.Thresholds.Green.Min, leversConfig.Global.Thresholds.Green.Max)
-	fmt.Printf("Yellow: %d-%d\n", leversConfig.Global.Thresholds.Yellow.Min, leversConfig.Global.Thresholds.Yellow.Max)
-	fmt.Printf("Red:    %d-%d\n", leversConfig.Global.Thresholds.Red.Min, leversConfig.Global.Thresholds.Red.Max)
-	fmt.Println()
-
-	// Validate thresholds
-	thresholdsValid := true
-	var errors []string
-
-	// Validate global thresholds
-	// 1. Check that min <= max for each range
-	if leversConfig.Global.Thresholds.Green.Min > leversConfig.Global.Thresholds.Green.Max {
-		thresholdsValid = false
-		errors = append(errors, fmt.Sprintf("Green threshold min (%d) must be less than or equal to max (%d)",
-			leversConfig.Global.Thresholds.Green.Min, leversConfig.Global.Thresholds.Green.Max))
-	}
-
-	if leversConfig.Global.Thresholds.Yellow.Min > leversConfig.Global.Thresholds.Yellow.Max {
-		thresholdsValid = false
-		errors = append(errors, fmt.Sprintf("Yellow threshold min (%d) must be less than or equal to max (%d)",
-			leversConfig.Global.Thresholds.Yellow.Min, leversConfig.Global.Thresholds.Yellow.Max))
-	}
-
-	if leversConfig.Global.Thresholds.Red.Min > leversConfig.Global.Thresholds.Red.Max {
-		thresholdsValid = false
-		errors = append(errors, fmt.Sprintf("Red threshold min (%d) must be less than or equal to max (%d)",
-			leversConfig.Global.Thresholds.Red.Min, leversConfig.Global.Thresholds.Red.Max))
-	}
-
-	// 2. Check that ranges don't overlap
-	if leversConfig.Global.Thresholds.Yellow.Max >= leversConfig.Global.Thresholds.Green.Min {
-		thresholdsValid = false
-		errors = append(errors, fmt.Sprintf("Yellow threshold max (%d) must be less than Green threshold min (%d)",
-			leversConfig.Global.Thresholds.Yellow.Max, leversConfig.Global.Thresholds.Green.Min))
-	}
-
-	if leversConfig.Global.Thresholds.Red.Max >= leversConfig.Global.Thresholds.Yellow.Min {
-		thresholdsValid = false
-		errors = append(errors, fmt.Sprintf("Red threshold max (%d) must be less than Yellow threshold min (%d)",
-			leversConfig.Global.Thresholds.Red.Max, leversConfig.Global.Thresholds.Yellow.Min))
-	}
-
-	// 3. Check that ranges cover the entire range from 0 to 100
-	if leversConfig.Global.Thresholds.Red.Min > 0 {
-		thresholdsValid = false
-		errors = append(errors, fmt.Sprintf("Red threshold min (%d) should be 0 to cover the entire range",
-			leversConfig.Global.Thresholds.Red.Min))
-	}
-
-	if leversConfig.Global.Thresholds.Green.Max < 100 {
-		thresholdsValid = false
-		errors = append(errors, fmt.Sprintf("Green threshold max (%d) should be 100 to cover the entire range",
-			leversConfig.Global.Thresholds.Green.Max))
-	}
-
-	// Also validate category-specific thresholds if they exist
-	if len(leversConfig.Weights.CategoryThresholds) > 0 {
-		fmt.Println("Category-Specific Thresholds:")
-
-		for category, thresholds := range leversConfig.Weights.CategoryThresholds {
-			fmt.Printf("%s:\n", category)
-			fmt.Printf("  Green:  %d-%d\n", thresholds.Green.Min, thresholds.Green.Max)
-			fmt.Printf("  Yellow: %d-%d\n", thresholds.Yellow.Min, thresholds.Yellow.Max)
-			fmt.Printf("  Red:    %d-%d\n", thresholds.Red.Min, thresholds.Red.Max)
-
-			// 1. Check that min <= max for each range
-			if thresholds.Green.Min > thresholds.Green.Max {
-				thresholdsValid = false
-				errors = append(errors, fmt.Sprintf("Category '%s': Green threshold min (%d) must be less than or equal to max (%d)",
-					category, thresholds.Green.Min, thresholds.Green.Max))
-			}
-
-			if thresholds.Yellow.Min > thresholds.Yellow.Max {
-				thresholdsValid = false
-				errors = append(errors, fmt.Sprintf("Category '%s': Yellow threshold min (%d) must be less than or equal to max (%d)",
-					category, thresholds.Yellow.Min, thresholds.Yellow.Max))
-			}
-
-			if thresholds.Red.Min > thresholds.Red.Max {
-				thresholdsValid = false
-				errors = append(errors, fmt.Sprintf("Category '%s': Red threshold min (%d) must be less than or equal to max (%d)",
-					category, thresholds.Red.Min, thresholds.Red.Max))
-			}
-
-			// 2. Check that ranges don't overlap
-			if thresholds.Yellow.Max >= thresholds.Green.Min {
-				thresholdsValid = false
-				errors = append(errors, fmt.Sprintf("Category '%s': Yellow threshold max (%d) must be less than Green threshold min (%d)",
-					category, thresholds.Yellow.Max, thresholds.Green.Min))
-			}
-
-			if thresholds.Red.Max >= thresholds.Yellow.Min {
-				thresholdsValid = false
-				errors = append(errors, fmt.Sprintf("Category '%s': Red threshold max (%d) must be less than Yellow threshold min (%d)",
-					category, thresholds.Red.Max, thresholds.Yellow.Min))
-			}
-
-			// 3. Check that ranges cover the entire range from 0 to 100
-			if thresholds.Red.Min > 0 {
-				thresholdsValid = false
-				errors = append(errors, fmt.Sprintf("Category '%s': Red threshold min (%d) should be 0 to cover the entire range",
-					category, thresholds.Red.Min))
-			}
-
-			if thresholds.Green.Max < 100 {
-				thresholdsValid = false
-				errors = append(errors, fmt.Sprintf("Category '%s': Green threshold max (%d) should be 100 to cover the entire range",
-					category, thresholds.Green.Max))
-			}
-		}
-	}
-
-	// Display threshold validation result
-	if thresholdsValid {
-		fmt.Println("✅ Thresholds validation PASSED: All threshold ranges are valid and don't overlap")
-	} else {
-		fmt.Println("❌ Thresholds validation FAILED:")
-		for _, err := range errors {
-			fmt.Printf("   - %s\n", err)
-		}
+		emitConfigMissing(err)
+		return
 	}
 
-	// Overall validation result
-	fmt.Println()
-	fmt.Println("=== Overall Validation Result ===")
-	if weightsValid && thresholdsValid {
-		fmt.Println("✅ All validations PASSED")
-	} else {
-		fmt.Println("❌ Some validations FAILED")
-		os.Exit(1)
-	}
+	checks := []pulse.ValidationCheck{pulse.ValidateWeights(leversConfig)}
+	checks = append(checks, pulse.ValidateMetricWeights(metricsConfig)...)
+	emitValidationChecks(checks)
 }
 
 // runValidateThresholdsCmd validates that threshold ranges are valid and don't overlap
 func runValidateThresholdsCmd(cmd *cobra.Command, args []string) {
-	// Initialize the config loader
 	configLoader := pulse.NewConfigLoader(configDir, dataDir)
 
-	// Load levers configuration
 	leversConfig, err := configLoader.LoadLeversConfig()
 	if err != nil {
-		fmt.Printf("Error loading levers config: %v\n", err)
-		os.Exit(1)
-	}
-
-	// Display the current thresholds
-	fmt.Println("Global Thresholds Validation:")
-	fmt.Println("--------------------------")
-	fmt.Printf("Green:  %d-%d\n", leversConfig.Global.Thresholds.Green.Min, leversConfig.Global.Thresholds.Green.Max)
-	fmt.Printf("Yellow: %d-%d\n", leversConfig.Global.Thresholds.Yellow.Min, leversConfig.Global.Thresholds.Yellow.Max)
-	fmt.Printf("Red:    %d-%d\n", leversConfig.Global.Thresholds.Red.Min, leversConfig.Global.Thresholds.Red.Max)
-	fmt.Println()
-
-	// Validate thresholds
-	valid := true
-	var errors []string
-
-	// Validate global thresholds
-	// 1. Check that min <= max for each range
-	if leversConfig.Global.Thresholds.Green.Min > leversConfig.Global.Thresholds.Green.Max {
-		valid = false
-		errors = append(errors, fmt.Sprintf("Green threshold min (%d) must be less than or equal to max (%d)",
-			leversConfig.Global.Thresholds.Green.Min, leversConfig.Global.Thresholds.Green.Max))
-	}
-
-	if leversConfig.Global.Thresholds.Yellow.Min > leversConfig.Global.Thresholds.Yellow.Max {
-		valid = false
-		errors = append(errors, fmt.Sprintf("Yellow threshold min (%d) must be less than or equal to max (%d)",
-			leversConfig.Global.Thresholds.Yellow.Min, leversConfig.Global.Thresholds.Yellow.Max))
-	}
-
-	if leversConfig.Global.Thresholds.Red.Min > leversConfig.Global.Thresholds.Red.Max {
-		valid = false
-		errors = append(errors, fmt.Sprintf("Red threshold min (%d) must be less than or equal to max (%d)",
-			leversConfig.Global.Thresholds.Red.Min, leversConfig.Global.Thresholds.Red.Max))
-	}
-
-	// 2. Check that ranges don't overlap
-	if leversConfig.Global.Thresholds.Yellow.Max >= leversConfig.Global.Thresholds.Green.Min {
-		valid = false
-		errors = append(errors, fmt.Sprintf("Yellow threshold max (%d) must be less than Green threshold min (%d)",
-			leversConfig.Global.Thresholds.Yellow.Max, leversConfig.Global.Thresholds.Green.Min))
-	}
-
-	if leversConfig.Global.Thresholds.Red.Max >= leversConfig.Global.Thresholds.Yellow.Min {
-		valid = false
-		errors = append(errors, fmt.Sprintf("Red threshold max (%d) must be less than Yellow threshold min (%d)",
-			leversConfig.Global.Thresholds.Red.Max, leversConfig.Global.Thresholds.Yellow.Min))
-	}
-
-	// 3. Check that ranges cover the entire range from 0 to 100
-	if leversConfig.Global.Thresholds.Red.Min > 0 {
-		valid = false
-		errors = append(errors, fmt.Sprintf("Red threshold min (%d) should be 0 to cover the entire range",
-			leversConfig.Global.Thresholds.Red.Min))
-	}
-
-	if leversConfig.Global.Thresholds.Green.Max < 100 {
-		valid = false
-		errors = append(errors, fmt.Sprintf("Green threshold max (%d) should be 100 to cover the entire range",
-			leversConfig.Global.Thresholds.Green.Max))
-	}
-
-	// Also validate category-specific thresholds if they exist
-	if len(leversConfig.Weights.CategoryThresholds) > 0 {
-		fmt.Println("Category-Specific Thresholds Validation:")
-		fmt.Println("-------------------------------------")
-
-		for category, thresholds := range leversConfig.Weights.CategoryThresholds {
-			fmt.Printf("%s:\n", category)
-			fmt.Printf("  Green:  %d-%d\n", thresholds.Green.Min, thresholds.Green.Max)
-			fmt.Printf("  Yellow: %d-%d\n", thresholds.Yellow.Min, thresholds.Yellow.Max)
-			fmt.Printf("  Red:    %d-%d\n", thresholds.Red.Min, thresholds.Red.Max)
-
-			// 1. Check that min <= max for each range
-			if thresholds.Green.Min > thresholds.Green.Max {
-				valid = false
-				errors = append(errors, fmt.Sprintf("Category '%s': Green threshold min (%d) must be less than or equal to max (%d)",
-					category, thresholds.Green.Min, thresholds.Green.Max))
-			}
-
-			if thresholds.Yellow.Min > thresholds.Yellow.Max {
-				valid = false
-				errors = append(errors, fmt.Sprintf("Category '%s': Yellow threshold min (%d) must be less than or equal to max (%d)",
-					category, thresholds.Yellow.Min, thresholds.Yellow.Max))
-			}
-
-			if thresholds.Red.Min > thresholds.Red.Max {
-				valid = false
-				errors = append(errors, fmt.Sprintf("Category '%s': Red threshold min (%d) must be less than or equal to max (%d)",
-					category, thresholds.Red.Min, thresholds.Red.Max))
-			}
-
-			// 2. Check that ranges don't overlap
-			if thresholds.Yellow.Max >= thresholds.Green.Min {
-				valid = false
-				errors = append(errors, fmt.Sprintf("Category '%s': Yellow threshold max (%d) must be less than Green threshold min (%d)",
-					category, thresholds.Yellow.Max, thresholds.Green.Min))
-			}
-
-			if thresholds.Red.Max >= thresholds.Yellow.Min {
-				valid = false
-				errors = append(errors, fmt.Sprintf("Category '%s': Red threshold max (%d) must be less than Yellow threshold min (%d)",
-					category, thresholds.Red.Max, thresholds.Yellow.Min))
-			}
-
-			// 3. Check that ranges cover the entire range from 0 to 100
-			if thresholds.Red.Min > 0 {
-				valid = false
-				errors = append(errors, fmt.Sprintf("Category '%s': Red threshold min (%d) should be 0 to cover the entire range",
-					category, thresholds.Red.Min))
-			}
-
-			if thresholds.Green.Max < 100 {
-				valid = false
-				errors = append(errors, fmt.Sprintf("Category '%s': Green threshold max (%d) should be 100 to cover the entire range",
-					category, thresholds.Green.Max))
-			}
-		}
+		emitConfigMissing(err)
+		return
 	}
 
-	fmt.Println()
-
-	// Display validation result
-	if valid {
-		fmt.Println("✅ Validation PASSED: All threshold ranges are valid and don't overlap")
-	} else {
-		fmt.Println("❌ Validation FAILED:")
-		for _, err := range errors {
-			fmt.Printf("   - %s\n", err)
-		}
-		fmt.Println()
-		fmt.Println("Threshold ranges should follow these rules:")
-		fmt.Println("  1. Min must be less than or equal to Max for each range")
-		fmt.Println("  2. Ranges must not overlap (Red.Max < Yellow.Min, Yellow.Max < Green.Min)")
-		fmt.Println("  3. Ranges should cover the entire range from 0 to 100")
-		fmt.Println()
-		fmt.Println("Example of valid threshold ranges:")
-		fmt.Println("  Green:  80-100")
-		fmt.Println("  Yellow: 60-79")
-		fmt.Println("  Red:    0-59")
-		os.Exit(1)
-	}
+	checks := append(pulse.ValidateThresholds(leversConfig), pulse.ValidateHysteresis(leversConfig))
+	checks = append(checks, pulse.ValidateCustomRules(leversConfig)...)
+	printTransitionMatrices(leversConfig)
+	emitValidationChecks(checks)
 }
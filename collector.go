@@ -0,0 +1,284 @@
+package pulse
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Aggregation combines multiple matches extracted from a single file into
+// one metric value.
+type Aggregation string
+
+const (
+	// AggregateLast uses the value of the final match in the file.
+	AggregateLast Aggregation = "last"
+	// AggregateMax uses the largest matched value.
+	AggregateMax Aggregation = "max"
+	// AggregateMin uses the smallest matched value.
+	AggregateMin Aggregation = "min"
+	// AggregateMean uses the arithmetic mean of all matched values.
+	AggregateMean Aggregation = "mean"
+)
+
+// CollectionRule describes how to extract a metric's value from a file or,
+// via Command, from the standard output of a shell command (e.g. a script
+// that queries a telemetry system pulse has no built-in driver for).
+// Exactly one of File or Command should be set, and exactly one of Regex,
+// JSONPath, or KeyValue should be set; Regex must contain a named capture
+// group called "v".
+type CollectionRule struct {
+	Reference   string      `yaml:"reference"`
+	File        string      `yaml:"file,omitempty"`
+	Command     string      `yaml:"command,omitempty"`
+	Args        []string    `yaml:"args,omitempty"`
+	Regex       string      `yaml:"regex,omitempty"`
+	JSONPath    string      `yaml:"jsonpath,omitempty"`
+	KeyValue    bool        `yaml:"key_value,omitempty"`
+	Aggregation Aggregation `yaml:"aggregation,omitempty"` // defaults to AggregateLast
+}
+
+// FileCollector extracts metric values from build logs, test reports, or
+// the output of shell commands, using per-reference CollectionRules, and
+// writes matches through to a MetricsProcessor.
+type FileCollector struct {
+	processor *MetricsProcessor
+	rules     []CollectionRule
+}
+
+// NewFileCollector creates a FileCollector that applies rules against
+// processor's metrics on each CollectOnce/Watch pass.
+func NewFileCollector(processor *MetricsProcessor, rules []CollectionRule) *FileCollector {
+	return &FileCollector{processor: processor, rules: rules}
+}
+
+// CollectOnce reads every rule's file, extracts matching values, aggregates
+// them, and updates the corresponding metric. A rule with no matches is
+// skipped. A rule whose file cannot be read, or whose update fails, does not
+// stop the remaining rules, but its error is included in the combined error
+// CollectOnce returns after every rule has been tried.
+func (c *FileCollector) CollectOnce() error {
+	var errs []string
+
+	for _, rule := range c.rules {
+		value, found, err := c.extract(rule)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", rule.Reference, err))
+			continue
+		}
+		if !found {
+			continue
+		}
+
+		if err := c.processor.UpdateMetric(rule.Reference, value); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", rule.Reference, err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("file collector: %s", strings.Join(errs, "; "))
+	}
+
+	return nil
+}
+
+// Watch runs CollectOnce every interval until stop is closed, or until a
+// CollectOnce pass returns an error, which Watch then returns.
+func (c *FileCollector) Watch(interval time.Duration, stop <-chan struct{}) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return nil
+		case <-ticker.C:
+			if err := c.CollectOnce(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// extract reads rule.File or runs rule.Command and returns the aggregated
+// value across all matches, or found=false if the rule produced no matches.
+func (c *FileCollector) extract(rule CollectionRule) (float64, bool, error) {
+	data, err := c.read(rule)
+	if err != nil {
+		return 0, false, err
+	}
+
+	var values []float64
+
+	switch {
+	case rule.Regex != "":
+		values, err = extractRegex(rule.Regex, string(data))
+	case rule.JSONPath != "":
+		values, err = extractJSONPath(rule.JSONPath, data)
+	case rule.KeyValue:
+		values, err = extractKeyValue(rule.Reference, string(data))
+	default:
+		return 0, false, fmt.Errorf("rule has no regex, jsonpath, or key_value extraction configured")
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	if len(values) == 0 {
+		return 0, false, nil
+	}
+
+	return aggregate(values, rule.Aggregation), true, nil
+}
+
+// read returns rule's content: rule.File's bytes, or the combined
+// stdout+stderr of rule.Command (run with rule.Args) if File is empty.
+func (c *FileCollector) read(rule CollectionRule) ([]byte, error) {
+	if rule.Command != "" {
+		cmd := exec.Command(rule.Command, rule.Args...)
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			return nil, fmt.Errorf("failed to run %s: %w", rule.Command, err)
+		}
+		return output, nil
+	}
+
+	data, err := os.ReadFile(rule.File)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", rule.File, err)
+	}
+	return data, nil
+}
+
+// extractRegex applies pattern, which must contain a named group "v", to
+// content and returns every matched value.
+func extractRegex(pattern, content string) ([]float64, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid regex: %w", err)
+	}
+
+	groupIndex := -1
+	for i, name := range re.SubexpNames() {
+		if name == "v" {
+			groupIndex = i
+			break
+		}
+	}
+	if groupIndex == -1 {
+		return nil, fmt.Errorf("regex must contain a named capture group 'v'")
+	}
+
+	var values []float64
+	for _, match := range re.FindAllStringSubmatch(content, -1) {
+		value, err := strconv.ParseFloat(match[groupIndex], 64)
+		if err != nil {
+			continue
+		}
+		values = append(values, value)
+	}
+
+	return values, nil
+}
+
+// extractJSONPath resolves a dotted path (e.g. "summary.coverage.percent")
+// against content, which may be a single JSON object or an NDJSON stream of
+// objects, and returns the numeric value found at that path in each.
+func extractJSONPath(path string, content []byte) ([]float64, error) {
+	segments := strings.Split(strings.TrimPrefix(path, "$."), ".")
+
+	var values []float64
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		var doc interface{}
+		if err := json.Unmarshal([]byte(line), &doc); err != nil {
+			return nil, fmt.Errorf("invalid JSON: %w", err)
+		}
+
+		value, ok := resolveJSONPath(doc, segments)
+		if ok {
+			values = append(values, value)
+		}
+	}
+
+	return values, nil
+}
+
+func resolveJSONPath(doc interface{}, segments []string) (float64, bool) {
+	current := doc
+	for _, segment := range segments {
+		m, ok := current.(map[string]interface{})
+		if !ok {
+			return 0, false
+		}
+		current, ok = m[segment]
+		if !ok {
+			return 0, false
+		}
+	}
+
+	value, ok := current.(float64)
+	return value, ok
+}
+
+// extractKeyValue scans content line by line for "reference=value" pairs,
+// matching only on the final path segment of reference (e.g. for reference
+// "test_cat.KPI.test_kpi" it looks for lines like "test_kpi=42").
+func extractKeyValue(reference, content string) ([]float64, error) {
+	parts := strings.Split(reference, ".")
+	key := parts[len(parts)-1]
+
+	var values []float64
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, key+"=") {
+			continue
+		}
+
+		value, err := strconv.ParseFloat(strings.TrimPrefix(line, key+"="), 64)
+		if err != nil {
+			continue
+		}
+		values = append(values, value)
+	}
+
+	return values, nil
+}
+
+// aggregate combines values according to agg, defaulting to AggregateLast.
+func aggregate(values []float64, agg Aggregation) float64 {
+	switch agg {
+	case AggregateMax:
+		max := values[0]
+		for _, v := range values[1:] {
+			if v > max {
+				max = v
+			}
+		}
+		return max
+	case AggregateMin:
+		min := values[0]
+		for _, v := range values[1:] {
+			if v < min {
+				min = v
+			}
+		}
+		return min
+	case AggregateMean:
+		var sum float64
+		for _, v := range values {
+			sum += v
+		}
+		return sum / float64(len(values))
+	default:
+		return values[len(values)-1]
+	}
+}
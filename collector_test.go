@@ -0,0 +1,139 @@
+package pulse
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func newTestProcessor() *MetricsProcessor {
+	config := &MetricsConfig{
+		Categories: []Category{
+			{
+				ID: "test_cat",
+				KPIs: []KPI{
+					{ID: "test_kpi", ScoringBands: []ScoringBand{{Min: FloatPtr(0), Score: 100}}},
+				},
+			},
+		},
+	}
+	levers := &LeversConfig{}
+	data := &MetricsData{}
+	return NewMetricsProcessor(config, levers, data)
+}
+
+func TestFileCollectorRegex(t *testing.T) {
+	dir := t.TempDir()
+	logPath := filepath.Join(dir, "ci.log")
+	if err := os.WriteFile(logPath, []byte("coverage=71.5\nrunning tests\ncoverage=82.0\n"), 0600); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+
+	processor := newTestProcessor()
+	collector := NewFileCollector(processor, []CollectionRule{
+		{Reference: "test_cat.KPI.test_kpi", File: logPath, Regex: `coverage=(?P<v>[0-9.]+)`, Aggregation: AggregateMax},
+	})
+
+	if err := collector.CollectOnce(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	metric, err := processor.GetMetricByReference("test_cat.KPI.test_kpi")
+	if err != nil {
+		t.Fatalf("expected metric to be updated: %v", err)
+	}
+	if metric.Value != 82.0 {
+		t.Fatalf("expected max aggregation to pick 82.0, got %v", metric.Value)
+	}
+}
+
+func TestFileCollectorKeyValue(t *testing.T) {
+	dir := t.TempDir()
+	logPath := filepath.Join(dir, "report.txt")
+	if err := os.WriteFile(logPath, []byte("test_kpi=55\n"), 0600); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+
+	processor := newTestProcessor()
+	collector := NewFileCollector(processor, []CollectionRule{
+		{Reference: "test_cat.KPI.test_kpi", File: logPath, KeyValue: true},
+	})
+
+	if err := collector.CollectOnce(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	metric, err := processor.GetMetricByReference("test_cat.KPI.test_kpi")
+	if err != nil {
+		t.Fatalf("expected metric to be updated: %v", err)
+	}
+	if metric.Value != 55 {
+		t.Fatalf("expected 55, got %v", metric.Value)
+	}
+}
+
+func TestFileCollectorJSONPath(t *testing.T) {
+	dir := t.TempDir()
+	logPath := filepath.Join(dir, "report.json")
+	if err := os.WriteFile(logPath, []byte(`{"summary":{"coverage":63.2}}`+"\n"), 0600); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+
+	processor := newTestProcessor()
+	collector := NewFileCollector(processor, []CollectionRule{
+		{Reference: "test_cat.KPI.test_kpi", File: logPath, JSONPath: "summary.coverage"},
+	})
+
+	if err := collector.CollectOnce(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	metric, err := processor.GetMetricByReference("test_cat.KPI.test_kpi")
+	if err != nil {
+		t.Fatalf("expected metric to be updated: %v", err)
+	}
+	if metric.Value != 63.2 {
+		t.Fatalf("expected 63.2, got %v", metric.Value)
+	}
+}
+
+func TestFileCollectorCommand(t *testing.T) {
+	processor := newTestProcessor()
+	collector := NewFileCollector(processor, []CollectionRule{
+		{Reference: "test_cat.KPI.test_kpi", Command: "echo", Args: []string{"test_kpi=91.0"}, KeyValue: true},
+	})
+
+	if err := collector.CollectOnce(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	metric, err := processor.GetMetricByReference("test_cat.KPI.test_kpi")
+	if err != nil {
+		t.Fatalf("expected metric to be updated: %v", err)
+	}
+	if metric.Value != 91.0 {
+		t.Fatalf("expected 91.0, got %v", metric.Value)
+	}
+}
+
+func TestFileCollectorCommandFailureIsReported(t *testing.T) {
+	processor := newTestProcessor()
+	collector := NewFileCollector(processor, []CollectionRule{
+		{Reference: "test_cat.KPI.test_kpi", Command: "/nonexistent/command", KeyValue: true},
+	})
+
+	if err := collector.CollectOnce(); err == nil {
+		t.Fatal("expected an error summarizing the failed command")
+	}
+}
+
+func TestFileCollectorMissingFileIsNonFatal(t *testing.T) {
+	processor := newTestProcessor()
+	collector := NewFileCollector(processor, []CollectionRule{
+		{Reference: "test_cat.KPI.test_kpi", File: "/nonexistent/path", KeyValue: true},
+	})
+
+	if err := collector.CollectOnce(); err == nil {
+		t.Fatal("expected an error summarizing the unreadable file")
+	}
+}
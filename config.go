@@ -4,10 +4,13 @@ import (
 	"bytes"
 	"embed"
 	"fmt"
+	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
 	"sync"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
@@ -23,9 +26,9 @@ func (c *ConfigLoader) MigrateMetricsData() error {
 
 	// 1. Check and migrate legacy file if it exists
 	legacyPath := filepath.Join(c.DataDir, "metrics.yaml")
-	if _, err := os.Stat(legacyPath); err == nil {
+	if c.fileExists(legacyPath) {
 		// Legacy file exists, read it
-		data, err := os.ReadFile(legacyPath)
+		data, err := c.readFile(legacyPath)
 		if err != nil {
 			return fmt.Errorf("failed to read legacy metrics file: %w", err)
 		}
@@ -56,16 +59,16 @@ func (c *ConfigLoader) MigrateMetricsData() error {
 
 		// Rename legacy file to .bak
 		backupPath := legacyPath + ".bak"
-		if err := os.Rename(legacyPath, backupPath); err != nil {
+		if err := c.fs.Rename(legacyPath, backupPath); err != nil {
 			return fmt.Errorf("failed to rename legacy metrics file: %w", err)
 		}
 	}
 
 	// 2. Check and migrate metrics directory if it exists
 	metricsDir := filepath.Join(c.DataDir, "metrics")
-	if _, err := os.Stat(metricsDir); err == nil {
+	if c.fileExists(metricsDir) {
 		// Metrics directory exists, read all files
-		files, err := os.ReadDir(metricsDir)
+		files, err := c.fs.ReadDir(metricsDir)
 		if err != nil {
 			return fmt.Errorf("failed to read metrics directory: %w", err)
 		}
@@ -78,7 +81,7 @@ func (c *ConfigLoader) MigrateMetricsData() error {
 			}
 
 			path := filepath.Join(metricsDir, file.Name())
-			data, err := os.ReadFile(path)
+			data, err := c.readFile(path)
 			if err != nil {
 				return fmt.Errorf("failed to read metrics file %s: %w", file.Name(), err)
 			}
@@ -110,7 +113,7 @@ func (c *ConfigLoader) MigrateMetricsData() error {
 
 		// Rename metrics directory to .bak
 		backupDir := metricsDir + ".bak"
-		if err := os.Rename(metricsDir, backupDir); err != nil {
+		if err := c.fs.Rename(metricsDir, backupDir); err != nil {
 			return fmt.Errorf("failed to rename metrics directory: %w", err)
 		}
 	}
@@ -146,7 +149,7 @@ func (c *ConfigLoader) MigrateMetricsData() error {
 				return fmt.Errorf("failed to marshal metrics data for %s: %w", fileName, err)
 			}
 
-			if err := os.WriteFile(filePath, data, 0600); err != nil {
+			if err := c.writeFile(filePath, data, 0600); err != nil {
 				return fmt.Errorf("failed to write metrics file %s: %w", fileName, err)
 			}
 		}
@@ -160,16 +163,87 @@ var fileLock sync.Mutex
 
 // ConfigLoader handles loading and parsing of configuration files
 type ConfigLoader struct {
-	ConfigDir string
-	DataDir   string
+	ConfigDir    string
+	DataDir      string
+	fs           FS
+	interpolator *Interpolator
+
+	recentWritesMu sync.Mutex
+	recentWrites   map[string]time.Time
+}
+
+// ConfigLoaderOption configures optional ConfigLoader behavior.
+type ConfigLoaderOption func(*ConfigLoader)
+
+// WithFS overrides the FS backend a ConfigLoader uses for all file
+// operations, which defaults to OSFS.
+func WithFS(fs FS) ConfigLoaderOption {
+	return func(c *ConfigLoader) {
+		c.fs = fs
+	}
+}
+
+// WithInterpolator overrides the Interpolator a ConfigLoader uses to resolve
+// ${SCHEME:ARG} placeholders before parsing YAML, which defaults to
+// NewInterpolator(). Pass an Interpolator with Strict set to false to leave
+// unresolved placeholders in place instead of erroring.
+func WithInterpolator(interpolator *Interpolator) ConfigLoaderOption {
+	return func(c *ConfigLoader) {
+		c.interpolator = interpolator
+	}
 }
 
 // NewConfigLoader creates a new ConfigLoader with the specified directories
-func NewConfigLoader(configDir, dataDir string) *ConfigLoader {
-	return &ConfigLoader{
-		ConfigDir: configDir,
-		DataDir:   dataDir,
+func NewConfigLoader(configDir, dataDir string, opts ...ConfigLoaderOption) *ConfigLoader {
+	c := &ConfigLoader{
+		ConfigDir:    configDir,
+		DataDir:      dataDir,
+		fs:           OSFS{},
+		interpolator: NewInterpolator(),
+	}
+
+	for _, opt := range opts {
+		opt(c)
 	}
+
+	return c
+}
+
+// readFile reads the entirety of path through c.fs.
+func (c *ConfigLoader) readFile(path string) ([]byte, error) {
+	f, err := c.fs.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return io.ReadAll(f)
+}
+
+// writeFile writes data to path through c.fs, creating or truncating it
+// with the given permissions.
+func (c *ConfigLoader) writeFile(path string, data []byte, perm fs.FileMode) error {
+	f, err := c.fs.Create(path, perm)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = f.Write(data)
+	c.noteOwnWrite(path)
+	return err
+}
+
+// fileExists reports whether path exists according to c.fs.
+func (c *ConfigLoader) fileExists(path string) bool {
+	_, err := c.fs.Stat(path)
+	return err == nil
+}
+
+// interpolate resolves ${SCHEME:ARG} placeholders in data via c.interpolator,
+// naming path in any resulting error.
+func (c *ConfigLoader) interpolate(path string, data []byte) ([]byte, error) {
+	return c.interpolator.Interpolate(path, data)
 }
 
 // validateYAML performs basic validation on YAML data before parsing
@@ -206,7 +280,7 @@ func validateYAML(data []byte) error {
 // LoadMetricsConfig loads the metrics configuration from the YAML file
 func (c *ConfigLoader) LoadMetricsConfig() (*MetricsConfig, error) {
 	path := filepath.Join(c.ConfigDir, "metrics.yaml")
-	data, err := os.ReadFile(path)
+	data, err := c.readFile(path)
 	if err != nil {
 		// If the file doesn't exist, return an empty config instead of an error
 		if os.IsNotExist(err) {
@@ -222,18 +296,88 @@ func (c *ConfigLoader) LoadMetricsConfig() (*MetricsConfig, error) {
 		return nil, fmt.Errorf("invalid metrics config file: %w", err)
 	}
 
+	data, err = c.interpolate(path, data)
+	if err != nil {
+		return nil, err
+	}
+
 	var config MetricsConfig
 	if err := yaml.Unmarshal(data, &config); err != nil {
 		return nil, fmt.Errorf("failed to parse metrics config file: %w", err)
 	}
 
+	if err := detectFormulaCycles(&config); err != nil {
+		return nil, fmt.Errorf("invalid metrics config file: %w", err)
+	}
+
+	return &config, nil
+}
+
+// LoadCollectorsConfig loads the file collector rules from the YAML file
+func (c *ConfigLoader) LoadCollectorsConfig() (*CollectorsConfig, error) {
+	path := filepath.Join(c.ConfigDir, "collectors.yaml")
+	data, err := c.readFile(path)
+	if err != nil {
+		// If the file doesn't exist, return an empty config instead of an error
+		if os.IsNotExist(err) {
+			return &CollectorsConfig{Rules: []CollectionRule{}}, nil
+		}
+		return nil, fmt.Errorf("failed to read collectors config file: %w", err)
+	}
+
+	// Validate YAML before parsing
+	if err := validateYAML(data); err != nil {
+		return nil, fmt.Errorf("invalid collectors config file: %w", err)
+	}
+
+	data, err = c.interpolate(path, data)
+	if err != nil {
+		return nil, err
+	}
+
+	var config CollectorsConfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse collectors config file: %w", err)
+	}
+
+	return &config, nil
+}
+
+// LoadNotificationsConfig loads the notification channels and routing rules
+// from the YAML file
+func (c *ConfigLoader) LoadNotificationsConfig() (*NotificationsConfig, error) {
+	path := filepath.Join(c.ConfigDir, "notifications.yaml")
+	data, err := c.readFile(path)
+	if err != nil {
+		// If the file doesn't exist, return an empty config instead of an error
+		if os.IsNotExist(err) {
+			return &NotificationsConfig{Notifiers: map[string]NotifierConfig{}}, nil
+		}
+		return nil, fmt.Errorf("failed to read notifications config file: %w", err)
+	}
+
+	// Validate YAML before parsing
+	if err := validateYAML(data); err != nil {
+		return nil, fmt.Errorf("invalid notifications config file: %w", err)
+	}
+
+	data, err = c.interpolate(path, data)
+	if err != nil {
+		return nil, err
+	}
+
+	var config NotificationsConfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse notifications config file: %w", err)
+	}
+
 	return &config, nil
 }
 
 // LoadLeversConfig loads the executive levers configuration from the YAML file
 func (c *ConfigLoader) LoadLeversConfig() (*LeversConfig, error) {
 	path := filepath.Join(c.ConfigDir, "levers.yaml")
-	data, err := os.ReadFile(path)
+	data, err := c.readFile(path)
 	if err != nil {
 		// If the file doesn't exist, return an empty config instead of an error
 		if os.IsNotExist(err) {
@@ -247,6 +391,11 @@ func (c *ConfigLoader) LoadLeversConfig() (*LeversConfig, error) {
 		return nil, fmt.Errorf("invalid levers config file: %w", err)
 	}
 
+	data, err = c.interpolate(path, data)
+	if err != nil {
+		return nil, err
+	}
+
 	var config LeversConfig
 	if err := yaml.Unmarshal(data, &config); err != nil {
 		return nil, fmt.Errorf("failed to parse levers config file: %w", err)
@@ -255,17 +404,48 @@ func (c *ConfigLoader) LoadLeversConfig() (*LeversConfig, error) {
 	return &config, nil
 }
 
+// SaveLeversConfig writes leversConfig to <config-dir>/levers.yaml, replacing
+// it atomically (write-to-temp-then-rename) so a reader never observes a
+// partially-written file.
+func (c *ConfigLoader) SaveLeversConfig(leversConfig *LeversConfig) error {
+	fileLock.Lock()
+	defer fileLock.Unlock()
+
+	if err := c.fs.MkdirAll(c.ConfigDir, 0700); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	data, err := yaml.Marshal(leversConfig)
+	if err != nil {
+		return fmt.Errorf("failed to marshal levers config: %w", err)
+	}
+
+	path := filepath.Join(c.ConfigDir, "levers.yaml")
+	tempFile := path + ".tmp"
+	if err := c.writeFile(tempFile, data, 0600); err != nil {
+		return fmt.Errorf("failed to write temporary levers config file: %w", err)
+	}
+
+	if err := c.fs.Rename(tempFile, path); err != nil {
+		c.fs.Remove(tempFile)
+		return fmt.Errorf("failed to rename temporary levers config file: %w", err)
+	}
+	c.noteOwnWrite(path)
+
+	return nil
+}
+
 // LoadMetricsData loads the metrics data from YAML files in the data directory
 func (c *ConfigLoader) LoadMetricsData() (*MetricsData, error) {
 	// Check if data directory exists
-	if _, err := os.Stat(c.DataDir); os.IsNotExist(err) {
+	if !c.fileExists(c.DataDir) {
 		return &MetricsData{
 			Metrics: []Metric{},
 		}, nil
 	}
 
 	// Read all files in the data directory
-	files, err := os.ReadDir(c.DataDir)
+	files, err := c.fs.ReadDir(c.DataDir)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read data directory: %w", err)
 	}
@@ -290,7 +470,7 @@ func (c *ConfigLoader) LoadMetricsData() (*MetricsData, error) {
 		}
 
 		path := filepath.Join(c.DataDir, file.Name())
-		data, err := os.ReadFile(path)
+		data, err := c.readFile(path)
 		if err != nil {
 			parseErrors = append(parseErrors, fmt.Sprintf("failed to read metrics file %s: %v", file.Name(), err))
 			continue
@@ -302,6 +482,12 @@ func (c *ConfigLoader) LoadMetricsData() (*MetricsData, error) {
 			continue
 		}
 
+		data, err = c.interpolate(path, data)
+		if err != nil {
+			parseErrors = append(parseErrors, err.Error())
+			continue
+		}
+
 		var fileMetrics MetricsData
 		if err := yaml.Unmarshal(data, &fileMetrics); err != nil {
 			parseErrors = append(parseErrors, fmt.Sprintf("failed to parse metrics file %s: %v", file.Name(), err))
@@ -325,6 +511,21 @@ func (c *ConfigLoader) LoadMetricsData() (*MetricsData, error) {
 	return allMetrics, nil
 }
 
+// LoadMetricHistory returns every observation recorded for reference at or
+// after since, read from the HistoryStore under <data-dir>/history. It
+// returns an empty slice, not an error, if history recording was never
+// enabled for this data directory.
+func (c *ConfigLoader) LoadMetricHistory(reference string, since time.Time) ([]HistoryPoint, error) {
+	store := NewHistoryStore(filepath.Join(c.DataDir, "history"))
+
+	points, err := store.Query(reference, since, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load metric history for %s: %w", reference, err)
+	}
+
+	return points, nil
+}
+
 // SaveMetricsData saves the metrics data to YAML files in the data directory
 func (c *ConfigLoader) SaveMetricsData(metricsData *MetricsData) error {
 	// Use global mutex to prevent concurrent access to file operations
@@ -332,7 +533,7 @@ func (c *ConfigLoader) SaveMetricsData(metricsData *MetricsData) error {
 	defer fileLock.Unlock()
 
 	// Ensure data directory exists
-	if err := os.MkdirAll(c.DataDir, 0700); err != nil {
+	if err := c.fs.MkdirAll(c.DataDir, 0700); err != nil {
 		return fmt.Errorf("failed to create data directory: %w", err)
 	}
 
@@ -363,15 +564,16 @@ func (c *ConfigLoader) SaveMetricsData(metricsData *MetricsData) error {
 
 		// Use atomic file write pattern
 		tempFile := filePath + ".tmp"
-		if err := os.WriteFile(tempFile, data, 0600); err != nil {
+		if err := c.writeFile(tempFile, data, 0600); err != nil {
 			return fmt.Errorf("failed to write temporary metrics data file %s: %w", fileName, err)
 		}
 
-		if err := os.Rename(tempFile, filePath); err != nil {
+		if err := c.fs.Rename(tempFile, filePath); err != nil {
 			// Try to clean up the temp file
-			os.Remove(tempFile)
+			c.fs.Remove(tempFile)
 			return fmt.Errorf("failed to rename temporary metrics data file %s: %w", fileName, err)
 		}
+		c.noteOwnWrite(filePath)
 	}
 
 	return nil
@@ -393,14 +595,14 @@ func (c *ConfigLoader) CreateMetricFile(fileName string) error {
 	}
 
 	// Ensure data directory exists
-	if err := os.MkdirAll(c.DataDir, 0700); err != nil {
+	if err := c.fs.MkdirAll(c.DataDir, 0700); err != nil {
 		return fmt.Errorf("failed to create data directory: %w", err)
 	}
 
 	filePath := filepath.Join(c.DataDir, fileName)
 
 	// Check if file already exists
-	if _, err := os.Stat(filePath); err == nil {
+	if c.fileExists(filePath) {
 		return fmt.Errorf("metric file %s already exists", fileName)
 	}
 
@@ -416,15 +618,16 @@ func (c *ConfigLoader) CreateMetricFile(fileName string) error {
 
 	// Use atomic file write pattern
 	tempFile := filePath + ".tmp"
-	if err := os.WriteFile(tempFile, data, 0600); err != nil {
+	if err := c.writeFile(tempFile, data, 0600); err != nil {
 		return fmt.Errorf("failed to write temporary metric file %s: %w", fileName, err)
 	}
 
-	if err := os.Rename(tempFile, filePath); err != nil {
+	if err := c.fs.Rename(tempFile, filePath); err != nil {
 		// Try to clean up the temp file
-		os.Remove(tempFile)
+		c.fs.Remove(tempFile)
 		return fmt.Errorf("failed to rename temporary metric file %s: %w", fileName, err)
 	}
+	c.noteOwnWrite(filePath)
 
 	return nil
 }
@@ -432,18 +635,18 @@ func (c *ConfigLoader) CreateMetricFile(fileName string) error {
 // CreateDefaultConfigFiles creates default configuration files if they don't exist
 func (c *ConfigLoader) CreateDefaultConfigFiles() error {
 	// Ensure directories exist
-	if err := os.MkdirAll(c.ConfigDir, 0700); err != nil {
+	if err := c.fs.MkdirAll(c.ConfigDir, 0700); err != nil {
 		return fmt.Errorf("failed to create config directory: %w", err)
 	}
 
-	if err := os.MkdirAll(c.DataDir, 0700); err != nil {
+	if err := c.fs.MkdirAll(c.DataDir, 0700); err != nil {
 		return fmt.Errorf("failed to create data directory: %w", err)
 	}
 
 	// Helper function to copy embedded file to destination
 	copyEmbeddedFile := func(embeddedPath, destPath string) error {
 		// Check if destination file already exists
-		if _, err := os.Stat(destPath); err == nil {
+		if c.fileExists(destPath) {
 			// File exists, skip
 			return nil
 		}
@@ -455,7 +658,7 @@ func (c *ConfigLoader) CreateDefaultConfigFiles() error {
 		}
 
 		// Write to destination
-		if err := os.WriteFile(destPath, data, 0600); err != nil {
+		if err := c.writeFile(destPath, data, 0600); err != nil {
 			return fmt.Errorf("failed to write file %s: %w", destPath, err)
 		}
 
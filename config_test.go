@@ -170,3 +170,28 @@ func TestConfigLoader(t *testing.T) {
 		t.Error("Failed to find the newly added metric in the saved data")
 	}
 }
+
+func TestSaveLeversConfigRoundTrip(t *testing.T) {
+	loader := NewConfigLoader(t.TempDir(), t.TempDir())
+
+	leversConfig := &LeversConfig{Global: Global{
+		Thresholds: Thresholds{
+			Green:  ThresholdRange{Min: 85, Max: 100},
+			Yellow: ThresholdRange{Min: 55, Max: 84},
+			Red:    ThresholdRange{Min: 0, Max: 54},
+		},
+	}}
+
+	if err := loader.SaveLeversConfig(leversConfig); err != nil {
+		t.Fatalf("failed to save levers config: %v", err)
+	}
+
+	reloaded, err := loader.LoadLeversConfig()
+	if err != nil {
+		t.Fatalf("failed to reload saved levers config: %v", err)
+	}
+
+	if reloaded.Global.Thresholds.Green.Min != 85 {
+		t.Fatalf("expected the saved Green.Min to round-trip, got %d", reloaded.Global.Thresholds.Green.Min)
+	}
+}
@@ -0,0 +1,342 @@
+package pulse
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// metricUpdatesTotal counts every successful MetricsProcessor.UpdateMetric
+// call for the lifetime of the process, exposed as pulse_metric_updates_total.
+var metricUpdatesTotal int64
+
+// MetricUpdatesTotal returns the number of metric updates recorded so far.
+func MetricUpdatesTotal() int64 {
+	return atomic.LoadInt64(&metricUpdatesTotal)
+}
+
+// Exporter renders loaded metrics and their scores in Prometheus text
+// exposition format. It reloads from disk on every call to RenderMetrics so
+// a scrape always reflects the latest data on disk.
+type Exporter struct {
+	configLoader *ConfigLoader
+
+	mu            sync.Mutex
+	metricsConfig *MetricsConfig
+	leversConfig  *LeversConfig
+}
+
+// NewExporter creates an Exporter backed by configLoader.
+func NewExporter(configLoader *ConfigLoader) *Exporter {
+	return &Exporter{configLoader: configLoader}
+}
+
+// Reload re-reads the metrics and levers configuration from disk. It is
+// called automatically by RenderMetrics, and can also be called explicitly
+// (e.g. from a SIGHUP handler) to refresh cached config ahead of a scrape.
+func (e *Exporter) Reload() error {
+	fileLock.Lock()
+	defer fileLock.Unlock()
+
+	metricsConfig, err := e.configLoader.LoadMetricsConfig()
+	if err != nil {
+		return fmt.Errorf("failed to reload metrics config: %w", err)
+	}
+
+	leversConfig, err := e.configLoader.LoadLeversConfig()
+	if err != nil {
+		return fmt.Errorf("failed to reload levers config: %w", err)
+	}
+
+	e.mu.Lock()
+	e.metricsConfig = metricsConfig
+	e.leversConfig = leversConfig
+	e.mu.Unlock()
+
+	return nil
+}
+
+// RenderMetrics reloads metrics data from disk and renders it, and every
+// known KPI/KRI's scoring bands, in Prometheus text exposition format.
+func (e *Exporter) RenderMetrics() (string, error) {
+	if err := e.Reload(); err != nil {
+		return "", err
+	}
+
+	metricsData, err := e.configLoader.LoadMetricsData()
+	if err != nil {
+		return "", fmt.Errorf("failed to load metrics data: %w", err)
+	}
+
+	e.mu.Lock()
+	metricsConfig := e.metricsConfig
+	leversConfig := e.leversConfig
+	e.mu.Unlock()
+
+	processor := NewMetricsProcessor(metricsConfig, leversConfig, metricsData)
+	scoreCalculator := NewScoreCalculator(processor, MedianScoring)
+
+	var sb strings.Builder
+
+	writeHelp(&sb, "pulse_kpi_value", "Current value of a Pulse KPI or KRI")
+	sb.WriteString("# TYPE pulse_kpi_value gauge\n")
+
+	// Sort for deterministic scrape output
+	metrics := append([]Metric(nil), metricsData.Metrics...)
+	sort.Slice(metrics, func(i, j int) bool { return metrics[i].Reference < metrics[j].Reference })
+
+	for _, metric := range metrics {
+		parts := strings.Split(metric.Reference, ".")
+		if len(parts) != 3 {
+			continue
+		}
+		category := parts[0]
+
+		unit := ""
+		if def, err := processor.GetMetricDefinition(metric.Reference); err == nil {
+			switch d := def.(type) {
+			case KPI:
+				unit = d.Unit
+			case KRI:
+				unit = d.Unit
+			}
+		}
+
+		fmt.Fprintf(&sb, "pulse_kpi_value{category=%q,reference=%q,unit=%q} %s\n",
+			category, metric.Reference, unit, formatGaugeValue(metric.Value))
+	}
+
+	writeHelp(&sb, "pulse_kpi_status", "Scoring band Pulse assigned a metric's current value, one series per band")
+	sb.WriteString("# TYPE pulse_kpi_status gauge\n")
+
+	for _, metric := range metrics {
+		metricScore, err := scoreCalculator.CalculateMetricScore(metric)
+		if err != nil {
+			continue
+		}
+
+		parts := strings.Split(metric.Reference, ".")
+		if len(parts) != 3 {
+			continue
+		}
+		category := parts[0]
+
+		for _, band := range []TrafficLightStatus{Green, Yellow, Red} {
+			value := 0
+			if metricScore.Status == band {
+				value = 1
+			}
+			fmt.Fprintf(&sb, "pulse_kpi_status{category=%q,reference=%q,band=%q} %d\n",
+				category, metric.Reference, string(band), value)
+		}
+	}
+
+	writeHelp(&sb, "pulse_metric_score", "Current 0-100 score Pulse assigned a metric")
+	sb.WriteString("# TYPE pulse_metric_score gauge\n")
+	writeHelp(&sb, "pulse_metric_score_status", "Traffic-light band Pulse assigned a metric's score (0=green,1=yellow,2=red)")
+	sb.WriteString("# TYPE pulse_metric_score_status gauge\n")
+
+	for _, metric := range metrics {
+		metricScore, err := scoreCalculator.CalculateMetricScore(metric)
+		if err != nil {
+			continue
+		}
+
+		parts := strings.Split(metric.Reference, ".")
+		if len(parts) != 3 {
+			continue
+		}
+		category, kind, id := parts[0], parts[1], parts[2]
+
+		fmt.Fprintf(&sb, "pulse_metric_score{category=%q,kind=%q,id=%q} %d\n", category, kind, id, metricScore.Score)
+		fmt.Fprintf(&sb, "pulse_metric_score_status{category=%q,kind=%q,id=%q} %d\n", category, kind, id, trafficLightStatusValue(metricScore.Status))
+	}
+
+	writeHelp(&sb, "pulse_category_score", "Current 0-100 KPI/KRI rollup score for a category")
+	sb.WriteString("# TYPE pulse_category_score gauge\n")
+	writeHelp(&sb, "pulse_category_score_status", "Traffic-light band for a category's KPI/KRI rollup (0=green,1=yellow,2=red)")
+	sb.WriteString("# TYPE pulse_category_score_status gauge\n")
+
+	categories := append([]Category(nil), processor.GetAllCategories()...)
+	sort.Slice(categories, func(i, j int) bool { return categories[i].ID < categories[j].ID })
+
+	for _, category := range categories {
+		categoryScore, err := scoreCalculator.CalculateCategoryScore(category.ID)
+		if err != nil {
+			continue
+		}
+
+		for _, point := range []struct {
+			kind   string
+			score  int
+			status TrafficLightStatus
+		}{
+			{"kpi", categoryScore.KPIScore, categoryScore.KPIStatus},
+			{"kri", categoryScore.KRIScore, categoryScore.KRIStatus},
+		} {
+			fmt.Fprintf(&sb, "pulse_category_score{category=%q,kind=%q} %d\n", category.ID, point.kind, point.score)
+			fmt.Fprintf(&sb, "pulse_category_score_status{category=%q,kind=%q} %d\n", category.ID, point.kind, trafficLightStatusValue(point.status))
+		}
+	}
+
+	writeHelp(&sb, "pulse_overall_score", "Current 0-100 overall posture score")
+	sb.WriteString("# TYPE pulse_overall_score gauge\n")
+	writeHelp(&sb, "pulse_overall_score_status", "Traffic-light band for the overall posture score (0=green,1=yellow,2=red)")
+	sb.WriteString("# TYPE pulse_overall_score_status gauge\n")
+
+	if overallScore, err := scoreCalculator.CalculateOverallScore(); err == nil {
+		for _, point := range []struct {
+			kind   string
+			score  int
+			status TrafficLightStatus
+		}{
+			{"kpi", overallScore.KPIScore, overallScore.KPIStatus},
+			{"kri", overallScore.KRIScore, overallScore.KRIStatus},
+			{"combined", overallScore.Score, overallScore.Status},
+		} {
+			fmt.Fprintf(&sb, "pulse_overall_score{kind=%q} %d\n", point.kind, point.score)
+			fmt.Fprintf(&sb, "pulse_overall_score_status{kind=%q} %d\n", point.kind, trafficLightStatusValue(point.status))
+		}
+	}
+
+	writeHelp(&sb, "pulse_metric_updates_total", "Total number of metric value updates processed since the exporter started")
+	sb.WriteString("# TYPE pulse_metric_updates_total counter\n")
+	fmt.Fprintf(&sb, "pulse_metric_updates_total %d\n", MetricUpdatesTotal())
+
+	return sb.String(), nil
+}
+
+func writeHelp(sb *strings.Builder, name, help string) {
+	fmt.Fprintf(sb, "# HELP %s %s\n", name, help)
+}
+
+// trafficLightStatusValue maps status to the numeric value its companion
+// *_status gauge exposes, following the usual Prometheus convention for an
+// enum-like status metric: 0 for the best band, increasing with severity.
+func trafficLightStatusValue(status TrafficLightStatus) int {
+	switch status {
+	case Green:
+		return 0
+	case Yellow:
+		return 1
+	case Red:
+		return 2
+	default:
+		return -1
+	}
+}
+
+// formatGaugeValue renders a float64 the way Prometheus text exposition
+// expects, without unnecessary trailing zeros.
+func formatGaugeValue(value float64) string {
+	return strings.TrimRight(strings.TrimRight(fmt.Sprintf("%f", value), "0"), ".")
+}
+
+// MetricsHandler serves the Prometheus scrape endpoint.
+func (e *Exporter) MetricsHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, err := e.RenderMetrics()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		_, _ = w.Write([]byte(body))
+	}
+}
+
+// ImportHandler serves a POST endpoint that bulk-ingests metric
+// observations from the request body, in the format named by the
+// "format" query parameter (prom, openmetrics, or csv; defaults to csv),
+// so a scrape pipeline can push samples directly instead of writing YAML
+// data files. It reloads configuration, applies MetricsProcessor.ImportFrom,
+// persists the result, and responds with ImportResult as JSON.
+func (e *Exporter) ImportHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		if err := e.Reload(); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		metricsData, err := e.configLoader.LoadMetricsData()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		e.mu.Lock()
+		metricsConfig := e.metricsConfig
+		leversConfig := e.leversConfig
+		e.mu.Unlock()
+
+		format := ImportFormat(r.URL.Query().Get("format"))
+		if format == "" {
+			format = CSVImport
+		}
+
+		processor := NewMetricsProcessor(metricsConfig, leversConfig, metricsData)
+		result, err := processor.ImportFrom(r.Body, format)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if err := e.configLoader.SaveMetricsData(metricsData); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if len(result.Errors) > 0 {
+			w.WriteHeader(http.StatusMultiStatus)
+		}
+		_ = json.NewEncoder(w).Encode(result)
+	}
+}
+
+// HealthzHandler serves a minimal liveness endpoint.
+func (e *Exporter) HealthzHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = w.Write([]byte("ok"))
+	}
+}
+
+// ServeConfig configures the exporter's HTTP server.
+type ServeConfig struct {
+	Addr     string
+	CertFile string // if set along with KeyFile, the server listens with TLS
+	KeyFile  string
+}
+
+// Serve starts an HTTP(S) server exposing /metrics and /healthz and blocks
+// until it returns an error (including a clean shutdown from the caller
+// closing the listener).
+func (e *Exporter) Serve(cfg ServeConfig) error {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", e.MetricsHandler())
+	mux.Handle("/healthz", e.HealthzHandler())
+	mux.Handle("/import", e.ImportHandler())
+
+	server := &http.Server{
+		Addr:    cfg.Addr,
+		Handler: mux,
+	}
+
+	if cfg.CertFile != "" && cfg.KeyFile != "" {
+		server.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+		return server.ListenAndServeTLS(cfg.CertFile, cfg.KeyFile)
+	}
+
+	return server.ListenAndServe()
+}
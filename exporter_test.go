@@ -0,0 +1,85 @@
+package pulse
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestExporterRenderMetrics(t *testing.T) {
+	configDir := t.TempDir()
+	dataDir := t.TempDir()
+
+	metricsYAML := `categories:
+  - id: app_sec
+    name: Application Security
+    kpis:
+      - id: coverage
+        name: Test Coverage
+        unit: percent
+        scoring_bands:
+          - min: 80
+            score: 100
+          - max: 79
+            score: 50
+`
+	if err := os.WriteFile(filepath.Join(configDir, "metrics.yaml"), []byte(metricsYAML), 0600); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+
+	leversYAML := `global:
+  kpi_thresholds:
+    green:
+      min: 80
+      max: 100
+    yellow:
+      min: 50
+      max: 79
+    red:
+      min: 0
+      max: 49
+`
+	if err := os.WriteFile(filepath.Join(configDir, "levers.yaml"), []byte(leversYAML), 0600); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+
+	dataYAML := `metrics:
+  - reference: app_sec.KPI.coverage
+    value: 87
+    timestamp: 2026-01-01T00:00:00Z
+`
+	if err := os.WriteFile(filepath.Join(dataDir, "app_sec.yaml"), []byte(dataYAML), 0600); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+
+	configLoader := NewConfigLoader(configDir, dataDir)
+	exporter := NewExporter(configLoader)
+
+	body, err := exporter.RenderMetrics()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(body, `pulse_kpi_value{category="app_sec",reference="app_sec.KPI.coverage",unit="percent"} 87`) {
+		t.Fatalf("expected a gauge line for the metric value, got:\n%s", body)
+	}
+	if !strings.Contains(body, `pulse_kpi_status{category="app_sec",reference="app_sec.KPI.coverage",band="green"} 1`) {
+		t.Fatalf("expected the green band to be marked 1, got:\n%s", body)
+	}
+	if !strings.Contains(body, `pulse_metric_score{category="app_sec",kind="KPI",id="coverage"} 100`) {
+		t.Fatalf("expected a metric score gauge, got:\n%s", body)
+	}
+	if !strings.Contains(body, `pulse_metric_score_status{category="app_sec",kind="KPI",id="coverage"} 0`) {
+		t.Fatalf("expected a green metric score status (0), got:\n%s", body)
+	}
+	if !strings.Contains(body, `pulse_category_score{category="app_sec",kind="kpi"} 100`) {
+		t.Fatalf("expected a category KPI score gauge, got:\n%s", body)
+	}
+	if !strings.Contains(body, `pulse_overall_score{kind="combined"}`) {
+		t.Fatalf("expected a combined overall score gauge, got:\n%s", body)
+	}
+	if !strings.Contains(body, "# TYPE pulse_metric_updates_total counter") {
+		t.Fatalf("expected the updates counter to be declared, got:\n%s", body)
+	}
+}
@@ -0,0 +1,646 @@
+package pulse
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// formulaVariables is the set of bare identifiers a Formula may reference,
+// beyond metric(...) calls which are resolved at evaluation time.
+var formulaVariables = map[string]bool{
+	"value":    true,
+	"target":   true,
+	"min":      true,
+	"max":      true,
+	"previous": true,
+}
+
+// detectFormulaCycles compiles every KPI/KRI Formula in config (failing on
+// an unknown identifier or syntax error) and fails if their metric(...)
+// cross-references form a cycle, since such a formula could never settle on
+// a value.
+func detectFormulaCycles(config *MetricsConfig) error {
+	deps := make(map[string][]string)
+
+	for _, category := range config.Categories {
+		for _, kpi := range category.KPIs {
+			if kpi.Formula == "" {
+				continue
+			}
+			ref := category.ID + ".KPI." + kpi.ID
+			formula, err := CompileFormula(kpi.Formula, formulaVariables)
+			if err != nil {
+				return fmt.Errorf("%s: %w", ref, err)
+			}
+			deps[ref] = formula.MetricReferences()
+		}
+		for _, kri := range category.KRIs {
+			if kri.Formula == "" {
+				continue
+			}
+			ref := category.ID + ".KRI." + kri.ID
+			formula, err := CompileFormula(kri.Formula, formulaVariables)
+			if err != nil {
+				return fmt.Errorf("%s: %w", ref, err)
+			}
+			deps[ref] = formula.MetricReferences()
+		}
+	}
+
+	visiting := make(map[string]bool)
+	visited := make(map[string]bool)
+
+	var visit func(ref string, path []string) error
+	visit = func(ref string, path []string) error {
+		if visiting[ref] {
+			return fmt.Errorf("formula cycle detected: %s", strings.Join(append(path, ref), " -> "))
+		}
+		if visited[ref] {
+			return nil
+		}
+
+		visiting[ref] = true
+		for _, dep := range deps[ref] {
+			if err := visit(dep, append(path, ref)); err != nil {
+				return err
+			}
+		}
+		visiting[ref] = false
+		visited[ref] = true
+
+		return nil
+	}
+
+	for ref := range deps {
+		if err := visit(ref, nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Formula is a small expression used to compute a metric's 0-100 score
+// directly, as an alternative to step-based ScoringBands. Supported syntax:
+// +, -, *, /, %, comparisons (< <= > >= == !=), boolean && || !, the
+// ternary cond ? a : b, parenthesized sub-expressions, numeric literals,
+// variable identifiers, and calls to a small function whitelist (clamp,
+// min, max, abs, pow, log, ceil, floor) plus metric("category.TYPE.name")
+// to read a sibling metric's current value.
+type Formula struct {
+	expr string
+	ast  formulaNode
+}
+
+// FormulaContext supplies the variables and cross-metric resolver a Formula
+// evaluates against.
+type FormulaContext struct {
+	Variables map[string]float64
+	Metric    func(reference string) (float64, error)
+}
+
+// CompileFormula parses expr and checks that every variable identifier it
+// references is in allowedVars, so a formula that typos a variable name or
+// references one that doesn't exist fails at config-load time rather than
+// the first time a report tries to use it. References to sibling metrics
+// via metric("...") are resolved at evaluation time and are always allowed.
+func CompileFormula(expr string, allowedVars map[string]bool) (*Formula, error) {
+	ast, err := parseFormula(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid formula %q: %w", expr, err)
+	}
+
+	for _, ident := range identifiersOf(ast) {
+		if !allowedVars[ident] {
+			return nil, fmt.Errorf("formula %q references unknown identifier %q", expr, ident)
+		}
+	}
+
+	return &Formula{expr: expr, ast: ast}, nil
+}
+
+// Eval evaluates the formula against ctx.
+func (f *Formula) Eval(ctx FormulaContext) (float64, error) {
+	return f.ast.eval(ctx)
+}
+
+// MetricReferences returns every reference argument passed to metric(...)
+// in the formula, for building a cross-metric dependency graph at config
+// load time (see detectFormulaCycles in config.go).
+func (f *Formula) MetricReferences() []string {
+	return metricRefsOf(f.ast)
+}
+
+// String returns the original formula text.
+func (f *Formula) String() string {
+	return f.expr
+}
+
+// formulaNode is one node of a parsed formula's AST.
+type formulaNode interface {
+	eval(ctx FormulaContext) (float64, error)
+}
+
+type numberNode float64
+
+func (n numberNode) eval(ctx FormulaContext) (float64, error) { return float64(n), nil }
+
+type identNode string
+
+func (n identNode) eval(ctx FormulaContext) (float64, error) {
+	v, ok := ctx.Variables[string(n)]
+	if !ok {
+		return 0, fmt.Errorf("undefined variable %q", string(n))
+	}
+	return v, nil
+}
+
+type stringNode string
+
+func (n stringNode) eval(ctx FormulaContext) (float64, error) {
+	return 0, fmt.Errorf("string literal %q used outside of a function argument", string(n))
+}
+
+type unaryNode struct {
+	op      byte // '-' or '!'
+	operand formulaNode
+}
+
+func (n unaryNode) eval(ctx FormulaContext) (float64, error) {
+	v, err := n.operand.eval(ctx)
+	if err != nil {
+		return 0, err
+	}
+	switch n.op {
+	case '-':
+		return -v, nil
+	case '!':
+		return boolToFloat(v == 0), nil
+	default:
+		return 0, fmt.Errorf("unknown unary operator %q", n.op)
+	}
+}
+
+type binaryNode struct {
+	op          string
+	left, right formulaNode
+}
+
+func (n binaryNode) eval(ctx FormulaContext) (float64, error) {
+	l, err := n.left.eval(ctx)
+	if err != nil {
+		return 0, err
+	}
+	r, err := n.right.eval(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	switch n.op {
+	case "+":
+		return l + r, nil
+	case "-":
+		return l - r, nil
+	case "*":
+		return l * r, nil
+	case "/":
+		if r == 0 {
+			return 0, fmt.Errorf("division by zero")
+		}
+		return l / r, nil
+	case "%":
+		if r == 0 {
+			return 0, fmt.Errorf("division by zero")
+		}
+		return math.Mod(l, r), nil
+	case "<":
+		return boolToFloat(l < r), nil
+	case "<=":
+		return boolToFloat(l <= r), nil
+	case ">":
+		return boolToFloat(l > r), nil
+	case ">=":
+		return boolToFloat(l >= r), nil
+	case "==":
+		return boolToFloat(l == r), nil
+	case "!=":
+		return boolToFloat(l != r), nil
+	case "&&":
+		return boolToFloat(l != 0 && r != 0), nil
+	case "||":
+		return boolToFloat(l != 0 || r != 0), nil
+	default:
+		return 0, fmt.Errorf("unknown operator %q", n.op)
+	}
+}
+
+type ternaryNode struct {
+	cond, then, els formulaNode
+}
+
+func (n ternaryNode) eval(ctx FormulaContext) (float64, error) {
+	c, err := n.cond.eval(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if c != 0 {
+		return n.then.eval(ctx)
+	}
+	return n.els.eval(ctx)
+}
+
+type callNode struct {
+	name string
+	args []formulaNode
+}
+
+func (n callNode) eval(ctx FormulaContext) (float64, error) {
+	if n.name == "metric" {
+		if len(n.args) != 1 {
+			return 0, fmt.Errorf("metric() takes exactly one argument")
+		}
+		ref, ok := n.args[0].(stringNode)
+		if !ok {
+			return 0, fmt.Errorf("metric() argument must be a string literal")
+		}
+		if ctx.Metric == nil {
+			return 0, fmt.Errorf("no metric resolver configured for metric(%q)", string(ref))
+		}
+		return ctx.Metric(string(ref))
+	}
+
+	args := make([]float64, len(n.args))
+	for i, a := range n.args {
+		v, err := a.eval(ctx)
+		if err != nil {
+			return 0, err
+		}
+		args[i] = v
+	}
+
+	switch n.name {
+	case "clamp":
+		if len(args) != 3 {
+			return 0, fmt.Errorf("clamp() takes exactly 3 arguments")
+		}
+		v, lo, hi := args[0], args[1], args[2]
+		if v < lo {
+			return lo, nil
+		}
+		if v > hi {
+			return hi, nil
+		}
+		return v, nil
+	case "min":
+		return foldFloats(args, math.Min)
+	case "max":
+		return foldFloats(args, math.Max)
+	case "abs":
+		if len(args) != 1 {
+			return 0, fmt.Errorf("abs() takes exactly 1 argument")
+		}
+		return math.Abs(args[0]), nil
+	case "pow":
+		if len(args) != 2 {
+			return 0, fmt.Errorf("pow() takes exactly 2 arguments")
+		}
+		return math.Pow(args[0], args[1]), nil
+	case "log":
+		if len(args) != 1 {
+			return 0, fmt.Errorf("log() takes exactly 1 argument")
+		}
+		return math.Log(args[0]), nil
+	case "ceil":
+		if len(args) != 1 {
+			return 0, fmt.Errorf("ceil() takes exactly 1 argument")
+		}
+		return math.Ceil(args[0]), nil
+	case "floor":
+		if len(args) != 1 {
+			return 0, fmt.Errorf("floor() takes exactly 1 argument")
+		}
+		return math.Floor(args[0]), nil
+	default:
+		return 0, fmt.Errorf("unknown function %q", n.name)
+	}
+}
+
+func foldFloats(args []float64, f func(a, b float64) float64) (float64, error) {
+	if len(args) == 0 {
+		return 0, fmt.Errorf("at least one argument is required")
+	}
+	result := args[0]
+	for _, v := range args[1:] {
+		result = f(result, v)
+	}
+	return result, nil
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// identifiersOf walks ast and returns every bare variable identifier it
+// references (not function names, not metric() string arguments).
+func identifiersOf(n formulaNode) []string {
+	var out []string
+	walkFormula(n, func(node formulaNode) {
+		if ident, ok := node.(identNode); ok {
+			out = append(out, string(ident))
+		}
+	})
+	return out
+}
+
+// metricRefsOf walks ast and returns every reference passed to metric(...).
+func metricRefsOf(n formulaNode) []string {
+	var out []string
+	walkFormula(n, func(node formulaNode) {
+		call, ok := node.(callNode)
+		if !ok || call.name != "metric" || len(call.args) != 1 {
+			return
+		}
+		if ref, ok := call.args[0].(stringNode); ok {
+			out = append(out, string(ref))
+		}
+	})
+	return out
+}
+
+func walkFormula(n formulaNode, visit func(formulaNode)) {
+	if n == nil {
+		return
+	}
+	visit(n)
+
+	switch node := n.(type) {
+	case unaryNode:
+		walkFormula(node.operand, visit)
+	case binaryNode:
+		walkFormula(node.left, visit)
+		walkFormula(node.right, visit)
+	case ternaryNode:
+		walkFormula(node.cond, visit)
+		walkFormula(node.then, visit)
+		walkFormula(node.els, visit)
+	case callNode:
+		for _, a := range node.args {
+			walkFormula(a, visit)
+		}
+	}
+}
+
+// --- Parsing ---
+
+type formulaToken struct {
+	kind string // "num", "ident", "string", "op", "("  , ")", ",", "eof"
+	text string
+	num  float64
+}
+
+func tokenizeFormula(expr string) ([]formulaToken, error) {
+	var tokens []formulaToken
+	runes := []rune(expr)
+
+	for i := 0; i < len(runes); {
+		c := runes[i]
+
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case c == '(':
+			tokens = append(tokens, formulaToken{kind: "(", text: "("})
+			i++
+		case c == ')':
+			tokens = append(tokens, formulaToken{kind: ")", text: ")"})
+			i++
+		case c == ',':
+			tokens = append(tokens, formulaToken{kind: ",", text: ","})
+			i++
+		case c == '?':
+			tokens = append(tokens, formulaToken{kind: "op", text: "?"})
+			i++
+		case c == ':':
+			tokens = append(tokens, formulaToken{kind: "op", text: ":"})
+			i++
+		case c == '"':
+			j := i + 1
+			for j < len(runes) && runes[j] != '"' {
+				j++
+			}
+			if j >= len(runes) {
+				return nil, fmt.Errorf("unterminated string literal")
+			}
+			tokens = append(tokens, formulaToken{kind: "string", text: string(runes[i+1 : j])})
+			i = j + 1
+		case unicode.IsDigit(c) || c == '.':
+			j := i
+			for j < len(runes) && (unicode.IsDigit(runes[j]) || runes[j] == '.') {
+				j++
+			}
+			text := string(runes[i:j])
+			num, err := strconv.ParseFloat(text, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid number %q", text)
+			}
+			tokens = append(tokens, formulaToken{kind: "num", text: text, num: num})
+			i = j
+		case unicode.IsLetter(c) || c == '_':
+			j := i
+			for j < len(runes) && (unicode.IsLetter(runes[j]) || unicode.IsDigit(runes[j]) || runes[j] == '_') {
+				j++
+			}
+			tokens = append(tokens, formulaToken{kind: "ident", text: string(runes[i:j])})
+			i = j
+		default:
+			two := ""
+			if i+1 < len(runes) {
+				two = string(runes[i : i+2])
+			}
+			switch two {
+			case "&&", "||", "==", "!=", "<=", ">=":
+				tokens = append(tokens, formulaToken{kind: "op", text: two})
+				i += 2
+				continue
+			}
+			switch c {
+			case '+', '-', '*', '/', '%', '<', '>', '!':
+				tokens = append(tokens, formulaToken{kind: "op", text: string(c)})
+				i++
+			default:
+				return nil, fmt.Errorf("unexpected character %q", c)
+			}
+		}
+	}
+
+	tokens = append(tokens, formulaToken{kind: "eof"})
+	return tokens, nil
+}
+
+type formulaParser struct {
+	tokens []formulaToken
+	pos    int
+}
+
+func parseFormula(expr string) (formulaNode, error) {
+	tokens, err := tokenizeFormula(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &formulaParser{tokens: tokens}
+	node, err := p.parseTernary()
+	if err != nil {
+		return nil, err
+	}
+	if p.peek().kind != "eof" {
+		return nil, fmt.Errorf("unexpected token %q", p.peek().text)
+	}
+	return node, nil
+}
+
+func (p *formulaParser) peek() formulaToken { return p.tokens[p.pos] }
+
+func (p *formulaParser) next() formulaToken {
+	t := p.tokens[p.pos]
+	if t.kind != "eof" {
+		p.pos++
+	}
+	return t
+}
+
+func (p *formulaParser) parseTernary() (formulaNode, error) {
+	cond, err := p.parseLogicalOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.peek().kind == "op" && p.peek().text == "?" {
+		p.next()
+		thenExpr, err := p.parseTernary()
+		if err != nil {
+			return nil, err
+		}
+		if !(p.peek().kind == "op" && p.peek().text == ":") {
+			return nil, fmt.Errorf("expected ':' in ternary expression")
+		}
+		p.next()
+		elseExpr, err := p.parseTernary()
+		if err != nil {
+			return nil, err
+		}
+		return ternaryNode{cond: cond, then: thenExpr, els: elseExpr}, nil
+	}
+	return cond, nil
+}
+
+func (p *formulaParser) parseBinaryLevel(ops []string, next func() (formulaNode, error)) (formulaNode, error) {
+	left, err := next()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == "op" && containsStr(ops, p.peek().text) {
+		op := p.next().text
+		right, err := next()
+		if err != nil {
+			return nil, err
+		}
+		left = binaryNode{op: op, left: left, right: right}
+	}
+	return left, nil
+}
+
+func containsStr(ss []string, s string) bool {
+	for _, x := range ss {
+		if x == s {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *formulaParser) parseLogicalOr() (formulaNode, error) {
+	return p.parseBinaryLevel([]string{"||"}, p.parseLogicalAnd)
+}
+
+func (p *formulaParser) parseLogicalAnd() (formulaNode, error) {
+	return p.parseBinaryLevel([]string{"&&"}, p.parseEquality)
+}
+
+func (p *formulaParser) parseEquality() (formulaNode, error) {
+	return p.parseBinaryLevel([]string{"==", "!="}, p.parseComparison)
+}
+
+func (p *formulaParser) parseComparison() (formulaNode, error) {
+	return p.parseBinaryLevel([]string{"<", "<=", ">", ">="}, p.parseAdditive)
+}
+
+func (p *formulaParser) parseAdditive() (formulaNode, error) {
+	return p.parseBinaryLevel([]string{"+", "-"}, p.parseTerm)
+}
+
+func (p *formulaParser) parseTerm() (formulaNode, error) {
+	return p.parseBinaryLevel([]string{"*", "/", "%"}, p.parseUnary)
+}
+
+func (p *formulaParser) parseUnary() (formulaNode, error) {
+	if p.peek().kind == "op" && (p.peek().text == "-" || p.peek().text == "!") {
+		op := p.next().text
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return unaryNode{op: op[0], operand: operand}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *formulaParser) parsePrimary() (formulaNode, error) {
+	tok := p.peek()
+
+	switch tok.kind {
+	case "num":
+		p.next()
+		return numberNode(tok.num), nil
+	case "string":
+		p.next()
+		return stringNode(tok.text), nil
+	case "(":
+		p.next()
+		node, err := p.parseTernary()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek().kind != ")" {
+			return nil, fmt.Errorf("expected ')'")
+		}
+		p.next()
+		return node, nil
+	case "ident":
+		p.next()
+		if p.peek().kind == "(" {
+			p.next()
+			var args []formulaNode
+			for p.peek().kind != ")" {
+				arg, err := p.parseTernary()
+				if err != nil {
+					return nil, err
+				}
+				args = append(args, arg)
+				if p.peek().kind == "," {
+					p.next()
+				}
+			}
+			p.next()
+			return callNode{name: tok.text, args: args}, nil
+		}
+		return identNode(tok.text), nil
+	default:
+		return nil, fmt.Errorf("unexpected token %q", tok.text)
+	}
+}
@@ -0,0 +1,111 @@
+package pulse
+
+import "testing"
+
+func TestFormulaClampAndTernary(t *testing.T) {
+	formula, err := CompileFormula(`clamp(100 - (value/target)*20, 0, 100)`, formulaVariables)
+	if err != nil {
+		t.Fatalf("unexpected compile error: %v", err)
+	}
+
+	result, err := formula.Eval(FormulaContext{Variables: map[string]float64{"value": 10, "target": 5}})
+	if err != nil {
+		t.Fatalf("unexpected eval error: %v", err)
+	}
+	if result != 60 {
+		t.Fatalf("expected 60, got %v", result)
+	}
+}
+
+func TestFormulaTernaryOperator(t *testing.T) {
+	formula, err := CompileFormula(`value >= 95 ? 100 : value`, formulaVariables)
+	if err != nil {
+		t.Fatalf("unexpected compile error: %v", err)
+	}
+
+	result, err := formula.Eval(FormulaContext{Variables: map[string]float64{"value": 97}})
+	if err != nil {
+		t.Fatalf("unexpected eval error: %v", err)
+	}
+	if result != 100 {
+		t.Fatalf("expected 100, got %v", result)
+	}
+
+	result, err = formula.Eval(FormulaContext{Variables: map[string]float64{"value": 80}})
+	if err != nil {
+		t.Fatalf("unexpected eval error: %v", err)
+	}
+	if result != 80 {
+		t.Fatalf("expected 80, got %v", result)
+	}
+}
+
+func TestFormulaRejectsUnknownIdentifier(t *testing.T) {
+	_, err := CompileFormula(`value + bogus`, formulaVariables)
+	if err == nil {
+		t.Fatal("expected an error for an unknown identifier")
+	}
+}
+
+func TestFormulaMetricReference(t *testing.T) {
+	formula, err := CompileFormula(`metric("app_sec.KPI.coverage") / metric("app_sec.KPI.total")`, formulaVariables)
+	if err != nil {
+		t.Fatalf("unexpected compile error: %v", err)
+	}
+
+	refs := formula.MetricReferences()
+	if len(refs) != 2 || refs[0] != "app_sec.KPI.coverage" || refs[1] != "app_sec.KPI.total" {
+		t.Fatalf("unexpected metric references: %v", refs)
+	}
+
+	result, err := formula.Eval(FormulaContext{
+		Metric: func(ref string) (float64, error) {
+			if ref == "app_sec.KPI.coverage" {
+				return 50, nil
+			}
+			return 100, nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected eval error: %v", err)
+	}
+	if result != 0.5 {
+		t.Fatalf("expected 0.5, got %v", result)
+	}
+}
+
+func TestDetectFormulaCyclesRejectsCycle(t *testing.T) {
+	config := &MetricsConfig{
+		Categories: []Category{
+			{
+				ID: "app_sec",
+				KPIs: []KPI{
+					{ID: "a", Formula: `metric("app_sec.KPI.b")`},
+					{ID: "b", Formula: `metric("app_sec.KPI.a")`},
+				},
+			},
+		},
+	}
+
+	if err := detectFormulaCycles(config); err == nil {
+		t.Fatal("expected a cycle error")
+	}
+}
+
+func TestDetectFormulaCyclesAllowsAcyclicGraph(t *testing.T) {
+	config := &MetricsConfig{
+		Categories: []Category{
+			{
+				ID: "app_sec",
+				KPIs: []KPI{
+					{ID: "a", Formula: `metric("app_sec.KPI.b") * 2`},
+					{ID: "b", Formula: `value`},
+				},
+			},
+		},
+	}
+
+	if err := detectFormulaCycles(config); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
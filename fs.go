@@ -0,0 +1,259 @@
+package pulse
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// FS abstracts the filesystem operations ConfigLoader needs, so configuration
+// and metrics data can be backed by something other than local disk (a git
+// working tree, an object store, etc). Paths are always passed in OS form
+// (as produced by filepath.Join against ConfigDir/DataDir).
+type FS interface {
+	Open(name string) (io.ReadCloser, error)
+	Create(name string, perm fs.FileMode) (io.WriteCloser, error)
+	Rename(oldpath, newpath string) error
+	Stat(name string) (fs.FileInfo, error)
+	ReadDir(name string) ([]fs.DirEntry, error)
+	MkdirAll(path string, perm fs.FileMode) error
+	Remove(name string) error
+}
+
+// OSFS is the default FS, backed directly by the local filesystem.
+type OSFS struct{}
+
+func (OSFS) Open(name string) (io.ReadCloser, error) { return os.Open(name) }
+
+func (OSFS) Create(name string, perm fs.FileMode) (io.WriteCloser, error) {
+	return os.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+}
+
+func (OSFS) Rename(oldpath, newpath string) error { return os.Rename(oldpath, newpath) }
+
+func (OSFS) Stat(name string) (fs.FileInfo, error) { return os.Stat(name) }
+
+func (OSFS) ReadDir(name string) ([]fs.DirEntry, error) { return os.ReadDir(name) }
+
+func (OSFS) MkdirAll(p string, perm fs.FileMode) error { return os.MkdirAll(p, perm) }
+
+func (OSFS) Remove(name string) error { return os.Remove(name) }
+
+// GitFS wraps another FS (OSFS by default) and commits every file written
+// through Create, so each SaveMetricsData/CreateMetricFile call leaves an
+// auditable git history entry. RepoDir must be the root of a git working
+// tree containing every path passed to Create.
+type GitFS struct {
+	FS
+	RepoDir         string
+	MessageTemplate string // e.g. "pulse: update %s"; defaults if empty
+	Sign            bool   // pass -S to `git commit`
+}
+
+// NewGitFS creates a GitFS rooted at repoDir, backed by OSFS.
+func NewGitFS(repoDir, messageTemplate string) *GitFS {
+	if messageTemplate == "" {
+		messageTemplate = "pulse: update %s"
+	}
+	return &GitFS{FS: OSFS{}, RepoDir: repoDir, MessageTemplate: messageTemplate}
+}
+
+// Create writes through to the wrapped FS and commits the file to git once
+// the returned writer is closed.
+func (g *GitFS) Create(name string, perm fs.FileMode) (io.WriteCloser, error) {
+	w, err := g.FS.Create(name, perm)
+	if err != nil {
+		return nil, err
+	}
+	return &gitCommitWriter{WriteCloser: w, fs: g, path: name}, nil
+}
+
+type gitCommitWriter struct {
+	io.WriteCloser
+	fs   *GitFS
+	path string
+}
+
+func (w *gitCommitWriter) Close() error {
+	if err := w.WriteCloser.Close(); err != nil {
+		return err
+	}
+	return w.fs.commit(w.path)
+}
+
+func (g *GitFS) commit(path string) error {
+	rel, err := filepath.Rel(g.RepoDir, path)
+	if err != nil {
+		rel = path
+	}
+
+	addCmd := exec.Command("git", "-C", g.RepoDir, "add", "--", rel)
+	if err := addCmd.Run(); err != nil {
+		return fmt.Errorf("git add failed for %s: %w", rel, err)
+	}
+
+	commitArgs := []string{"-C", g.RepoDir, "commit"}
+	if g.Sign {
+		commitArgs = append(commitArgs, "-S")
+	}
+	commitArgs = append(commitArgs, "-m", fmt.Sprintf(g.MessageTemplate, rel), "--", rel)
+
+	commitCmd := exec.Command("git", commitArgs...)
+	if err := commitCmd.Run(); err != nil {
+		return fmt.Errorf("git commit failed for %s: %w", rel, err)
+	}
+
+	return nil
+}
+
+// S3Client is the subset of an S3 SDK client S3FS needs. Pulse does not
+// depend on an AWS SDK directly; adapt whichever client version a
+// deployment vendors to this interface.
+type S3Client interface {
+	PutObject(key string, body []byte) error
+	GetObject(key string) ([]byte, error)
+	ListObjects(prefix string) ([]string, error)
+	DeleteObject(key string) error
+}
+
+// S3FS is an FS backed by an object store, keyed by bucket+prefix, for
+// running Pulse against a read-only container image.
+type S3FS struct {
+	Client S3Client
+	Prefix string
+}
+
+// NewS3FS creates an S3FS that stores objects under prefix via client.
+func NewS3FS(client S3Client, prefix string) *S3FS {
+	return &S3FS{Client: client, Prefix: prefix}
+}
+
+func (s *S3FS) key(name string) string {
+	return path.Join(s.Prefix, filepath.ToSlash(name))
+}
+
+func (s *S3FS) Open(name string) (io.ReadCloser, error) {
+	data, err := s.Client.GetObject(s.key(name))
+	if err != nil {
+		return nil, err
+	}
+	return &byteReadCloser{Reader: newByteReader(data)}, nil
+}
+
+func (s *S3FS) Create(name string, perm fs.FileMode) (io.WriteCloser, error) {
+	return &s3ObjectWriter{fs: s, key: s.key(name)}, nil
+}
+
+func (s *S3FS) Rename(oldpath, newpath string) error {
+	data, err := s.Client.GetObject(s.key(oldpath))
+	if err != nil {
+		return err
+	}
+	if err := s.Client.PutObject(s.key(newpath), data); err != nil {
+		return err
+	}
+	return s.Client.DeleteObject(s.key(oldpath))
+}
+
+func (s *S3FS) Stat(name string) (fs.FileInfo, error) {
+	keys, err := s.Client.ListObjects(s.key(name))
+	if err != nil {
+		return nil, err
+	}
+	for _, k := range keys {
+		if k == s.key(name) {
+			return s3FileInfo{name: filepath.Base(name)}, nil
+		}
+	}
+	return nil, fs.ErrNotExist
+}
+
+func (s *S3FS) ReadDir(name string) ([]fs.DirEntry, error) {
+	keys, err := s.Client.ListObjects(s.key(name))
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]fs.DirEntry, 0, len(keys))
+	for _, k := range keys {
+		entries = append(entries, s3DirEntry{name: path.Base(k)})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+
+	return entries, nil
+}
+
+func (s *S3FS) MkdirAll(p string, perm fs.FileMode) error {
+	// Object stores have no real directories; nothing to do.
+	return nil
+}
+
+func (s *S3FS) Remove(name string) error {
+	return s.Client.DeleteObject(s.key(name))
+}
+
+type s3ObjectWriter struct {
+	fs  *S3FS
+	key string
+	buf []byte
+}
+
+func (w *s3ObjectWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	return len(p), nil
+}
+
+func (w *s3ObjectWriter) Close() error {
+	return w.fs.Client.PutObject(w.key, w.buf)
+}
+
+type byteReadCloser struct {
+	Reader interface {
+		Read(p []byte) (int, error)
+	}
+}
+
+func (b *byteReadCloser) Read(p []byte) (int, error) { return b.Reader.Read(p) }
+func (b *byteReadCloser) Close() error                { return nil }
+
+type byteReader struct {
+	data []byte
+	pos  int
+}
+
+func newByteReader(data []byte) *byteReader { return &byteReader{data: data} }
+
+func (r *byteReader) Read(p []byte) (int, error) {
+	if r.pos >= len(r.data) {
+		return 0, io.EOF
+	}
+	n := copy(p, r.data[r.pos:])
+	r.pos += n
+	return n, nil
+}
+
+type s3FileInfo struct {
+	name string
+}
+
+func (i s3FileInfo) Name() string       { return i.name }
+func (i s3FileInfo) Size() int64        { return 0 }
+func (i s3FileInfo) Mode() fs.FileMode  { return 0 }
+func (i s3FileInfo) ModTime() time.Time { return time.Time{} }
+func (i s3FileInfo) IsDir() bool        { return false }
+func (i s3FileInfo) Sys() interface{}   { return nil }
+
+type s3DirEntry struct {
+	name string
+}
+
+func (e s3DirEntry) Name() string               { return e.name }
+func (e s3DirEntry) IsDir() bool                { return false }
+func (e s3DirEntry) Type() fs.FileMode          { return 0 }
+func (e s3DirEntry) Info() (fs.FileInfo, error) { return s3FileInfo{name: e.name}, nil }
@@ -0,0 +1,139 @@
+package pulse
+
+import (
+	"bytes"
+	"io"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestConfigLoaderWithCustomFS(t *testing.T) {
+	mem := newMemFS()
+	configLoader := NewConfigLoader("/config", "/data", WithFS(mem))
+
+	metricsData := &MetricsData{
+		Metrics: []Metric{
+			{Reference: "app_sec.KPI.coverage", Value: 87, SourceFile: "app_sec.yaml"},
+		},
+	}
+
+	if err := configLoader.SaveMetricsData(metricsData); err != nil {
+		t.Fatalf("failed to save metrics data: %v", err)
+	}
+
+	if _, ok := mem.files["/data/app_sec.yaml"]; !ok {
+		t.Fatalf("expected SaveMetricsData to write through the injected FS, got: %v", mem.files)
+	}
+
+	loaded, err := configLoader.LoadMetricsData()
+	if err != nil {
+		t.Fatalf("failed to load metrics data: %v", err)
+	}
+	if len(loaded.Metrics) != 1 || loaded.Metrics[0].Reference != "app_sec.KPI.coverage" {
+		t.Fatalf("expected the previously saved metric to round-trip, got: %+v", loaded.Metrics)
+	}
+}
+
+// memFS is a minimal in-memory FS used to verify ConfigLoader routes every
+// file operation through the injected FS rather than the local disk.
+type memFS struct {
+	files map[string][]byte
+	dirs  map[string]bool
+}
+
+func newMemFS() *memFS {
+	return &memFS{files: make(map[string][]byte), dirs: make(map[string]bool)}
+}
+
+func (m *memFS) Open(name string) (io.ReadCloser, error) {
+	data, ok := m.files[name]
+	if !ok {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (m *memFS) Create(name string, perm fs.FileMode) (io.WriteCloser, error) {
+	return &memWriteCloser{fs: m, path: name}, nil
+}
+
+func (m *memFS) Rename(oldpath, newpath string) error {
+	data, ok := m.files[oldpath]
+	if !ok {
+		return &fs.PathError{Op: "rename", Path: oldpath, Err: fs.ErrNotExist}
+	}
+	m.files[newpath] = data
+	delete(m.files, oldpath)
+	return nil
+}
+
+func (m *memFS) Stat(name string) (fs.FileInfo, error) {
+	if m.dirs[name] {
+		return memFileInfo{name: filepath.Base(name), isDir: true}, nil
+	}
+	if _, ok := m.files[name]; ok {
+		return memFileInfo{name: filepath.Base(name)}, nil
+	}
+	return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrNotExist}
+}
+
+func (m *memFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	var entries []fs.DirEntry
+	for p := range m.files {
+		dir, file := path.Split(p)
+		if strings.TrimSuffix(dir, "/") == strings.TrimSuffix(name, "/") {
+			entries = append(entries, memDirEntry{name: file})
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+	return entries, nil
+}
+
+func (m *memFS) MkdirAll(p string, perm fs.FileMode) error {
+	m.dirs[p] = true
+	return nil
+}
+
+func (m *memFS) Remove(name string) error {
+	delete(m.files, name)
+	return nil
+}
+
+type memWriteCloser struct {
+	fs   *memFS
+	path string
+	buf  bytes.Buffer
+}
+
+func (w *memWriteCloser) Write(p []byte) (int, error) { return w.buf.Write(p) }
+
+func (w *memWriteCloser) Close() error {
+	w.fs.files[w.path] = append([]byte(nil), w.buf.Bytes()...)
+	return nil
+}
+
+type memFileInfo struct {
+	name  string
+	isDir bool
+}
+
+func (i memFileInfo) Name() string       { return i.name }
+func (i memFileInfo) Size() int64        { return 0 }
+func (i memFileInfo) Mode() fs.FileMode  { return 0 }
+func (i memFileInfo) ModTime() time.Time { return time.Time{} }
+func (i memFileInfo) IsDir() bool        { return i.isDir }
+func (i memFileInfo) Sys() interface{}   { return nil }
+
+type memDirEntry struct {
+	name string
+}
+
+func (e memDirEntry) Name() string               { return e.name }
+func (e memDirEntry) IsDir() bool                { return false }
+func (e memDirEntry) Type() fs.FileMode          { return 0 }
+func (e memDirEntry) Info() (fs.FileInfo, error) { return memFileInfo{name: e.name}, nil }
@@ -0,0 +1,221 @@
+package pulse
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// HistoryPoint is a single recorded observation of a metric's value.
+type HistoryPoint struct {
+	Reference string    `json:"reference"`
+	Value     float64   `json:"value"`
+	Timestamp time.Time `json:"timestamp"`
+	Author    string    `json:"author,omitempty"`
+}
+
+// HistoryStore is an append-only, NDJSON-backed time-series log of metric
+// values, partitioned into one file per UTC day under Dir.
+type HistoryStore struct {
+	Dir string
+}
+
+// NewHistoryStore creates a HistoryStore rooted at dir (typically
+// <data-dir>/history).
+func NewHistoryStore(dir string) *HistoryStore {
+	return &HistoryStore{Dir: dir}
+}
+
+// Append records a HistoryPoint, creating Dir and the day's file as needed.
+func (h *HistoryStore) Append(point HistoryPoint) error {
+	if err := os.MkdirAll(h.Dir, 0750); err != nil {
+		return fmt.Errorf("failed to create history directory: %w", err)
+	}
+
+	data, err := json.Marshal(point)
+	if err != nil {
+		return fmt.Errorf("failed to marshal history point: %w", err)
+	}
+
+	path := h.pathForDay(point.Timestamp)
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open history file %s: %w", path, err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write history point: %w", err)
+	}
+
+	return nil
+}
+
+// Query returns every HistoryPoint recorded for reference within [from, to],
+// ordered by timestamp ascending.
+func (h *HistoryStore) Query(reference string, from, to time.Time) ([]HistoryPoint, error) {
+	var points []HistoryPoint
+
+	for d := from.UTC().Truncate(24 * time.Hour); !d.After(to); d = d.AddDate(0, 0, 1) {
+		dayPoints, err := h.readDay(d)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range dayPoints {
+			if p.Reference != reference {
+				continue
+			}
+			if p.Timestamp.Before(from) || p.Timestamp.After(to) {
+				continue
+			}
+			points = append(points, p)
+		}
+	}
+
+	sort.Slice(points, func(i, j int) bool {
+		return points[i].Timestamp.Before(points[j].Timestamp)
+	})
+
+	return points, nil
+}
+
+// ValueAt returns the most recent value recorded for reference at or before
+// t, and true if one was found.
+func (h *HistoryStore) ValueAt(reference string, t time.Time) (float64, bool, error) {
+	points, err := h.Query(reference, time.Time{}, t)
+	if err != nil {
+		return 0, false, err
+	}
+	if len(points) == 0 {
+		return 0, false, nil
+	}
+	return points[len(points)-1].Value, true, nil
+}
+
+// ApplyRetention enforces policy against the store's existing day files,
+// relative to now. Days older than RawDays are downsampled in place to a
+// single end-of-day point per reference; days older than RawDays+DailyDays
+// are deleted outright. A zero RawDays disables retention entirely (the
+// default, meaning "keep everything").
+func (h *HistoryStore) ApplyRetention(policy RetentionPolicy, now time.Time) error {
+	if policy.RawDays <= 0 {
+		return nil
+	}
+
+	rawCutoff := now.AddDate(0, 0, -policy.RawDays)
+
+	var dailyCutoff time.Time
+	if policy.DailyDays > 0 {
+		dailyCutoff = rawCutoff.AddDate(0, 0, -policy.DailyDays)
+	}
+
+	entries, err := os.ReadDir(h.Dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to read history directory: %w", err)
+	}
+
+	for _, entry := range entries {
+		name := entry.Name()
+		day, err := time.ParseInLocation("2006-01-02", filepath.Base(name[:len(name)-len(filepath.Ext(name))]), time.UTC)
+		if err != nil {
+			continue
+		}
+
+		path := filepath.Join(h.Dir, name)
+
+		if !dailyCutoff.IsZero() && day.Before(dailyCutoff) {
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("failed to prune history file %s: %w", path, err)
+			}
+			continue
+		}
+
+		if day.Before(rawCutoff) {
+			if err := h.downsampleDay(day); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// downsampleDay collapses every point recorded on day down to the last
+// observed value per reference, overwriting the day's file.
+func (h *HistoryStore) downsampleDay(day time.Time) error {
+	points, err := h.readDay(day)
+	if err != nil {
+		return err
+	}
+
+	latest := make(map[string]HistoryPoint, len(points))
+	for _, p := range points {
+		existing, ok := latest[p.Reference]
+		if !ok || p.Timestamp.After(existing.Timestamp) {
+			latest[p.Reference] = p
+		}
+	}
+
+	path := h.pathForDay(day)
+	f, err := os.OpenFile(path, os.O_TRUNC|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to rewrite history file %s: %w", path, err)
+	}
+	defer f.Close()
+
+	for _, p := range latest {
+		data, err := json.Marshal(p)
+		if err != nil {
+			return fmt.Errorf("failed to marshal history point: %w", err)
+		}
+		if _, err := f.Write(append(data, '\n')); err != nil {
+			return fmt.Errorf("failed to write history point: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// pathForDay returns the NDJSON file path for the UTC day containing t.
+func (h *HistoryStore) pathForDay(t time.Time) string {
+	return filepath.Join(h.Dir, t.UTC().Format("2006-01-02")+".ndjson")
+}
+
+func (h *HistoryStore) readDay(t time.Time) ([]HistoryPoint, error) {
+	path := h.pathForDay(t)
+
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to open history file %s: %w", path, err)
+	}
+	defer f.Close()
+
+	var points []HistoryPoint
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var point HistoryPoint
+		if err := json.Unmarshal(line, &point); err != nil {
+			return nil, fmt.Errorf("failed to parse history file %s: %w", path, err)
+		}
+		points = append(points, point)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read history file %s: %w", path, err)
+	}
+
+	return points, nil
+}
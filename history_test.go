@@ -0,0 +1,95 @@
+package pulse
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHistoryStoreAppendAndQuery(t *testing.T) {
+	store := NewHistoryStore(t.TempDir())
+
+	base := time.Date(2026, 7, 20, 12, 0, 0, 0, time.UTC)
+	points := []HistoryPoint{
+		{Reference: "app_sec.KPI.coverage", Value: 50, Timestamp: base},
+		{Reference: "app_sec.KPI.coverage", Value: 60, Timestamp: base.Add(24 * time.Hour)},
+		{Reference: "app_sec.KPI.coverage", Value: 70, Timestamp: base.Add(48 * time.Hour)},
+		{Reference: "other.KPI.metric", Value: 99, Timestamp: base},
+	}
+
+	for _, p := range points {
+		if err := store.Append(p); err != nil {
+			t.Fatalf("failed to append history point: %v", err)
+		}
+	}
+
+	results, err := store.Query("app_sec.KPI.coverage", base, base.Add(48*time.Hour))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("expected 3 points, got %d", len(results))
+	}
+	if results[0].Value != 50 || results[2].Value != 70 {
+		t.Fatalf("unexpected ordering: %+v", results)
+	}
+
+	value, found, err := store.ValueAt("app_sec.KPI.coverage", base.Add(30*time.Hour))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !found || value != 60 {
+		t.Fatalf("expected the most recent value at or before the query time, got %v found=%v", value, found)
+	}
+}
+
+func TestHistoryStoreValueAtNoData(t *testing.T) {
+	store := NewHistoryStore(t.TempDir())
+
+	_, found, err := store.ValueAt("app_sec.KPI.coverage", time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if found {
+		t.Fatal("expected no value to be found for an empty store")
+	}
+}
+
+func TestHistoryStoreApplyRetentionDownsamplesAndPrunes(t *testing.T) {
+	store := NewHistoryStore(t.TempDir())
+
+	now := time.Date(2026, 7, 26, 0, 0, 0, 0, time.UTC)
+	downsampleDay := now.AddDate(0, 0, -10)
+	pruneDay := now.AddDate(0, 0, -100)
+
+	points := []HistoryPoint{
+		{Reference: "app_sec.KPI.coverage", Value: 10, Timestamp: downsampleDay},
+		{Reference: "app_sec.KPI.coverage", Value: 20, Timestamp: downsampleDay.Add(6 * time.Hour)},
+		{Reference: "app_sec.KPI.coverage", Value: 99, Timestamp: pruneDay},
+	}
+	for _, p := range points {
+		if err := store.Append(p); err != nil {
+			t.Fatalf("failed to append history point: %v", err)
+		}
+	}
+
+	policy := RetentionPolicy{RawDays: 7, DailyDays: 30}
+	if err := store.ApplyRetention(policy, now); err != nil {
+		t.Fatalf("unexpected error applying retention: %v", err)
+	}
+
+	results, err := store.Query("app_sec.KPI.coverage", downsampleDay, downsampleDay.Add(24*time.Hour))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != 1 || results[0].Value != 20 {
+		t.Fatalf("expected the day to be downsampled to its last value, got %+v", results)
+	}
+
+	pruned, err := store.Query("app_sec.KPI.coverage", pruneDay, pruneDay.Add(24*time.Hour))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(pruned) != 0 {
+		t.Fatalf("expected the stale day to be pruned, got %+v", pruned)
+	}
+}
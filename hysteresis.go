@@ -0,0 +1,250 @@
+package pulse
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// bandRank orders TrafficLightStatus from worst (Red) to best (Green) so
+// BandResolver can tell which direction a proposed transition moves, and -1
+// for anything else.
+func bandRank(status TrafficLightStatus) int {
+	switch status {
+	case Red:
+		return 0
+	case Yellow:
+		return 1
+	case Green:
+		return 2
+	default:
+		return -1
+	}
+}
+
+// boundaryFor returns the plain (no-hysteresis) score boundary between two
+// adjacent bands: at=0 is the Red/Yellow boundary, at=1 is the Yellow/Green
+// boundary. Under HigherIsBetter that's Yellow's and Green's lower bound
+// respectively; under LowerIsBetter the stack is inverted (Green low, Red
+// high), so it's Yellow's and Green's upper bound instead.
+func boundaryFor(thresholds Thresholds, at int, direction Direction) float64 {
+	band := thresholds.Yellow
+	if at == 1 {
+		band = thresholds.Green
+	}
+
+	lower, upper := band.normalizedBounds()
+	edge := lower
+	if direction == LowerIsBetter {
+		edge = upper
+	}
+	if edge != nil {
+		return edge.Value
+	}
+	return 0
+}
+
+// enterCutoff returns the score a target must clear to move into a better
+// band (e.g. Yellow -> Green): under HigherIsBetter that means reaching or
+// exceeding boundary, under LowerIsBetter it means falling to or below it.
+// EnterMargin shifts the plain boundary further in the harder-to-reach
+// direction; EnterMin, when set, is used as the cutoff directly.
+func enterCutoff(boundary float64, h *Hysteresis, direction Direction) float64 {
+	if h == nil {
+		return boundary
+	}
+	if h.EnterMin != nil {
+		return *h.EnterMin
+	}
+	if h.EnterMargin != nil {
+		if direction == LowerIsBetter {
+			return boundary - *h.EnterMargin
+		}
+		return boundary + *h.EnterMargin
+	}
+	return boundary
+}
+
+// exitCutoff returns the score a target must fall back across to leave a
+// band for a worse one (e.g. Green -> Yellow): under HigherIsBetter that
+// means falling to or below boundary, under LowerIsBetter it means rising
+// to or above it. ExitMargin shifts the plain boundary further in the
+// harder-to-leave direction; ExitMin, when set, is used as the cutoff
+// directly.
+func exitCutoff(boundary float64, h *Hysteresis, direction Direction) float64 {
+	if h == nil {
+		return boundary
+	}
+	if h.ExitMin != nil {
+		return *h.ExitMin
+	}
+	if h.ExitMargin != nil {
+		if direction == LowerIsBetter {
+			return boundary + *h.ExitMargin
+		}
+		return boundary - *h.ExitMargin
+	}
+	return boundary
+}
+
+// resolveWithHysteresis decides whether to honor a transition from prev to
+// bare (the plain determineStatus result) under thresholds.Hysteresis: a
+// move away from prev only takes effect once score clears every boundary's
+// enter cutoff (moving to a better band) or exit cutoff (moving to a worse
+// one) between the two bands; otherwise the target sticks to prev.
+// thresholds.effectiveDirection decides which side of each cutoff counts as
+// "clears" for a lower-is-better metric.
+func resolveWithHysteresis(prev, bare TrafficLightStatus, score int, thresholds Thresholds) TrafficLightStatus {
+	h := thresholds.Hysteresis
+	prevRank, bareRank := bandRank(prev), bandRank(bare)
+	if h == nil || prevRank < 0 || bareRank < 0 || prevRank == bareRank {
+		return bare
+	}
+
+	direction := thresholds.effectiveDirection()
+	lowerIsBetter := direction == LowerIsBetter
+	value := float64(score)
+
+	if bareRank > prevRank {
+		for boundary := prevRank; boundary < bareRank; boundary++ {
+			cutoff := enterCutoff(boundaryFor(thresholds, boundary, direction), h, direction)
+			if lowerIsBetter {
+				if value > cutoff {
+					return prev
+				}
+				continue
+			}
+			if value < cutoff {
+				return prev
+			}
+		}
+		return bare
+	}
+
+	for boundary := bareRank; boundary < prevRank; boundary++ {
+		cutoff := exitCutoff(boundaryFor(thresholds, boundary, direction), h, direction)
+		if lowerIsBetter {
+			if value < cutoff {
+				return prev
+			}
+			continue
+		}
+		if value > cutoff {
+			return prev
+		}
+	}
+	return bare
+}
+
+// bandResolverState is the on-disk representation of a BandResolver's last
+// known band per target, persisted so hysteresis survives across separate
+// CLI invocations the same way AlertEngine's accrual counters do.
+type bandResolverState struct {
+	LastBand map[string]TrafficLightStatus `json:"last_band"`
+}
+
+// BandResolver maps a raw score to a TrafficLightStatus the same way
+// determineStatus does, except when thresholds.Hysteresis is set: then it
+// only honors a transition away from a target's last resolved band once the
+// score clears that transition's enter/exit margin, preventing a target
+// from flapping between Green/Yellow/Red on near-identical scores across
+// runs.
+type BandResolver struct {
+	journalPath string
+	lastBand    map[string]TrafficLightStatus
+}
+
+// NewBandResolver creates a BandResolver that persists its per-target "last
+// band" journal to journalPath. An empty journalPath disables persistence;
+// the resolver still applies hysteresis within a single process.
+func NewBandResolver(journalPath string) *BandResolver {
+	return &BandResolver{
+		journalPath: journalPath,
+		lastBand:    make(map[string]TrafficLightStatus),
+	}
+}
+
+// Load restores the journal previously written by Save. A missing file, or
+// an empty journalPath, is not an error; the resolver simply starts fresh.
+func (r *BandResolver) Load() error {
+	if r.journalPath == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(r.journalPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to read band resolver journal: %w", err)
+	}
+
+	var state bandResolverState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return fmt.Errorf("failed to parse band resolver journal: %w", err)
+	}
+
+	if state.LastBand != nil {
+		r.lastBand = state.LastBand
+	}
+
+	return nil
+}
+
+// Save persists the resolver's per-target last-band journal to journalPath
+// so the next invocation can resume hysteresis from where this one left off.
+func (r *BandResolver) Save() error {
+	if r.journalPath == "" {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(bandResolverState{LastBand: r.lastBand}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal band resolver journal: %w", err)
+	}
+
+	if err := os.WriteFile(r.journalPath, data, 0600); err != nil {
+		return fmt.Errorf("failed to write band resolver journal: %w", err)
+	}
+
+	return nil
+}
+
+// Resolve maps score to a TrafficLightStatus under thresholds, applying
+// thresholds.Hysteresis (if set) relative to target's previously resolved
+// band, and records the result as target's new last band.
+func (r *BandResolver) Resolve(target string, score int, thresholds Thresholds) TrafficLightStatus {
+	bare := determineStatus(score, thresholds)
+
+	prev, ok := r.lastBand[target]
+	if !ok || thresholds.Hysteresis == nil {
+		r.lastBand[target] = bare
+		return bare
+	}
+
+	resolved := resolveWithHysteresis(prev, bare, score, thresholds)
+	r.lastBand[target] = resolved
+	return resolved
+}
+
+// FormatTransitionMatrix renders the enter/exit score cutoffs
+// thresholds.Hysteresis produces at the Red/Yellow and Yellow/Green
+// boundaries, so a validate command can show a levers.yaml's effective
+// stickiness without the reader doing the margin arithmetic by hand. It
+// returns "" when thresholds has no Hysteresis configured.
+func FormatTransitionMatrix(thresholds Thresholds) string {
+	h := thresholds.Hysteresis
+	if h == nil {
+		return ""
+	}
+
+	direction := thresholds.effectiveDirection()
+	redYellow := boundaryFor(thresholds, 0, direction)
+	yellowGreen := boundaryFor(thresholds, 1, direction)
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Red -> Yellow: enters at %g, exits at %g\n", enterCutoff(redYellow, h, direction), exitCutoff(redYellow, h, direction))
+	fmt.Fprintf(&sb, "Yellow -> Green: enters at %g, exits at %g\n", enterCutoff(yellowGreen, h, direction), exitCutoff(yellowGreen, h, direction))
+	return sb.String()
+}
@@ -0,0 +1,120 @@
+package pulse
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func thresholdsWithHysteresis(h *Hysteresis) Thresholds {
+	return Thresholds{
+		Green:      ThresholdRange{Min: 80, Max: 100},
+		Yellow:     ThresholdRange{Min: 50, Max: 79},
+		Red:        ThresholdRange{Min: 0, Max: 49},
+		Hysteresis: h,
+	}
+}
+
+func TestBandResolverWithoutHysteresisMatchesDetermineStatus(t *testing.T) {
+	thresholds := thresholdsWithHysteresis(nil)
+	resolver := NewBandResolver("")
+
+	if got := resolver.Resolve("app_sec", 85, thresholds); got != Green {
+		t.Fatalf("expected Green, got %s", got)
+	}
+	if got := resolver.Resolve("app_sec", 60, thresholds); got != Yellow {
+		t.Fatalf("expected an unconfigured Hysteresis to flip bands immediately, got %s", got)
+	}
+}
+
+func TestBandResolverHoldsBandUntilExitMarginCleared(t *testing.T) {
+	thresholds := thresholdsWithHysteresis(&Hysteresis{ExitMargin: FloatPtr(5)})
+	resolver := NewBandResolver("")
+
+	if got := resolver.Resolve("app_sec", 85, thresholds); got != Green {
+		t.Fatalf("expected Green, got %s", got)
+	}
+
+	// A dip to 78 is below the plain Green boundary (80) but still above the
+	// exit cutoff (80-5=75), so the resolver should stick to Green.
+	if got := resolver.Resolve("app_sec", 78, thresholds); got != Green {
+		t.Fatalf("expected the resolver to hold Green within the exit margin, got %s", got)
+	}
+
+	// A drop to 70 clears the exit cutoff, so the transition now takes effect.
+	if got := resolver.Resolve("app_sec", 70, thresholds); got != Yellow {
+		t.Fatalf("expected the resolver to transition to Yellow once the exit margin clears, got %s", got)
+	}
+}
+
+func TestBandResolverRequiresEnterMarginToMoveUp(t *testing.T) {
+	thresholds := thresholdsWithHysteresis(&Hysteresis{EnterMargin: FloatPtr(5)})
+	resolver := NewBandResolver("")
+
+	if got := resolver.Resolve("app_sec", 60, thresholds); got != Yellow {
+		t.Fatalf("expected Yellow, got %s", got)
+	}
+
+	// 82 clears the plain Green boundary (80) but not the enter cutoff (85).
+	if got := resolver.Resolve("app_sec", 82, thresholds); got != Yellow {
+		t.Fatalf("expected the resolver to hold Yellow until the enter margin clears, got %s", got)
+	}
+
+	if got := resolver.Resolve("app_sec", 86, thresholds); got != Green {
+		t.Fatalf("expected the resolver to transition to Green once the enter margin clears, got %s", got)
+	}
+}
+
+func TestBandResolverJournalPersistence(t *testing.T) {
+	journalPath := filepath.Join(t.TempDir(), "bands.json")
+	thresholds := thresholdsWithHysteresis(&Hysteresis{ExitMargin: FloatPtr(5)})
+
+	resolver := NewBandResolver(journalPath)
+	if err := resolver.Load(); err != nil {
+		t.Fatalf("unexpected error loading a missing journal: %v", err)
+	}
+	resolver.Resolve("app_sec", 85, thresholds)
+	if err := resolver.Save(); err != nil {
+		t.Fatalf("unexpected error saving journal: %v", err)
+	}
+
+	reloaded := NewBandResolver(journalPath)
+	if err := reloaded.Load(); err != nil {
+		t.Fatalf("unexpected error loading journal: %v", err)
+	}
+	if got := reloaded.Resolve("app_sec", 78, thresholds); got != Green {
+		t.Fatalf("expected the reloaded resolver to remember the last Green band, got %s", got)
+	}
+}
+
+func TestFormatTransitionMatrix(t *testing.T) {
+	if FormatTransitionMatrix(thresholdsWithHysteresis(nil)) != "" {
+		t.Fatal("expected no matrix for thresholds without Hysteresis")
+	}
+
+	matrix := FormatTransitionMatrix(thresholdsWithHysteresis(&Hysteresis{EnterMargin: FloatPtr(5), ExitMargin: FloatPtr(5)}))
+	if matrix == "" {
+		t.Fatal("expected a non-empty matrix")
+	}
+}
+
+func TestValidateHysteresisDetectsInconsistentMargins(t *testing.T) {
+	leversConfig := &LeversConfig{Global: Global{
+		Thresholds: thresholdsWithHysteresis(&Hysteresis{EnterMin: FloatPtr(60), ExitMin: FloatPtr(70)}),
+	}}
+
+	check := ValidateHysteresis(leversConfig)
+	if check.OK {
+		t.Fatal("expected exit_min > enter_min to fail validation")
+	}
+}
+
+func TestValidateHysteresisPassesForConsistentMargins(t *testing.T) {
+	leversConfig := &LeversConfig{Global: Global{
+		Thresholds: thresholdsWithHysteresis(&Hysteresis{EnterMargin: FloatPtr(5), ExitMargin: FloatPtr(5)}),
+	}}
+
+	check := ValidateHysteresis(leversConfig)
+	if !check.OK {
+		t.Fatalf("expected consistent margins to pass, got %+v", check)
+	}
+}
@@ -0,0 +1,241 @@
+package pulse
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ImportFormat identifies how a bulk feed of metric observations is
+// encoded for MetricsProcessor.ImportFrom.
+type ImportFormat string
+
+const (
+	// PrometheusImport parses the Prometheus text exposition format, e.g.
+	// `metric_name{category="SEC",type="KPI",id="mfa"} 0.87`.
+	PrometheusImport ImportFormat = "prom"
+	// OpenMetricsImport parses OpenMetrics, a stricter superset of the
+	// Prometheus text format that terminates with a "# EOF" line.
+	OpenMetricsImport ImportFormat = "openmetrics"
+	// CSVImport parses a "reference,value,timestamp" CSV, the same shape
+	// ParseBatch's CSVBatch format already accepts.
+	CSVImport ImportFormat = "csv"
+)
+
+// ImportError reports one row or sample line ImportFrom couldn't apply,
+// without aborting the rest of the import.
+type ImportError struct {
+	Line    int
+	Message string
+}
+
+func (e ImportError) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Message)
+}
+
+// ImportResult summarizes an ImportFrom call: how many references were
+// applied, and every row/sample that was skipped along with why.
+type ImportResult struct {
+	Applied int
+	Errors  []ImportError
+}
+
+// importSample is one parsed observation, before schema validation and
+// deduplication.
+type importSample struct {
+	Reference string
+	Value     float64
+	Timestamp time.Time
+	Line      int
+}
+
+// ImportFrom reads a bulk feed of metric observations from reader in the
+// given format, validates every reference against m's configured
+// ReferenceSchema, deduplicates by reference (keeping the sample with the
+// latest Timestamp), and applies the survivors via UpdateMetric. A bad row
+// or sample line is recorded in ImportResult.Errors and skipped rather than
+// aborting the whole import, since a single malformed line in a bulk scrape
+// feed shouldn't block every other metric in it.
+func (m *MetricsProcessor) ImportFrom(reader io.Reader, format ImportFormat) (ImportResult, error) {
+	var samples []importSample
+	var errs []ImportError
+
+	switch format {
+	case CSVImport:
+		samples, errs = parseCSVImport(reader)
+	case PrometheusImport:
+		samples, errs = parseExpositionImport(reader, false)
+	case OpenMetricsImport:
+		samples, errs = parseExpositionImport(reader, true)
+	default:
+		return ImportResult{}, fmt.Errorf("unknown import format: %s", format)
+	}
+
+	deduped := make(map[string]importSample, len(samples))
+	for _, sample := range samples {
+		refErrors, err := m.ValidateReference(sample.Reference)
+		if err != nil {
+			return ImportResult{}, err
+		}
+		if len(refErrors) > 0 {
+			errs = append(errs, ImportError{Line: sample.Line, Message: fmt.Sprintf("%s: %s", sample.Reference, joinReferenceErrors(refErrors))})
+			continue
+		}
+
+		if existing, ok := deduped[sample.Reference]; !ok || sample.Timestamp.After(existing.Timestamp) {
+			deduped[sample.Reference] = sample
+		}
+	}
+
+	references := make([]string, 0, len(deduped))
+	for reference := range deduped {
+		references = append(references, reference)
+	}
+	sort.Strings(references)
+
+	result := ImportResult{Errors: errs}
+	for _, reference := range references {
+		sample := deduped[reference]
+		if err := m.UpdateMetric(sample.Reference, sample.Value); err != nil {
+			result.Errors = append(result.Errors, ImportError{Line: sample.Line, Message: err.Error()})
+			continue
+		}
+		result.Applied++
+	}
+
+	return result, nil
+}
+
+// parseCSVImport parses a "reference,value,timestamp" CSV (timestamp
+// optional, RFC3339), recording a per-row ImportError instead of aborting
+// when a single row has a bad value or timestamp.
+func parseCSVImport(reader io.Reader) ([]importSample, []ImportError) {
+	rows, err := csv.NewReader(reader).ReadAll()
+	if err != nil {
+		return nil, []ImportError{{Line: 0, Message: fmt.Sprintf("failed to parse CSV: %v", err)}}
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	col := make(map[string]int, len(rows[0]))
+	for i, name := range rows[0] {
+		col[name] = i
+	}
+	refIdx, ok := col["reference"]
+	if !ok {
+		return nil, []ImportError{{Line: 1, Message: `CSV header missing required "reference" column`}}
+	}
+	valIdx, ok := col["value"]
+	if !ok {
+		return nil, []ImportError{{Line: 1, Message: `CSV header missing required "value" column`}}
+	}
+	tsIdx, hasTimestamp := col["timestamp"]
+
+	var samples []importSample
+	var errs []ImportError
+	for i, row := range rows[1:] {
+		line := i + 2 // +1 for the header row, +1 to make it 1-indexed
+
+		value, err := strconv.ParseFloat(row[valIdx], 64)
+		if err != nil {
+			errs = append(errs, ImportError{Line: line, Message: fmt.Sprintf("invalid value %q for reference %q: %v", row[valIdx], row[refIdx], err)})
+			continue
+		}
+
+		timestamp := time.Now()
+		if hasTimestamp && row[tsIdx] != "" {
+			ts, err := time.Parse(time.RFC3339, row[tsIdx])
+			if err != nil {
+				errs = append(errs, ImportError{Line: line, Message: fmt.Sprintf("invalid timestamp %q for reference %q: %v", row[tsIdx], row[refIdx], err)})
+				continue
+			}
+			timestamp = ts
+		}
+
+		samples = append(samples, importSample{Reference: row[refIdx], Value: value, Timestamp: timestamp, Line: line})
+	}
+	return samples, errs
+}
+
+// expositionSampleRe matches one Prometheus/OpenMetrics text exposition
+// sample line: a metric name, a "{label="value",...}" set, a float value,
+// and an optional trailing timestamp in milliseconds since the Unix epoch.
+var expositionSampleRe = regexp.MustCompile(`^([A-Za-z_:][A-Za-z0-9_:]*)\{(.*)\}\s+(\S+)(?:\s+(\S+))?$`)
+
+// expositionLabelRe matches one label="value" pair within a sample line's
+// label set.
+var expositionLabelRe = regexp.MustCompile(`([A-Za-z_][A-Za-z0-9_]*)="((?:[^"\\]|\\.)*)"`)
+
+// parseExpositionImport parses the Prometheus text exposition format (or,
+// with openMetrics true, OpenMetrics, which is the same sample-line shape
+// terminated by a "# EOF" line). Each sample's reference is built from its
+// category/type/id labels (e.g. `{category="SEC",type="KPI",id="mfa"}`
+// becomes "SEC.KPI.mfa"); comment lines ("# HELP", "# TYPE") are skipped,
+// and a sample missing one of those three labels is recorded as a per-line
+// error rather than aborting the rest of the feed.
+func parseExpositionImport(reader io.Reader, openMetrics bool) ([]importSample, []ImportError) {
+	var samples []importSample
+	var errs []ImportError
+
+	scanner := bufio.NewScanner(reader)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		if openMetrics && text == "# EOF" {
+			break
+		}
+		if strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		match := expositionSampleRe.FindStringSubmatch(text)
+		if match == nil {
+			errs = append(errs, ImportError{Line: line, Message: fmt.Sprintf("could not parse sample line: %s", text)})
+			continue
+		}
+
+		labels := make(map[string]string)
+		for _, labelMatch := range expositionLabelRe.FindAllStringSubmatch(match[2], -1) {
+			labels[labelMatch[1]] = labelMatch[2]
+		}
+
+		category, metricType, id := labels["category"], labels["type"], labels["id"]
+		if category == "" || metricType == "" || id == "" {
+			errs = append(errs, ImportError{Line: line, Message: `sample is missing one of the required "category", "type", or "id" labels`})
+			continue
+		}
+		reference := category + "." + metricType + "." + id
+
+		value, err := strconv.ParseFloat(match[3], 64)
+		if err != nil {
+			errs = append(errs, ImportError{Line: line, Message: fmt.Sprintf("invalid value %q for %s: %v", match[3], reference, err)})
+			continue
+		}
+
+		timestamp := time.Now()
+		if match[4] != "" {
+			if millis, err := strconv.ParseFloat(match[4], 64); err == nil {
+				timestamp = time.UnixMilli(int64(millis))
+			}
+		}
+
+		samples = append(samples, importSample{Reference: reference, Value: value, Timestamp: timestamp, Line: line})
+	}
+
+	if err := scanner.Err(); err != nil {
+		errs = append(errs, ImportError{Line: line, Message: fmt.Sprintf("error reading input: %v", err)})
+	}
+
+	return samples, errs
+}
@@ -0,0 +1,146 @@
+package pulse
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func importFixture() *MetricsProcessor {
+	metricsConfig := &MetricsConfig{Categories: []Category{{ID: "sec"}}}
+	leversConfig := &LeversConfig{}
+	metricsData := &MetricsData{}
+	return NewMetricsProcessor(metricsConfig, leversConfig, metricsData)
+}
+
+func TestImportFromCSV(t *testing.T) {
+	processor := importFixture()
+	csv := "reference,value,timestamp\nsec.KPI.availability,99.9,2026-01-01T00:00:00Z\n"
+
+	result, err := processor.ImportFrom(strings.NewReader(csv), CSVImport)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Applied != 1 || len(result.Errors) != 0 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	metric, err := processor.GetMetricByReference("sec.KPI.availability")
+	if err != nil {
+		t.Fatalf("expected metric to be applied: %v", err)
+	}
+	if metric.Value != 99.9 {
+		t.Errorf("expected value 99.9, got %v", metric.Value)
+	}
+}
+
+func TestImportFromCSVCollectsRowErrors(t *testing.T) {
+	processor := importFixture()
+	csv := "reference,value,timestamp\nsec.KPI.availability,not-a-number,\nsec.KPI.coverage,95,\n"
+
+	result, err := processor.ImportFrom(strings.NewReader(csv), CSVImport)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Applied != 1 {
+		t.Errorf("expected 1 applied row despite the other failing, got %d", result.Applied)
+	}
+	if len(result.Errors) != 1 {
+		t.Fatalf("expected 1 row error, got %+v", result.Errors)
+	}
+}
+
+func TestImportFromPrometheusExposition(t *testing.T) {
+	processor := importFixture()
+	body := "# HELP mfa_rate MFA adoption rate\n" +
+		"# TYPE mfa_rate gauge\n" +
+		`metric_name{category="sec",type="KPI",id="availability"} 0.87` + "\n"
+
+	result, err := processor.ImportFrom(strings.NewReader(body), PrometheusImport)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Applied != 1 || len(result.Errors) != 0 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	metric, err := processor.GetMetricByReference("sec.KPI.availability")
+	if err != nil {
+		t.Fatalf("expected metric to be applied: %v", err)
+	}
+	if metric.Value != 0.87 {
+		t.Errorf("expected value 0.87, got %v", metric.Value)
+	}
+}
+
+func TestParseExpositionImportTimestampIsMilliseconds(t *testing.T) {
+	body := `metric_name{category="sec",type="KPI",id="availability"} 0.87 1767225600000` + "\n"
+
+	samples, errs := parseExpositionImport(strings.NewReader(body), false)
+	if len(errs) != 0 {
+		t.Fatalf("unexpected errors: %+v", errs)
+	}
+	if len(samples) != 1 {
+		t.Fatalf("expected 1 sample, got %+v", samples)
+	}
+
+	want := time.UnixMilli(1767225600000)
+	if !samples[0].Timestamp.Equal(want) {
+		t.Errorf("expected the trailing field to be parsed as milliseconds (%v), got %v", want, samples[0].Timestamp)
+	}
+}
+
+func TestImportFromOpenMetricsStopsAtEOF(t *testing.T) {
+	processor := importFixture()
+	body := `metric_name{category="sec",type="KPI",id="availability"} 0.87` + "\n" +
+		"# EOF\n" +
+		`metric_name{category="sec",type="KPI",id="coverage"} 0.5` + "\n"
+
+	result, err := processor.ImportFrom(strings.NewReader(body), OpenMetricsImport)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Applied != 1 {
+		t.Fatalf("expected only the sample before \"# EOF\" to be applied, got %+v", result)
+	}
+}
+
+func TestImportFromDedupesKeepingNewestTimestamp(t *testing.T) {
+	processor := importFixture()
+	csv := "reference,value,timestamp\n" +
+		"sec.KPI.availability,90,2026-01-01T00:00:00Z\n" +
+		"sec.KPI.availability,95,2026-01-02T00:00:00Z\n"
+
+	result, err := processor.ImportFrom(strings.NewReader(csv), CSVImport)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Applied != 1 {
+		t.Fatalf("expected the duplicate reference to collapse into 1 applied metric, got %+v", result)
+	}
+	metric, err := processor.GetMetricByReference("sec.KPI.availability")
+	if err != nil {
+		t.Fatalf("expected metric to be applied: %v", err)
+	}
+	if metric.Value != 95 {
+		t.Errorf("expected the newer (2026-01-02) value 95 to win, got %v", metric.Value)
+	}
+}
+
+func TestImportFromRejectsInvalidReference(t *testing.T) {
+	processor := importFixture()
+	csv := "reference,value,timestamp\ninvalid_reference,1,\n"
+
+	result, err := processor.ImportFrom(strings.NewReader(csv), CSVImport)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Applied != 0 || len(result.Errors) != 1 {
+		t.Fatalf("expected the bad reference to be rejected without applying, got %+v", result)
+	}
+}
+
+func TestImportFromUnknownFormat(t *testing.T) {
+	processor := importFixture()
+	if _, err := processor.ImportFrom(strings.NewReader(""), ImportFormat("bogus")); err == nil {
+		t.Error("expected an error for an unknown import format")
+	}
+}
@@ -0,0 +1,73 @@
+// Package pdftest compares two gofpdf-generated PDFs for golden-file
+// testing, modeled on gofpdf's own example comparison approach: normalize
+// away run-to-run-volatile content, then diff what remains object-by-object
+// rather than byte-for-byte.
+package pdftest
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+)
+
+// volatile matches PDF content that legitimately differs between otherwise
+// identical reports: the document's CreationDate/ModDate, its trailer ID,
+// and the "Report Date: ..." cell text gofpdf embeds as a literal PDF
+// string in the content stream.
+var volatile = []*regexp.Regexp{
+	regexp.MustCompile(`/CreationDate\s*\([^)]*\)`),
+	regexp.MustCompile(`/ModDate\s*\([^)]*\)`),
+	regexp.MustCompile(`/ID\s*\[<[^>]*><[^>]*>\]`),
+	regexp.MustCompile(`\(Report Date:?[^)]*\)`),
+}
+
+// stripVolatile removes every byte range matched by volatile, so two PDFs
+// generated moments apart (or carrying different "Report Date" values)
+// compare equal as long as their layout is unchanged.
+func stripVolatile(pdf []byte) []byte {
+	for _, re := range volatile {
+		pdf = re.ReplaceAll(pdf, nil)
+	}
+	return pdf
+}
+
+// objectPattern matches one indirect PDF object: "N G obj ... endobj".
+var objectPattern = regexp.MustCompile(`(?s)(\d+) \d+ obj(.*?)endobj`)
+
+// parseObjects splits pdf into its indirect objects, keyed by object
+// number. It ignores the header, trailer, and cross-reference table, which
+// carry no layout information relevant to a golden-file diff.
+func parseObjects(pdf []byte) map[int][]byte {
+	objects := make(map[int][]byte)
+	for _, match := range objectPattern.FindAllSubmatch(pdf, -1) {
+		var num int
+		fmt.Sscanf(string(match[1]), "%d", &num)
+		objects[num] = bytes.TrimSpace(match[2])
+	}
+	return objects
+}
+
+// ComparePDFs reports a diagnostic error describing the first structural
+// difference between got and want, after both are normalized by
+// stripVolatile and parsed into their indirect objects. It returns nil when
+// got and want contain the same set of objects with byte-identical bodies.
+func ComparePDFs(got, want []byte) error {
+	gotObjects := parseObjects(stripVolatile(got))
+	wantObjects := parseObjects(stripVolatile(want))
+
+	if len(gotObjects) != len(wantObjects) {
+		return fmt.Errorf("object count mismatch: got %d objects, want %d", len(gotObjects), len(wantObjects))
+	}
+
+	for num, wantBody := range wantObjects {
+		gotBody, ok := gotObjects[num]
+		if !ok {
+			return fmt.Errorf("object %d: present in want, missing from got", num)
+		}
+		if !bytes.Equal(gotBody, wantBody) {
+			return fmt.Errorf("object %d differs:\n--- got ---\n%s\n--- want ---\n%s", num, gotBody, wantBody)
+		}
+	}
+
+	return nil
+}
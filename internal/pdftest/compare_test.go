@@ -0,0 +1,42 @@
+package pdftest
+
+import "testing"
+
+func fakePDF(creationDate, reportDate string) []byte {
+	return []byte("%PDF-1.4\n" +
+		"1 0 obj<</CreationDate (" + creationDate + ")>>endobj\n" +
+		"2 0 obj<</Contents (Report Date: " + reportDate + ")>>endobj\n" +
+		"trailer<</ID [<aaaa><bbbb>]>>\n")
+}
+
+func TestComparePDFsIgnoresVolatileContent(t *testing.T) {
+	got := fakePDF("D:20240115090000", "2024-01-15 09:00:00")
+	want := fakePDF("D:20240116103000", "2024-01-16 10:30:00")
+
+	if err := ComparePDFs(got, want); err != nil {
+		t.Errorf("expected volatile-only differences to compare equal, got: %v", err)
+	}
+}
+
+func TestComparePDFsDetectsStructuralDrift(t *testing.T) {
+	got := fakePDF("D:20240115090000", "2024-01-15 09:00:00")
+	want := []byte("%PDF-1.4\n" +
+		"1 0 obj<</CreationDate (D:20240115090000)>>endobj\n" +
+		"2 0 obj<</Contents (Report Date: 2024-01-15 09:00:00)(Extra Column)>>endobj\n" +
+		"trailer<</ID [<aaaa><bbbb>]>>\n")
+
+	if err := ComparePDFs(got, want); err == nil {
+		t.Error("expected a structural difference to be reported")
+	}
+}
+
+func TestComparePDFsDetectsObjectCountMismatch(t *testing.T) {
+	got := fakePDF("D:20240115090000", "2024-01-15 09:00:00")
+	want := []byte("%PDF-1.4\n" +
+		"1 0 obj<</CreationDate (D:20240115090000)>>endobj\n" +
+		"trailer<</ID [<aaaa><bbbb>]>>\n")
+
+	if err := ComparePDFs(got, want); err == nil {
+		t.Error("expected an object count mismatch to be reported")
+	}
+}
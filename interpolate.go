@@ -0,0 +1,121 @@
+package pulse
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+)
+
+// Resolver resolves the value for a placeholder's argument. For
+// ${ENV:VAR}, the resolver registered as "ENV" is called with "VAR".
+type Resolver func(arg string) (string, error)
+
+var placeholderPattern = regexp.MustCompile(`\$\{([A-Za-z0-9_]+):([^}]*)\}`)
+
+// Interpolator resolves ${SCHEME:ARG} placeholders embedded in YAML text
+// before it is parsed, via a registry of named Resolvers. In Strict mode
+// (the default), an unknown scheme or a resolver error fails the whole
+// Interpolate call; otherwise the placeholder is left untouched.
+type Interpolator struct {
+	resolvers map[string]Resolver
+	Strict    bool
+}
+
+// NewInterpolator creates a strict Interpolator pre-registered with the
+// built-in ENV, FILE, and CMD resolvers.
+func NewInterpolator() *Interpolator {
+	i := &Interpolator{resolvers: make(map[string]Resolver), Strict: true}
+	i.Register("ENV", resolveEnv)
+	i.Register("FILE", resolveFile)
+	i.Register("CMD", resolveCmd)
+	return i
+}
+
+// Register adds or replaces the resolver for scheme.
+func (i *Interpolator) Register(scheme string, resolver Resolver) {
+	i.resolvers[scheme] = resolver
+}
+
+// Interpolate replaces every ${SCHEME:ARG} placeholder in data with its
+// resolved value. name identifies the source (typically a file path) and is
+// used, along with the placeholder's line number, in error messages.
+func (i *Interpolator) Interpolate(name string, data []byte) ([]byte, error) {
+	matches := placeholderPattern.FindAllSubmatchIndex(data, -1)
+	if len(matches) == 0 {
+		return data, nil
+	}
+
+	var buf bytes.Buffer
+	last := 0
+
+	for _, m := range matches {
+		start, end := m[0], m[1]
+		scheme := string(data[m[2]:m[3]])
+		arg := string(data[m[4]:m[5]])
+
+		buf.Write(data[last:start])
+		last = end
+
+		resolver, ok := i.resolvers[scheme]
+		if !ok {
+			if i.Strict {
+				return nil, fmt.Errorf("%s:%d: unknown placeholder scheme %q", name, lineAt(data, start), scheme)
+			}
+			buf.Write(data[start:end])
+			continue
+		}
+
+		value, err := resolver(arg)
+		if err != nil {
+			if i.Strict {
+				return nil, fmt.Errorf("%s:%d: failed to resolve ${%s:%s}: %w", name, lineAt(data, start), scheme, arg, err)
+			}
+			buf.Write(data[start:end])
+			continue
+		}
+
+		buf.WriteString(value)
+	}
+
+	buf.Write(data[last:])
+
+	return buf.Bytes(), nil
+}
+
+// lineAt returns the 1-based line number containing byte offset in data.
+func lineAt(data []byte, offset int) int {
+	return bytes.Count(data[:offset], []byte("\n")) + 1
+}
+
+func resolveEnv(name string) (string, error) {
+	value, ok := os.LookupEnv(name)
+	if !ok {
+		return "", fmt.Errorf("environment variable %s is not set", name)
+	}
+	return value, nil
+}
+
+func resolveFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return strings.TrimRight(string(data), "\n"), nil
+}
+
+func resolveCmd(command string) (string, error) {
+	fields := strings.Fields(command)
+	if len(fields) == 0 {
+		return "", fmt.Errorf("empty command")
+	}
+
+	out, err := exec.Command(fields[0], fields[1:]...).Output()
+	if err != nil {
+		return "", fmt.Errorf("command %q failed: %w", command, err)
+	}
+
+	return strings.TrimRight(string(out), "\n"), nil
+}
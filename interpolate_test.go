@@ -0,0 +1,71 @@
+package pulse
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestInterpolatorEnv(t *testing.T) {
+	t.Setenv("PULSE_TEST_TOKEN", "s3cr3t")
+
+	i := NewInterpolator()
+	out, err := i.Interpolate("metrics.yaml", []byte(`token: ${ENV:PULSE_TEST_TOKEN}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(out) != "token: s3cr3t" {
+		t.Fatalf("expected the env var to be substituted, got %q", out)
+	}
+}
+
+func TestInterpolatorFile(t *testing.T) {
+	path := t.TempDir() + "/secret.txt"
+	if err := os.WriteFile(path, []byte("hunter2\n"), 0600); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+
+	i := NewInterpolator()
+	out, err := i.Interpolate("levers.yaml", []byte("password: ${FILE:"+path+"}"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(out) != "password: hunter2" {
+		t.Fatalf("expected the file contents to be substituted (trailing newline trimmed), got %q", out)
+	}
+}
+
+func TestInterpolatorUnresolvedEnvStrict(t *testing.T) {
+	i := NewInterpolator()
+	_, err := i.Interpolate("metrics.yaml", []byte("token: ${ENV:PULSE_DOES_NOT_EXIST}"))
+	if err == nil {
+		t.Fatal("expected an error for an undefined environment variable in strict mode")
+	}
+	if !strings.Contains(err.Error(), "metrics.yaml:1") {
+		t.Fatalf("expected the error to name the file and line, got: %v", err)
+	}
+}
+
+func TestInterpolatorUnknownSchemeNonStrict(t *testing.T) {
+	i := NewInterpolator()
+	i.Strict = false
+
+	out, err := i.Interpolate("metrics.yaml", []byte("x: ${WAT:thing}"))
+	if err != nil {
+		t.Fatalf("unexpected error in non-strict mode: %v", err)
+	}
+	if string(out) != "x: ${WAT:thing}" {
+		t.Fatalf("expected unknown placeholders to be left untouched, got %q", out)
+	}
+}
+
+func TestInterpolatorNoPlaceholders(t *testing.T) {
+	i := NewInterpolator()
+	out, err := i.Interpolate("metrics.yaml", []byte("categories: []"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(out) != "categories: []" {
+		t.Fatalf("expected plain YAML to pass through unchanged, got %q", out)
+	}
+}
@@ -0,0 +1,182 @@
+package pulse
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// jsonPathStepKind identifies the kind of a single parsed JSONPath step.
+type jsonPathStepKind int
+
+const (
+	jsonPathField jsonPathStepKind = iota
+	jsonPathWildcard
+	jsonPathIndex
+	jsonPathFilter
+)
+
+// jsonPathStep is one parsed segment of a JSONPath expression.
+type jsonPathStep struct {
+	kind        jsonPathStepKind
+	field       string // jsonPathField
+	index       int    // jsonPathIndex
+	filterField string // jsonPathFilter
+	filterValue string // jsonPathFilter
+}
+
+// parseJSONPath parses a kubectl-style JSONPath expression such as
+// `{.categories[?(@.kri_status=="red")].id}` into a sequence of steps. Only
+// the subset queryJSONPath's callers need is supported: dotted field
+// access, [*] wildcards, [N] indexing, and [?(@.field==value)] equality
+// filters.
+func parseJSONPath(expr string) ([]jsonPathStep, error) {
+	expr = strings.TrimSpace(expr)
+	expr = strings.TrimPrefix(expr, "{")
+	expr = strings.TrimSuffix(expr, "}")
+
+	var steps []jsonPathStep
+	i := 0
+	for i < len(expr) {
+		switch expr[i] {
+		case '.':
+			i++
+			start := i
+			for i < len(expr) && expr[i] != '.' && expr[i] != '[' {
+				i++
+			}
+			field := expr[start:i]
+			if field == "" {
+				return nil, fmt.Errorf("jsonpath: empty field at position %d", start)
+			}
+			steps = append(steps, jsonPathStep{kind: jsonPathField, field: field})
+		case '[':
+			end := strings.IndexByte(expr[i:], ']')
+			if end == -1 {
+				return nil, fmt.Errorf("jsonpath: unterminated [ at position %d", i)
+			}
+			inner := expr[i+1 : i+end]
+			i += end + 1
+
+			switch {
+			case inner == "*":
+				steps = append(steps, jsonPathStep{kind: jsonPathWildcard})
+			case strings.HasPrefix(inner, "?("):
+				predicate := strings.TrimSuffix(strings.TrimPrefix(inner, "?("), ")")
+				field, value, err := parseJSONPathPredicate(predicate)
+				if err != nil {
+					return nil, err
+				}
+				steps = append(steps, jsonPathStep{kind: jsonPathFilter, filterField: field, filterValue: value})
+			default:
+				idx, err := strconv.Atoi(inner)
+				if err != nil {
+					return nil, fmt.Errorf("jsonpath: invalid index %q: %w", inner, err)
+				}
+				steps = append(steps, jsonPathStep{kind: jsonPathIndex, index: idx})
+			}
+		default:
+			return nil, fmt.Errorf("jsonpath: unexpected character %q at position %d", string(expr[i]), i)
+		}
+	}
+
+	return steps, nil
+}
+
+// parseJSONPathPredicate parses `@.field=="value"` (or `@.field==value` for
+// a bareword/number) into its field and comparison value.
+func parseJSONPathPredicate(predicate string) (field, value string, err error) {
+	parts := strings.SplitN(predicate, "==", 2)
+	if len(parts) != 2 {
+		return "", "", fmt.Errorf("jsonpath: unsupported filter predicate %q (only @.field==value equality is supported)", predicate)
+	}
+
+	field = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(parts[0]), "@."))
+	value = strings.Trim(strings.TrimSpace(parts[1]), `"'`)
+	return field, value, nil
+}
+
+// evalJSONPath runs steps against data (as produced by unmarshaling JSON
+// into interface{}), returning every matching leaf value.
+func evalJSONPath(data interface{}, steps []jsonPathStep) []interface{} {
+	current := []interface{}{data}
+
+	for _, step := range steps {
+		var next []interface{}
+		switch step.kind {
+		case jsonPathField:
+			for _, v := range current {
+				if m, ok := v.(map[string]interface{}); ok {
+					if field, ok := m[step.field]; ok {
+						next = append(next, field)
+					}
+				}
+			}
+		case jsonPathWildcard:
+			for _, v := range current {
+				if s, ok := v.([]interface{}); ok {
+					next = append(next, s...)
+				}
+			}
+		case jsonPathIndex:
+			for _, v := range current {
+				if s, ok := v.([]interface{}); ok && step.index >= 0 && step.index < len(s) {
+					next = append(next, s[step.index])
+				}
+			}
+		case jsonPathFilter:
+			for _, v := range current {
+				s, ok := v.([]interface{})
+				if !ok {
+					continue
+				}
+				for _, item := range s {
+					m, ok := item.(map[string]interface{})
+					if !ok {
+						continue
+					}
+					if fmt.Sprintf("%v", m[step.filterField]) == step.filterValue {
+						next = append(next, item)
+					}
+				}
+			}
+		}
+		current = next
+	}
+
+	return current
+}
+
+// renderJSONPathResult joins matched values the way kubectl's -o jsonpath
+// does: space-separated, with no surrounding brackets or quotes.
+func renderJSONPathResult(values []interface{}) string {
+	rendered := make([]string, len(values))
+	for i, v := range values {
+		rendered[i] = fmt.Sprintf("%v", v)
+	}
+	return strings.Join(rendered, " ")
+}
+
+// queryJSONPath marshals v to JSON, re-parses it into a generic
+// map/slice/scalar tree, and evaluates expr against it, so JSONPathFormat
+// reports see the exact same field names as JSONFormat output produced from
+// the same struct.
+func queryJSONPath(v interface{}, expr string) (string, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("jsonpath: failed to marshal report: %w", err)
+	}
+
+	var data interface{}
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return "", fmt.Errorf("jsonpath: failed to decode report: %w", err)
+	}
+
+	steps, err := parseJSONPath(expr)
+	if err != nil {
+		return "", err
+	}
+
+	return renderJSONPathResult(evalJSONPath(data, steps)), nil
+}
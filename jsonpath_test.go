@@ -0,0 +1,57 @@
+package pulse
+
+import "testing"
+
+func TestQueryJSONPathFieldAndFilter(t *testing.T) {
+	data := map[string]interface{}{
+		"categories": []interface{}{
+			map[string]interface{}{"id": "app_sec", "kri_status": "red"},
+			map[string]interface{}{"id": "platform", "kri_status": "green"},
+		},
+	}
+
+	result, err := queryJSONPath(data, `{.categories[?(@.kri_status=="red")].id}`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result != "app_sec" {
+		t.Fatalf("expected app_sec, got %q", result)
+	}
+}
+
+func TestQueryJSONPathWildcardAndIndex(t *testing.T) {
+	data := map[string]interface{}{
+		"categories": []interface{}{
+			map[string]interface{}{"id": "a"},
+			map[string]interface{}{"id": "b"},
+		},
+	}
+
+	all, err := queryJSONPath(data, "{.categories[*].id}")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if all != "a b" {
+		t.Fatalf("expected \"a b\", got %q", all)
+	}
+
+	first, err := queryJSONPath(data, "{.categories[0].id}")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if first != "a" {
+		t.Fatalf("expected a, got %q", first)
+	}
+}
+
+func TestParseJSONPathRejectsUnsupportedPredicate(t *testing.T) {
+	if _, err := parseJSONPath(`{.categories[?(@.score>50)]}`); err == nil {
+		t.Fatal("expected an error for an unsupported comparison operator")
+	}
+}
+
+func TestParseJSONPathRejectsUnterminatedBracket(t *testing.T) {
+	if _, err := parseJSONPath("{.categories[0}"); err == nil {
+		t.Fatal("expected an error for an unterminated bracket")
+	}
+}
@@ -0,0 +1,200 @@
+package pulse
+
+import (
+	"fmt"
+	"strings"
+)
+
+// metric_rules.go implements MetricThresholdRule evaluation: unlike
+// CustomRule (an invariant over LeversConfig itself, see rules.go), these
+// rules check the metric *data* MetricsProcessor has loaded against a
+// floor or ceiling, the same "alert when a value crosses a threshold in
+// either direction" capability a Prometheus alerting rule gives, without
+// requiring a separate alerting stack.
+
+// MetricRuleViolation is one MetricThresholdRule whose resolved value
+// failed its bound, returned by MetricsProcessor.EvaluateRules.
+type MetricRuleViolation struct {
+	Name  string
+	Want  float64
+	Got   float64
+	Bound string // "lower" or "upper"
+}
+
+// String renders the violation the way ValidateMetricRules's
+// ValidationCheck.Details does, so callers that just want a human-readable
+// line don't need to duplicate the formatting.
+func (v MetricRuleViolation) String() string {
+	if v.Bound == "lower" {
+		return fmt.Sprintf("%s: got %.2f, want >= %.2f", v.Name, v.Got, v.Want)
+	}
+	return fmt.Sprintf("%s: got %.2f, want <= %.2f", v.Name, v.Got, v.Want)
+}
+
+// EvaluateRules resolves every configured MetricThresholdRule's Selector
+// against m's loaded metrics and checks it against Threshold, returning one
+// MetricRuleViolation per rule that failed. A rule whose selector resolves
+// to no matching metrics (other than a count aggregate, which resolves to 0)
+// makes EvaluateRules return an error rather than being silently skipped,
+// since an empty selector is almost always a typo'd reference or category.
+func (m *MetricsProcessor) EvaluateRules() ([]MetricRuleViolation, error) {
+	var violations []MetricRuleViolation
+
+	for _, rule := range m.leversConfig.MetricRules {
+		got, err := m.resolveRuleSelector(rule.Selector)
+		if err != nil {
+			return nil, fmt.Errorf("rule %q: %w", rule.Name, err)
+		}
+
+		bound := "upper"
+		if rule.LowerBound {
+			bound = "lower"
+		}
+
+		ok := got <= rule.Threshold
+		if rule.LowerBound {
+			ok = got >= rule.Threshold
+		}
+
+		if !ok {
+			violations = append(violations, MetricRuleViolation{
+				Name: rule.Name, Want: rule.Threshold, Got: got, Bound: bound,
+			})
+		}
+	}
+
+	return violations, nil
+}
+
+// resolveRuleSelector resolves a MetricThresholdRule.Selector to a single
+// float64: either the value of a single bare reference, or an aggregate
+// function (avg, sum, min, max, count) applied over every reference
+// matching a glob pattern, e.g. "avg(SEC.KPI.*)".
+func (m *MetricsProcessor) resolveRuleSelector(selector string) (float64, error) {
+	fn, pattern, isAggregate := parseAggregateSelector(selector)
+	if !isAggregate {
+		metric, err := m.GetMetricByReference(selector)
+		if err != nil {
+			return 0, err
+		}
+		return metric.Value, nil
+	}
+
+	var values []float64
+	for _, metric := range m.metricsData.Metrics {
+		if referenceMatchesGlob(metric.Reference, pattern) {
+			values = append(values, metric.Value)
+		}
+	}
+
+	if len(values) == 0 && fn != "count" {
+		return 0, fmt.Errorf("selector %q matched no metrics", selector)
+	}
+
+	return aggregateValues(fn, values)
+}
+
+// parseAggregateSelector recognizes the "fn(pattern)" selector shape
+// (avg/sum/min/max/count), returning the function name, the inner glob
+// pattern, and whether selector was in that shape at all (a bare
+// reference like "SEC.KPI.coverage" is not).
+func parseAggregateSelector(selector string) (fn, pattern string, isAggregate bool) {
+	open := strings.IndexByte(selector, '(')
+	if open == -1 || !strings.HasSuffix(selector, ")") {
+		return "", "", false
+	}
+
+	fn = selector[:open]
+	switch fn {
+	case "avg", "sum", "min", "max", "count":
+		return fn, selector[open+1 : len(selector)-1], true
+	default:
+		return "", "", false
+	}
+}
+
+// referenceMatchesGlob reports whether reference matches pattern, where
+// both are dot-separated three-part metric references and a pattern
+// segment of "*" matches any reference segment at that position.
+func referenceMatchesGlob(reference, pattern string) bool {
+	refParts := strings.Split(reference, ".")
+	patternParts := strings.Split(pattern, ".")
+	if len(refParts) != len(patternParts) {
+		return false
+	}
+	for i, p := range patternParts {
+		if p != "*" && p != refParts[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// aggregateValues applies fn (avg, sum, min, max, or count) over values.
+func aggregateValues(fn string, values []float64) (float64, error) {
+	switch fn {
+	case "count":
+		return float64(len(values)), nil
+	case "sum":
+		var total float64
+		for _, v := range values {
+			total += v
+		}
+		return total, nil
+	case "avg":
+		var total float64
+		for _, v := range values {
+			total += v
+		}
+		return total / float64(len(values)), nil
+	case "min":
+		min := values[0]
+		for _, v := range values[1:] {
+			if v < min {
+				min = v
+			}
+		}
+		return min, nil
+	case "max":
+		max := values[0]
+		for _, v := range values[1:] {
+			if v > max {
+				max = v
+			}
+		}
+		return max, nil
+	default:
+		return 0, fmt.Errorf("unknown aggregate function %q", fn)
+	}
+}
+
+// ValidateMetricRules runs MetricsProcessor.EvaluateRules and renders its
+// violations as ValidationChecks, one per configured MetricThresholdRule,
+// so "pulse validate-rules" can report them through the same
+// text/json/sarif/junit rendering every other validate command uses.
+func ValidateMetricRules(processor *MetricsProcessor) ([]ValidationCheck, error) {
+	violations, err := processor.EvaluateRules()
+	if err != nil {
+		return nil, err
+	}
+
+	byRule := make(map[string]MetricRuleViolation, len(violations))
+	for _, v := range violations {
+		byRule[v.Name] = v
+	}
+
+	checks := make([]ValidationCheck, 0, len(processor.leversConfig.MetricRules))
+	for _, rule := range processor.leversConfig.MetricRules {
+		if v, failed := byRule[rule.Name]; failed {
+			details := rule.Message
+			if details == "" {
+				details = v.String()
+			}
+			checks = append(checks, ValidationCheck{Name: rule.Name, OK: false, Details: details})
+			continue
+		}
+		checks = append(checks, ValidationCheck{Name: rule.Name, OK: true})
+	}
+
+	return checks, nil
+}
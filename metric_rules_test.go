@@ -0,0 +1,102 @@
+package pulse
+
+import "testing"
+
+func metricRulesFixture(rules []MetricThresholdRule, metrics []Metric) *MetricsProcessor {
+	metricsConfig := &MetricsConfig{Categories: []Category{{ID: "sec"}}}
+	leversConfig := &LeversConfig{MetricRules: rules}
+	metricsData := &MetricsData{Metrics: metrics}
+	return NewMetricsProcessor(metricsConfig, leversConfig, metricsData)
+}
+
+func TestEvaluateRulesBareReferenceLowerBound(t *testing.T) {
+	processor := metricRulesFixture(
+		[]MetricThresholdRule{{Name: "availability_floor", Selector: "sec.KPI.availability", Threshold: 99.9, LowerBound: true}},
+		[]Metric{{Reference: "sec.KPI.availability", Value: 99.5}},
+	)
+
+	violations, err := processor.EvaluateRules()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(violations) != 1 {
+		t.Fatalf("expected 1 violation, got %d", len(violations))
+	}
+	if violations[0].Bound != "lower" || violations[0].Got != 99.5 || violations[0].Want != 99.9 {
+		t.Errorf("unexpected violation: %+v", violations[0])
+	}
+}
+
+func TestEvaluateRulesAggregateUpperBound(t *testing.T) {
+	processor := metricRulesFixture(
+		[]MetricThresholdRule{{Name: "open_vulns_ceiling", Selector: "avg(sec.KRI.*)", Threshold: 50, LowerBound: false}},
+		[]Metric{
+			{Reference: "sec.KRI.critical", Value: 60},
+			{Reference: "sec.KRI.high", Value: 80},
+		},
+	)
+
+	violations, err := processor.EvaluateRules()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(violations) != 1 {
+		t.Fatalf("expected 1 violation, got %d", len(violations))
+	}
+	if violations[0].Got != 70 {
+		t.Errorf("expected avg(60,80)=70, got %.2f", violations[0].Got)
+	}
+}
+
+func TestEvaluateRulesPassesWithinBounds(t *testing.T) {
+	processor := metricRulesFixture(
+		[]MetricThresholdRule{{Name: "availability_floor", Selector: "sec.KPI.availability", Threshold: 99.9, LowerBound: true}},
+		[]Metric{{Reference: "sec.KPI.availability", Value: 99.99}},
+	)
+
+	violations, err := processor.EvaluateRules()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(violations) != 0 {
+		t.Fatalf("expected no violations, got %+v", violations)
+	}
+}
+
+func TestEvaluateRulesErrorsOnEmptySelector(t *testing.T) {
+	processor := metricRulesFixture(
+		[]MetricThresholdRule{{Name: "missing", Selector: "sec.KRI.nonexistent", Threshold: 1, LowerBound: true}},
+		nil,
+	)
+
+	if _, err := processor.EvaluateRules(); err == nil {
+		t.Error("expected an error when the selector resolves to no metric")
+	}
+}
+
+func TestValidateMetricRulesRendersOneCheckPerRule(t *testing.T) {
+	processor := metricRulesFixture(
+		[]MetricThresholdRule{
+			{Name: "availability_floor", Selector: "sec.KPI.availability", Threshold: 99.9, LowerBound: true, Message: "availability dropped"},
+			{Name: "coverage_floor", Selector: "sec.KPI.coverage", Threshold: 80, LowerBound: true},
+		},
+		[]Metric{
+			{Reference: "sec.KPI.availability", Value: 99.5},
+			{Reference: "sec.KPI.coverage", Value: 95},
+		},
+	)
+
+	checks, err := ValidateMetricRules(processor)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(checks) != 2 {
+		t.Fatalf("expected 2 checks, got %d", len(checks))
+	}
+	if checks[0].OK || checks[0].Details != "availability dropped" {
+		t.Errorf("expected a failing check with the rule's Message, got %+v", checks[0])
+	}
+	if !checks[1].OK {
+		t.Errorf("expected coverage_floor to pass, got %+v", checks[1])
+	}
+}
@@ -1,17 +1,22 @@
 package pulse
 
 import (
+	"errors"
 	"fmt"
+	"sort"
 	"strings"
+	"sync/atomic"
 	"time"
-	"unicode"
 )
 
 // MetricsProcessor handles processing and analysis of metrics
 type MetricsProcessor struct {
-	metricsConfig *MetricsConfig
-	leversConfig  *LeversConfig
-	metricsData   *MetricsData
+	metricsConfig      *MetricsConfig
+	leversConfig       *LeversConfig
+	metricsData        *MetricsData
+	source             MetricsSource
+	history            *HistoryStore
+	referenceValidator *ReferenceValidator
 }
 
 // NewMetricsProcessor creates a new MetricsProcessor with the specified configurations
@@ -20,9 +25,88 @@ func NewMetricsProcessor(metricsConfig *MetricsConfig, leversConfig *LeversConfi
 		metricsConfig: metricsConfig,
 		leversConfig:  leversConfig,
 		metricsData:   metricsData,
+		source:        NewPromQLSource(),
 	}
 }
 
+// SetSource overrides the MetricsSource used to resolve live metric values,
+// primarily for testing or to plug in a different data source implementation.
+func (m *MetricsProcessor) SetSource(source MetricsSource) {
+	m.source = source
+}
+
+// SetHistoryStore attaches a HistoryStore that UpdateMetric will append to.
+// History recording is disabled until a store is set.
+func (m *MetricsProcessor) SetHistoryStore(history *HistoryStore) {
+	m.history = history
+}
+
+// History returns the HistoryStore attached via SetHistoryStore, or nil if
+// none has been configured.
+func (m *MetricsProcessor) History() *HistoryStore {
+	return m.history
+}
+
+// RefreshLiveMetrics re-resolves the value of every KPI/KRI that declares a
+// Query/Endpoint pair against its MetricsSource, updating the in-memory
+// metric (without persisting it). A metric is skipped if it has no query
+// configured.
+func (m *MetricsProcessor) RefreshLiveMetrics() error {
+	for _, category := range m.metricsConfig.Categories {
+		for _, kpi := range category.KPIs {
+			if err := m.refreshLiveMetric(category.ID+".KPI."+kpi.ID, kpi.Endpoint, kpi.Query, kpi.RequireSamples); err != nil {
+				return err
+			}
+		}
+		for _, kri := range category.KRIs {
+			if err := m.refreshLiveMetric(category.ID+".KRI."+kri.ID, kri.Endpoint, kri.Query, kri.RequireSamples); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// refreshLiveMetric resolves a single metric's live value and either updates
+// the existing in-memory metric or appends a new one. If the query resolves
+// to an empty vector and requireSamples is false, the metric is left
+// unchanged rather than failing the run; requireSamples true propagates the
+// empty-vector error like any other fetch failure.
+func (m *MetricsProcessor) refreshLiveMetric(reference, endpoint, query string, requireSamples bool) error {
+	if query == "" {
+		return nil
+	}
+	if m.source == nil {
+		return fmt.Errorf("no metrics source configured for %s", reference)
+	}
+
+	value, err := m.source.FetchValue(endpoint, query)
+	if err != nil {
+		var emptyVector *EmptyVectorError
+		if errors.As(err, &emptyVector) && !requireSamples {
+			return nil
+		}
+		return fmt.Errorf("failed to fetch live value for %s: %w", reference, err)
+	}
+
+	for i, metric := range m.metricsData.Metrics {
+		if metric.Reference == reference {
+			m.metricsData.Metrics[i].Value = value
+			m.metricsData.Metrics[i].Timestamp = time.Now()
+			return nil
+		}
+	}
+
+	parts := strings.Split(reference, ".")
+	m.metricsData.Metrics = append(m.metricsData.Metrics, Metric{
+		Reference:  reference,
+		Value:      value,
+		Timestamp:  time.Now(),
+		SourceFile: parts[0] + ".yaml",
+	})
+	return nil
+}
+
 // GetMetricByReference returns a metric by its reference
 func (m *MetricsProcessor) GetMetricByReference(reference string) (*Metric, error) {
 	for _, metric := range m.metricsData.Metrics {
@@ -33,16 +117,28 @@ func (m *MetricsProcessor) GetMetricByReference(reference string) (*Metric, erro
 	return nil, fmt.Errorf("metric not found: %s", reference)
 }
 
-// UpdateMetric updates a metric value or adds a new metric if it doesn't exist
+// UpdateMetric updates a metric value or adds a new metric if it doesn't
+// exist. It is equivalent to UpdateMetricWithAuthor with an empty author.
 func (m *MetricsProcessor) UpdateMetric(reference string, value float64) error {
-	// Validate the reference format
-	if !isValidReference(reference) {
-		return fmt.Errorf("invalid metric reference format: %s", reference)
+	return m.UpdateMetricWithAuthor(reference, value, "")
+}
+
+// UpdateMetricWithAuthor behaves like UpdateMetric, but additionally records
+// author on the HistoryPoint appended to the attached HistoryStore (if any),
+// so a trend query can attribute who made a given observation.
+func (m *MetricsProcessor) UpdateMetricWithAuthor(reference string, value float64, author string) error {
+	// Validate the reference format against the configured (or default) schema
+	refErrors, err := m.ValidateReference(reference)
+	if err != nil {
+		return fmt.Errorf("invalid reference_schema configuration: %w", err)
+	}
+	if len(refErrors) > 0 {
+		return fmt.Errorf("invalid metric reference %q: %s", reference, joinReferenceErrors(refErrors))
 	}
 
 	// Extract category ID from reference for file organization
 	parts := strings.Split(reference, ".")
-	categoryID := parts[0]
+	categoryID := parts[len(parts)-3]
 	sourceFile := categoryID + ".yaml"
 
 	// Check if the metric exists
@@ -72,6 +168,15 @@ func (m *MetricsProcessor) UpdateMetric(reference string, value float64) error {
 		})
 	}
 
+	if m.history != nil {
+		point := HistoryPoint{Reference: reference, Value: value, Timestamp: time.Now(), Author: author}
+		if err := m.history.Append(point); err != nil {
+			return fmt.Errorf("failed to record metric history: %w", err)
+		}
+	}
+
+	atomic.AddInt64(&metricUpdatesTotal, 1)
+
 	return nil
 }
 
@@ -94,6 +199,40 @@ func (m *MetricsProcessor) GetMetricsByCategory(categoryID string) []Metric {
 	return categoryMetrics
 }
 
+// GetMetricsByScope returns every metric tagged with scope and key (e.g.
+// scope=ScopeTeam, key="payments"). Metrics with no Scope set are not
+// matched by any (scope, key) pair.
+func (m *MetricsProcessor) GetMetricsByScope(scope MetricScope, key string) []Metric {
+	var scoped []Metric
+
+	for _, metric := range m.metricsData.Metrics {
+		if metric.Scope == scope && metric.ScopeKey == key {
+			scoped = append(scoped, metric)
+		}
+	}
+
+	return scoped
+}
+
+// ScopeKeys returns the distinct scope keys recorded for scope (e.g.
+// ["payments", "platform"] for scope=ScopeTeam), sorted for deterministic
+// iteration.
+func (m *MetricsProcessor) ScopeKeys(scope MetricScope) []string {
+	seen := make(map[string]bool)
+	var keys []string
+
+	for _, metric := range m.metricsData.Metrics {
+		if metric.Scope != scope || seen[metric.ScopeKey] {
+			continue
+		}
+		seen[metric.ScopeKey] = true
+		keys = append(keys, metric.ScopeKey)
+	}
+
+	sort.Strings(keys)
+	return keys
+}
+
 // GetCategoryByID returns a category by its ID
 func (m *MetricsProcessor) GetCategoryByID(categoryID string) (*Category, error) {
 	for _, category := range m.metricsConfig.Categories {
@@ -110,63 +249,79 @@ func (m *MetricsProcessor) GetAllCategories() []Category {
 }
 
 // isValidReference checks if a metric reference has the correct format
+// under the built-in (schema-less) scheme. It exists for callers without
+// access to a MetricsProcessor's configured ReferenceSchema; prefer
+// MetricsProcessor.ValidateReference where one is available, since it
+// honors custom middle tokens and team segments.
 func isValidReference(reference string) bool {
-	// Check for empty or overly long references
-	if reference == "" || len(reference) > 100 {
+	validator, err := NewReferenceValidator(nil)
+	if err != nil {
 		return false
 	}
+	return len(validator.Validate(reference)) == 0
+}
 
-	// Check for invalid characters
-	for _, char := range reference {
-		if !unicode.IsLetter(char) && !unicode.IsDigit(char) && char != '.' && char != '_' && char != '-' {
-			return false
-		}
+// joinReferenceErrors renders a []ReferenceError as a single semicolon
+// separated string, for embedding in an error returned to a caller that
+// doesn't need the structured detail (e.g. UpdateMetric's error contract).
+func joinReferenceErrors(errs []ReferenceError) string {
+	messages := make([]string, len(errs))
+	for i, e := range errs {
+		messages[i] = e.Message
 	}
+	return strings.Join(messages, "; ")
+}
 
-	parts := strings.Split(reference, ".")
-	if len(parts) != 3 {
-		return false
+// ReferenceValidator returns the ReferenceValidator built from m's
+// configured LeversConfig.ReferenceSchema (or the built-in KPI/KRI scheme
+// if none is configured), building and caching it on first use.
+func (m *MetricsProcessor) ReferenceValidator() (*ReferenceValidator, error) {
+	if m.referenceValidator != nil {
+		return m.referenceValidator, nil
 	}
-
-	// Check if each part is not empty
-	for _, part := range parts {
-		if part == "" {
-			return false
-		}
+	validator, err := NewReferenceValidator(m.leversConfig.ReferenceSchema)
+	if err != nil {
+		return nil, err
 	}
+	m.referenceValidator = validator
+	return validator, nil
+}
 
-	// Check if the second part is KPI or KRI
-	if parts[1] != "KPI" && parts[1] != "KRI" {
-		return false
+// ValidateReference checks reference against m's configured
+// ReferenceSchema, returning every violation found (see
+// ReferenceValidator.Validate) rather than a single pass/fail bool.
+func (m *MetricsProcessor) ValidateReference(reference string) ([]ReferenceError, error) {
+	validator, err := m.ReferenceValidator()
+	if err != nil {
+		return nil, err
 	}
-
-	return true
+	return validator.Validate(reference), nil
 }
 
-// GetMetricType returns whether a metric is a KPI or KRI
+// GetMetricType returns the reference's type segment (e.g. "KPI", "KRI",
+// or a user-defined token like "OKR"), the second-to-last dot-separated
+// segment. It doesn't check the token against a ReferenceSchema's allowed
+// middle tokens; use MetricsProcessor.ValidateReference before trusting an
+// externally-supplied reference.
 func GetMetricType(reference string) (string, error) {
 	parts := strings.Split(reference, ".")
-	if len(parts) != 3 {
+	if len(parts) < 3 {
 		return "", fmt.Errorf("invalid metric reference format: %s", reference)
 	}
 
-	if parts[1] != "KPI" && parts[1] != "KRI" {
-		return "", fmt.Errorf("invalid metric type: %s", parts[1])
-	}
-
-	return parts[1], nil
+	return parts[len(parts)-2], nil
 }
 
 // GetMetricDefinition returns the KPI or KRI definition for a metric
 func (m *MetricsProcessor) GetMetricDefinition(reference string) (interface{}, error) {
 	parts := strings.Split(reference, ".")
-	if len(parts) != 3 {
+	if len(parts) < 3 {
 		return nil, fmt.Errorf("invalid metric reference format: %s", reference)
 	}
 
-	categoryID := parts[0]
-	metricType := parts[1]
-	metricID := parts[2]
+	categoryID := parts[len(parts)-3]
+	metricType := parts[len(parts)-2]
+	metricID := parts[len(parts)-1]
 
 	category, err := m.GetCategoryByID(categoryID)
 	if err != nil {
@@ -0,0 +1,54 @@
+package pulse
+
+import "testing"
+
+// fakeEmptyVectorSource always reports an empty Prometheus vector, to
+// exercise RefreshLiveMetrics' RequireSamples handling without a live
+// HTTP server.
+type fakeEmptyVectorSource struct{}
+
+func (fakeEmptyVectorSource) FetchValue(endpoint, query string) (float64, error) {
+	return 0, &EmptyVectorError{Query: query}
+}
+
+func liveMetricsFixture(requireSamples bool) (*MetricsProcessor, *MetricsData) {
+	metricsConfig := &MetricsConfig{
+		Categories: []Category{
+			{
+				ID: "app_sec",
+				KPIs: []KPI{
+					{
+						ID:             "coverage",
+						Query:          "avg(coverage_ratio)",
+						Endpoint:       "http://prometheus.invalid",
+						RequireSamples: requireSamples,
+						ScoringBands:   []ScoringBand{{Min: FloatPtr(0), Score: 100}},
+					},
+				},
+			},
+		},
+	}
+	metricsData := &MetricsData{Metrics: []Metric{{Reference: "app_sec.KPI.coverage", Value: 50}}}
+	processor := NewMetricsProcessor(metricsConfig, &LeversConfig{}, metricsData)
+	processor.SetSource(fakeEmptyVectorSource{})
+	return processor, metricsData
+}
+
+func TestRefreshLiveMetricsKeepsLastValueWhenSamplesNotRequired(t *testing.T) {
+	processor, metricsData := liveMetricsFixture(false)
+
+	if err := processor.RefreshLiveMetrics(); err != nil {
+		t.Fatalf("expected an empty vector to be tolerated, got error: %v", err)
+	}
+	if metricsData.Metrics[0].Value != 50 {
+		t.Fatalf("expected the last known value to be kept, got %v", metricsData.Metrics[0].Value)
+	}
+}
+
+func TestRefreshLiveMetricsFailsWhenSamplesRequired(t *testing.T) {
+	processor, _ := liveMetricsFixture(true)
+
+	if err := processor.RefreshLiveMetrics(); err == nil {
+		t.Fatal("expected an empty vector to fail the run when RequireSamples is set")
+	}
+}
@@ -19,7 +19,7 @@ func TestMetricsProcessor(t *testing.T) {
 						Name:        "Test KPI",
 						Description: "Test KPI description",
 						Unit:        "count",
-						Target:      10,
+						Target:      FloatPtr(10),
 						ScoringBands: []ScoringBand{
 							{Max: FloatPtr(5), Score: 95},
 							{Min: FloatPtr(5), Max: FloatPtr(10), Score: 85},
@@ -35,7 +35,7 @@ func TestMetricsProcessor(t *testing.T) {
 						Name:        "Test KRI",
 						Description: "Test KRI description",
 						Unit:        "count",
-						Threshold:   5,
+						Target:      FloatPtr(5),
 						ScoringBands: []ScoringBand{
 							{Max: FloatPtr(0), Score: 95},
 							{Min: FloatPtr(0), Max: FloatPtr(2), Score: 85},
@@ -52,9 +52,9 @@ func TestMetricsProcessor(t *testing.T) {
 	leversConfig := &LeversConfig{
 		Global: Global{
 			Thresholds: Thresholds{
-				Green:  80,
-				Yellow: 60,
-				Red:    0,
+				Green:  ThresholdRange{Min: 80, Max: 100},
+				Yellow: ThresholdRange{Min: 60, Max: 79},
+				Red:    ThresholdRange{Min: 0, Max: 59},
 			},
 		},
 		Weights: Weights{
@@ -0,0 +1,89 @@
+package pulse
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// NagiosRange is a Nagios-plugin style threshold range (see the "Range"
+// section of the Nagios Plugin Development Guidelines):
+//
+//	"10"     -> alert outside {0..10}
+//	"10:"    -> alert outside {10..+inf}
+//	"~:10"   -> alert outside {-inf..10}
+//	"10:20"  -> alert outside {10..20}
+//	"@10:20" -> alert inside {10..20} (a leading "@" inverts the test)
+//
+// A nil Lower or Upper means that side is unbounded.
+type NagiosRange struct {
+	Lower, Upper *float64
+	Invert       bool
+}
+
+// ParseNagiosRange parses spec into a NagiosRange, returning an error for a
+// malformed range or one whose start exceeds its end.
+func ParseNagiosRange(spec string) (NagiosRange, error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "" {
+		return NagiosRange{}, fmt.Errorf("nagios range: empty spec")
+	}
+
+	var r NagiosRange
+	if strings.HasPrefix(spec, "@") {
+		r.Invert = true
+		spec = spec[1:]
+	}
+
+	if !strings.Contains(spec, ":") {
+		end, err := strconv.ParseFloat(spec, 64)
+		if err != nil {
+			return NagiosRange{}, fmt.Errorf("nagios range: invalid bound %q: %w", spec, err)
+		}
+		lower := 0.0
+		r.Lower, r.Upper = &lower, &end
+		return r, nil
+	}
+
+	parts := strings.SplitN(spec, ":", 2)
+	start, end := parts[0], parts[1]
+
+	if start != "" && start != "~" {
+		v, err := strconv.ParseFloat(start, 64)
+		if err != nil {
+			return NagiosRange{}, fmt.Errorf("nagios range: invalid start %q: %w", start, err)
+		}
+		r.Lower = &v
+	}
+
+	if end != "" {
+		v, err := strconv.ParseFloat(end, 64)
+		if err != nil {
+			return NagiosRange{}, fmt.Errorf("nagios range: invalid end %q: %w", end, err)
+		}
+		r.Upper = &v
+	}
+
+	if r.Lower != nil && r.Upper != nil && *r.Lower > *r.Upper {
+		return NagiosRange{}, fmt.Errorf("nagios range: start (%g) must be <= end (%g)", *r.Lower, *r.Upper)
+	}
+
+	return r, nil
+}
+
+// Inside reports whether value falls within r's [Lower,Upper] bounds,
+// ignoring Invert: it's the raw numeric membership test a leading "@"
+// flips the sense of for alerting, but that ThresholdRange.Contains still
+// needs on its own to report band membership rather than alert status.
+func (r NagiosRange) Inside(value float64) bool {
+	return (r.Lower == nil || value >= *r.Lower) && (r.Upper == nil || value <= *r.Upper)
+}
+
+// Alerts reports whether value falls in r's alerting region: outside
+// [Lower,Upper] normally, or inside it when Invert (a leading "@") is set.
+func (r NagiosRange) Alerts(value float64) bool {
+	if r.Invert {
+		return r.Inside(value)
+	}
+	return !r.Inside(value)
+}
@@ -0,0 +1,95 @@
+package pulse
+
+import "testing"
+
+func TestParseNagiosRangeForms(t *testing.T) {
+	cases := []struct {
+		spec       string
+		value      float64
+		wantAlerts bool
+	}{
+		{"10", 5, false},
+		{"10", 11, true},
+		{"10:", 15, false},
+		{"10:", 5, true},
+		{"10:20", 15, false},
+		{"10:20", 25, true},
+		{"@10:20", 15, true},
+		{"@10:20", 25, false},
+	}
+
+	for _, c := range cases {
+		r, err := ParseNagiosRange(c.spec)
+		if err != nil {
+			t.Fatalf("ParseNagiosRange(%q): unexpected error: %v", c.spec, err)
+		}
+		if got := r.Alerts(c.value); got != c.wantAlerts {
+			t.Fatalf("ParseNagiosRange(%q).Alerts(%g) = %v, want %v", c.spec, c.value, got, c.wantAlerts)
+		}
+	}
+}
+
+func TestParseNagiosRangeOpenEnded(t *testing.T) {
+	r, err := ParseNagiosRange("~:10")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if r.Lower != nil {
+		t.Fatalf("expected a nil lower bound for ~:10, got %v", *r.Lower)
+	}
+	if !r.Alerts(11) {
+		t.Fatal("expected 11 to alert for ~:10")
+	}
+	if r.Alerts(10) {
+		t.Fatal("expected 10 not to alert for ~:10")
+	}
+}
+
+func TestParseNagiosRangeRejectsInvertedBounds(t *testing.T) {
+	if _, err := ParseNagiosRange("20:10"); err == nil {
+		t.Fatal("expected an error for a range whose start exceeds its end")
+	}
+}
+
+func TestParseNagiosRangeRejectsGarbage(t *testing.T) {
+	if _, err := ParseNagiosRange("not-a-range"); err == nil {
+		t.Fatal("expected an error for an unparseable range")
+	}
+}
+
+func TestThresholdRangeContainsHonorsNagiosRange(t *testing.T) {
+	band := ThresholdRange{Range: "80:"}
+	if !band.Contains(85) {
+		t.Fatal("expected 85 to be contained in range 80:")
+	}
+	if band.Contains(70) {
+		t.Fatal("expected 70 not to be contained in range 80:")
+	}
+}
+
+func TestThresholdRangeContainsHonorsInvertedNagiosRange(t *testing.T) {
+	band := ThresholdRange{Range: "@0:20"}
+	if !band.Contains(10) {
+		t.Fatal("expected 10 to be contained in inverted range @0:20")
+	}
+	if band.Contains(50) {
+		t.Fatal("expected 50 not to be contained in inverted range @0:20")
+	}
+}
+
+func TestValidateThresholdsCatchesInvalidRangeSyntax(t *testing.T) {
+	leversConfig := &LeversConfig{
+		Global: Global{Thresholds: Thresholds{
+			Green:  ThresholdRange{Range: "not-a-range"},
+			Yellow: ThresholdRange{Min: 50, Max: 79},
+			Red:    ThresholdRange{Min: 0, Max: 49},
+		}},
+	}
+
+	checks := ValidateThresholds(leversConfig)
+	for _, check := range checks {
+		if check.Name == "threshold_range_syntax" && check.OK {
+			t.Fatal("expected threshold_range_syntax to fail for an unparseable range")
+		}
+	}
+}
@@ -0,0 +1,353 @@
+package pulse
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/smtp"
+	"os"
+	"time"
+)
+
+// NotificationEvent describes a single status transition - either the
+// overall score or one category - for a Notifier to render. Category is
+// nil for an overall-scope event.
+type NotificationEvent struct {
+	Scope      string // "overall" or a category ID
+	OldStatus  TrafficLightStatus
+	NewStatus  TrafficLightStatus
+	Overall    OverallScore
+	Category   *CategoryScore // nil when Scope == "overall"
+	OccurredAt time.Time
+}
+
+// String renders a single-line human-readable summary of the event,
+// suitable as the message body for notifiers that don't need anything
+// richer (Slack, Teams, generic HTTP, email subject lines).
+func (e NotificationEvent) String() string {
+	return fmt.Sprintf("pulse: %s flipped %s -> %s (overall score %d)", e.Scope, e.OldStatus, e.NewStatus, e.Overall.Score)
+}
+
+// Notifier dispatches a NotificationEvent to some external channel. Unlike
+// Sink, which delivers a single metric-level Alert, a Notifier is driven by
+// category/overall status transitions detected by NotificationEngine.
+type Notifier interface {
+	Notify(event NotificationEvent) error
+}
+
+// SlackNotifier posts a NotificationEvent to a Slack incoming webhook.
+type SlackNotifier struct {
+	WebhookURL string
+	HTTPClient *http.Client
+}
+
+// NewSlackNotifier creates a SlackNotifier with a sane request timeout.
+func NewSlackNotifier(webhookURL string) *SlackNotifier {
+	return &SlackNotifier{WebhookURL: webhookURL, HTTPClient: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// Notify implements Notifier.
+func (n *SlackNotifier) Notify(event NotificationEvent) error {
+	body, err := json.Marshal(struct {
+		Text string `json:"text"`
+	}{Text: event.String()})
+	if err != nil {
+		return fmt.Errorf("slack notifier: failed to marshal event: %w", err)
+	}
+	return postJSON(n.HTTPClient, n.WebhookURL, body, "slack notifier")
+}
+
+// TeamsNotifier posts a NotificationEvent to a Microsoft Teams incoming
+// webhook as a legacy MessageCard.
+type TeamsNotifier struct {
+	WebhookURL string
+	HTTPClient *http.Client
+}
+
+// NewTeamsNotifier creates a TeamsNotifier with a sane request timeout.
+func NewTeamsNotifier(webhookURL string) *TeamsNotifier {
+	return &TeamsNotifier{WebhookURL: webhookURL, HTTPClient: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// Notify implements Notifier.
+func (n *TeamsNotifier) Notify(event NotificationEvent) error {
+	body, err := json.Marshal(struct {
+		Type    string `json:"@type"`
+		Summary string `json:"summary"`
+		Text    string `json:"text"`
+	}{Type: "MessageCard", Summary: "pulse status change", Text: event.String()})
+	if err != nil {
+		return fmt.Errorf("teams notifier: failed to marshal event: %w", err)
+	}
+	return postJSON(n.HTTPClient, n.WebhookURL, body, "teams notifier")
+}
+
+// PagerDutyNotifier triggers a PagerDuty Events API v2 incident for a
+// NotificationEvent.
+type PagerDutyNotifier struct {
+	RoutingKey string
+	HTTPClient *http.Client
+}
+
+// pagerDutyEventsURL is the PagerDuty Events API v2 endpoint.
+const pagerDutyEventsURL = "https://events.pagerduty.com/v2/enqueue"
+
+// NewPagerDutyNotifier creates a PagerDutyNotifier with a sane request timeout.
+func NewPagerDutyNotifier(routingKey string) *PagerDutyNotifier {
+	return &PagerDutyNotifier{RoutingKey: routingKey, HTTPClient: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// Notify implements Notifier.
+func (n *PagerDutyNotifier) Notify(event NotificationEvent) error {
+	severity := "warning"
+	if event.NewStatus == Red {
+		severity = "critical"
+	}
+
+	body, err := json.Marshal(struct {
+		RoutingKey  string `json:"routing_key"`
+		EventAction string `json:"event_action"`
+		Payload     struct {
+			Summary  string `json:"summary"`
+			Source   string `json:"source"`
+			Severity string `json:"severity"`
+		} `json:"payload"`
+	}{
+		RoutingKey:  n.RoutingKey,
+		EventAction: "trigger",
+		Payload: struct {
+			Summary  string `json:"summary"`
+			Source   string `json:"source"`
+			Severity string `json:"severity"`
+		}{Summary: event.String(), Source: "pulse", Severity: severity},
+	})
+	if err != nil {
+		return fmt.Errorf("pagerduty notifier: failed to marshal event: %w", err)
+	}
+	return postJSON(n.HTTPClient, pagerDutyEventsURL, body, "pagerduty notifier")
+}
+
+// HTTPNotifier POSTs a NotificationEvent as JSON to an arbitrary URL, for
+// channels without a dedicated Notifier implementation.
+type HTTPNotifier struct {
+	URL        string
+	HTTPClient *http.Client
+}
+
+// NewHTTPNotifier creates an HTTPNotifier with a sane request timeout.
+func NewHTTPNotifier(url string) *HTTPNotifier {
+	return &HTTPNotifier{URL: url, HTTPClient: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// Notify implements Notifier.
+func (n *HTTPNotifier) Notify(event NotificationEvent) error {
+	body, err := json.Marshal(struct {
+		Scope     string `json:"scope"`
+		OldStatus string `json:"old_status"`
+		NewStatus string `json:"new_status"`
+		Score     int    `json:"score"`
+		Message   string `json:"message"`
+	}{
+		Scope: event.Scope, OldStatus: string(event.OldStatus), NewStatus: string(event.NewStatus),
+		Score: event.Overall.Score, Message: event.String(),
+	})
+	if err != nil {
+		return fmt.Errorf("http notifier: failed to marshal event: %w", err)
+	}
+	return postJSON(n.HTTPClient, n.URL, body, "http notifier")
+}
+
+// postJSON is the shared POST-and-check-status body for SlackNotifier,
+// TeamsNotifier, PagerDutyNotifier, and HTTPNotifier.
+func postJSON(client *http.Client, url string, body []byte, label string) error {
+	resp, err := client.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("%s: request failed: %w", label, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("%s: unexpected status %d", label, resp.StatusCode)
+	}
+	return nil
+}
+
+// EmailNotifier emails a NotificationEvent via SMTP.
+type EmailNotifier struct {
+	SMTPHost string
+	SMTPPort int
+	From     string
+	To       []string
+	Auth     smtp.Auth
+}
+
+// NewEmailNotifier creates an EmailNotifier. If username and password are
+// both non-empty, it authenticates with smtp.PlainAuth against host.
+func NewEmailNotifier(host string, port int, username, password, from string, to []string) *EmailNotifier {
+	var auth smtp.Auth
+	if username != "" && password != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+	return &EmailNotifier{SMTPHost: host, SMTPPort: port, From: from, To: to, Auth: auth}
+}
+
+// Notify implements Notifier.
+func (n *EmailNotifier) Notify(event NotificationEvent) error {
+	addr := fmt.Sprintf("%s:%d", n.SMTPHost, n.SMTPPort)
+	subject := fmt.Sprintf("[pulse] %s is now %s", event.Scope, event.NewStatus)
+	msg := fmt.Sprintf("To: %s\r\nSubject: %s\r\n\r\n%s\r\n", joinRecipients(n.To), subject, event.String())
+
+	if err := smtp.SendMail(addr, n.Auth, n.From, n.To, []byte(msg)); err != nil {
+		return fmt.Errorf("email notifier: failed to send mail: %w", err)
+	}
+	return nil
+}
+
+// joinRecipients renders to as a comma-separated header value.
+func joinRecipients(to []string) string {
+	joined := ""
+	for i, addr := range to {
+		if i > 0 {
+			joined += ", "
+		}
+		joined += addr
+	}
+	return joined
+}
+
+// NotifierFromConfig builds the Notifier described by cfg.
+func NotifierFromConfig(cfg NotifierConfig) (Notifier, error) {
+	switch cfg.Type {
+	case "slack":
+		return NewSlackNotifier(cfg.WebhookURL), nil
+	case "teams":
+		return NewTeamsNotifier(cfg.WebhookURL), nil
+	case "pagerduty":
+		return NewPagerDutyNotifier(cfg.RoutingKey), nil
+	case "http":
+		return NewHTTPNotifier(cfg.URL), nil
+	case "email":
+		return NewEmailNotifier(cfg.SMTPHost, cfg.SMTPPort, cfg.Username, cfg.Password, cfg.From, cfg.To), nil
+	default:
+		return nil, fmt.Errorf("unknown notifier type: %q", cfg.Type)
+	}
+}
+
+// NotificationEngine watches successive OverallScore computations and
+// dispatches NotificationEvents only on status transitions (overall or
+// per-category), routed to channels through a set of NotificationRules.
+type NotificationEngine struct {
+	rules      []NotificationRule
+	notifiers  map[string]Notifier
+	lastStatus map[string]TrafficLightStatus
+}
+
+// NewNotificationEngine creates a NotificationEngine that routes
+// transitions through rules to the named notifiers.
+func NewNotificationEngine(rules []NotificationRule, notifiers map[string]Notifier) *NotificationEngine {
+	return &NotificationEngine{
+		rules:      rules,
+		notifiers:  notifiers,
+		lastStatus: make(map[string]TrafficLightStatus),
+	}
+}
+
+// notificationEngineState is the on-disk representation of a
+// NotificationEngine's last-seen statuses, persisted so transitions are
+// detected across separate CLI invocations.
+type notificationEngineState struct {
+	LastStatus map[string]TrafficLightStatus `json:"last_status"`
+}
+
+// LoadState restores last-seen statuses previously persisted by SaveState.
+// A missing file is not an error; the engine simply starts with no prior
+// state, so its first Evaluate raises no transitions.
+func (e *NotificationEngine) LoadState(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to read notification engine state: %w", err)
+	}
+
+	var state notificationEngineState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return fmt.Errorf("failed to parse notification engine state: %w", err)
+	}
+	if state.LastStatus != nil {
+		e.lastStatus = state.LastStatus
+	}
+	return nil
+}
+
+// SaveState persists the engine's last-seen statuses to path.
+func (e *NotificationEngine) SaveState(path string) error {
+	data, err := json.MarshalIndent(notificationEngineState{LastStatus: e.lastStatus}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal notification engine state: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write notification engine state: %w", err)
+	}
+	return nil
+}
+
+// Evaluate compares overall's status and each category's status against
+// the last-seen status recorded for that scope, and dispatches a
+// NotificationEvent through every channel a matching NotificationRule
+// names for each scope whose status changed. It returns the events raised.
+func (e *NotificationEngine) Evaluate(overall *OverallScore) ([]NotificationEvent, error) {
+	now := time.Now()
+	var events []NotificationEvent
+
+	dispatch := func(scope string, oldStatus, newStatus TrafficLightStatus, category *CategoryScore) error {
+		if oldStatus == "" || oldStatus == newStatus {
+			return nil
+		}
+		event := NotificationEvent{Scope: scope, OldStatus: oldStatus, NewStatus: newStatus, Overall: *overall, Category: category, OccurredAt: now}
+		events = append(events, event)
+		return e.route(scope, newStatus, event)
+	}
+
+	if err := dispatch("overall", e.lastStatus["overall"], overall.Status, nil); err != nil {
+		return events, err
+	}
+	e.lastStatus["overall"] = overall.Status
+
+	for i := range overall.Categories {
+		category := overall.Categories[i]
+		if err := dispatch(category.ID, e.lastStatus[category.ID], category.Status, &category); err != nil {
+			return events, err
+		}
+		e.lastStatus[category.ID] = category.Status
+	}
+
+	return events, nil
+}
+
+// route dispatches event to every notifier named by a NotificationRule
+// matching scope and newStatus. A rule matches scope when its Scope equals
+// scope exactly, or equals "*" and scope isn't "overall".
+func (e *NotificationEngine) route(scope string, newStatus TrafficLightStatus, event NotificationEvent) error {
+	for _, rule := range e.rules {
+		if TrafficLightStatus(rule.Status) != newStatus {
+			continue
+		}
+		if rule.Scope != scope && !(rule.Scope == "*" && scope != "overall") {
+			continue
+		}
+
+		for _, channel := range rule.Channels {
+			notifier, ok := e.notifiers[channel]
+			if !ok {
+				return fmt.Errorf("notification rule references unknown channel %q", channel)
+			}
+			if err := notifier.Notify(event); err != nil {
+				return fmt.Errorf("failed to dispatch notification to %q: %w", channel, err)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+package pulse
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+)
+
+func TestSlackNotifierPostsEventText(t *testing.T) {
+	var received struct {
+		Text string `json:"text"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Fatalf("failed to decode request body: %v", err)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	notifier := NewSlackNotifier(server.URL)
+	event := NotificationEvent{Scope: "overall", OldStatus: Green, NewStatus: Red, Overall: OverallScore{Score: 40}}
+
+	if err := notifier.Notify(event); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if received.Text == "" {
+		t.Fatal("expected a non-empty Slack message text")
+	}
+}
+
+func TestHTTPNotifierReportsNon2xxStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	notifier := NewHTTPNotifier(server.URL)
+	if err := notifier.Notify(NotificationEvent{Scope: "overall", NewStatus: Red}); err == nil {
+		t.Fatal("expected an error for a 500 response")
+	}
+}
+
+func TestNotifierFromConfigBuildsEachType(t *testing.T) {
+	cases := []string{"slack", "teams", "pagerduty", "http", "email"}
+	for _, notifierType := range cases {
+		notifier, err := NotifierFromConfig(NotifierConfig{Type: notifierType})
+		if err != nil {
+			t.Fatalf("unexpected error for type %q: %v", notifierType, err)
+		}
+		if notifier == nil {
+			t.Fatalf("expected a non-nil Notifier for type %q", notifierType)
+		}
+	}
+
+	if _, err := NotifierFromConfig(NotifierConfig{Type: "bogus"}); err == nil {
+		t.Fatal("expected an error for an unknown notifier type")
+	}
+}
+
+func TestNotificationEngineDispatchesOnlyOnTransitions(t *testing.T) {
+	var dispatched []NotificationEvent
+	fake := notifierFunc(func(event NotificationEvent) error {
+		dispatched = append(dispatched, event)
+		return nil
+	})
+
+	rules := []NotificationRule{{Scope: "overall", Status: "red", Channels: []string{"oncall"}}}
+	engine := NewNotificationEngine(rules, map[string]Notifier{"oncall": fake})
+
+	greenOverall := &OverallScore{Status: Green}
+	if _, err := engine.Evaluate(greenOverall); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(dispatched) != 0 {
+		t.Fatalf("expected no dispatch on the first observation, got %d", len(dispatched))
+	}
+
+	redOverall := &OverallScore{Status: Red}
+	events, err := engine.Evaluate(redOverall)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(events) != 1 || len(dispatched) != 1 {
+		t.Fatalf("expected exactly one dispatch on the green-to-red transition, got %d events, %d dispatched", len(events), len(dispatched))
+	}
+
+	// Re-evaluating the same Red status a second time shouldn't re-dispatch.
+	if _, err := engine.Evaluate(redOverall); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(dispatched) != 1 {
+		t.Fatalf("expected no re-dispatch while status stays Red, got %d", len(dispatched))
+	}
+}
+
+func TestNotificationEngineRoutesCategoryWildcard(t *testing.T) {
+	var dispatched []NotificationEvent
+	fake := notifierFunc(func(event NotificationEvent) error {
+		dispatched = append(dispatched, event)
+		return nil
+	})
+
+	rules := []NotificationRule{{Scope: "*", Status: "red", Channels: []string{"secops"}}}
+	engine := NewNotificationEngine(rules, map[string]Notifier{"secops": fake})
+
+	if _, err := engine.Evaluate(&OverallScore{Status: Green, Categories: []CategoryScore{{ID: "app_sec", Status: Green}}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := engine.Evaluate(&OverallScore{Status: Green, Categories: []CategoryScore{{ID: "app_sec", Status: Red}}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(dispatched) != 1 {
+		t.Fatalf("expected the wildcard rule to dispatch once for app_sec going Red, got %d", len(dispatched))
+	}
+	if dispatched[0].Scope != "app_sec" {
+		t.Fatalf("expected the event's Scope to be app_sec, got %q", dispatched[0].Scope)
+	}
+}
+
+func TestNotificationEngineStatePersistence(t *testing.T) {
+	dir := t.TempDir()
+	statePath := filepath.Join(dir, "notifications_state.json")
+
+	engine := NewNotificationEngine(nil, nil)
+	if _, err := engine.Evaluate(&OverallScore{Status: Red}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := engine.SaveState(statePath); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	restored := NewNotificationEngine(nil, nil)
+	if err := restored.LoadState(statePath); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Having restored a Red last-status, re-observing Red shouldn't raise
+	// a transition.
+	events, err := restored.Evaluate(&OverallScore{Status: Red})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(events) != 0 {
+		t.Fatalf("expected no transition after restoring a matching last status, got %d", len(events))
+	}
+}
+
+func TestNotificationEngineLoadMissingState(t *testing.T) {
+	engine := NewNotificationEngine(nil, nil)
+	if err := engine.LoadState(filepath.Join(t.TempDir(), "missing.json")); err != nil {
+		t.Fatalf("expected a missing state file to be a no-op, got error: %v", err)
+	}
+}
+
+// notifierFunc adapts a plain function to the Notifier interface, for tests
+// that want to assert on dispatched events without a real channel.
+type notifierFunc func(event NotificationEvent) error
+
+func (f notifierFunc) Notify(event NotificationEvent) error {
+	return f(event)
+}
@@ -0,0 +1,161 @@
+// Package output provides pluggable rendering of pulse CLI command results,
+// so a command builds one typed Result and lets the user pick how it's
+// rendered (table, wide, json, yaml, csv, or a Go text/template) instead of
+// every handler hand-rolling its own fmt.Println formatting.
+package output
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Result is a typed, printer-agnostic record set. Headers names each column
+// in display order; Rows holds one map of header -> value per record.
+// WideHeaders lists additional columns a "wide" Printer includes alongside
+// Headers.
+type Result struct {
+	Headers     []string
+	WideHeaders []string
+	Rows        []map[string]interface{}
+}
+
+// Printer renders a Result to w in a specific format.
+type Printer interface {
+	Print(w io.Writer, result Result) error
+}
+
+// Format identifies a Printer implementation.
+type Format string
+
+const (
+	TableFormat    Format = "table"
+	WideFormat     Format = "wide"
+	JSONFormat     Format = "json"
+	YAMLFormat     Format = "yaml"
+	CSVFormat      Format = "csv"
+	TemplateFormat Format = "template"
+)
+
+// NewPrinter returns the Printer for format. tmpl is only consulted
+// when format is TemplateFormat and holds the Go text/template source to
+// execute against result.Rows.
+func NewPrinter(format Format, tmpl string) (Printer, error) {
+	switch format {
+	case "", TableFormat:
+		return tablePrinter{}, nil
+	case WideFormat:
+		return tablePrinter{wide: true}, nil
+	case JSONFormat:
+		return jsonPrinter{}, nil
+	case YAMLFormat:
+		return yamlPrinter{}, nil
+	case CSVFormat:
+		return csvPrinter{}, nil
+	case TemplateFormat:
+		if tmpl == "" {
+			return nil, fmt.Errorf("template format requires --template")
+		}
+		parsed, err := template.New("output").Parse(tmpl)
+		if err != nil {
+			return nil, fmt.Errorf("invalid template: %w", err)
+		}
+		return templatePrinter{tmpl: parsed}, nil
+	default:
+		return nil, fmt.Errorf("unknown output format: %s", format)
+	}
+}
+
+// tablePrinter renders Result as aligned columns via text/tabwriter. When
+// wide is set, WideHeaders are appended after Headers.
+type tablePrinter struct {
+	wide bool
+}
+
+func (p tablePrinter) Print(w io.Writer, result Result) error {
+	headers := result.Headers
+	if p.wide {
+		headers = append(append([]string{}, result.Headers...), result.WideHeaders...)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+
+	for i, header := range headers {
+		if i > 0 {
+			fmt.Fprint(tw, "\t")
+		}
+		fmt.Fprint(tw, header)
+	}
+	fmt.Fprintln(tw)
+
+	for _, row := range result.Rows {
+		for i, header := range headers {
+			if i > 0 {
+				fmt.Fprint(tw, "\t")
+			}
+			fmt.Fprintf(tw, "%v", row[header])
+		}
+		fmt.Fprintln(tw)
+	}
+
+	return tw.Flush()
+}
+
+// jsonPrinter renders Result.Rows as an indented JSON array.
+type jsonPrinter struct{}
+
+func (jsonPrinter) Print(w io.Writer, result Result) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(result.Rows)
+}
+
+// yamlPrinter renders Result.Rows as a YAML sequence.
+type yamlPrinter struct{}
+
+func (yamlPrinter) Print(w io.Writer, result Result) error {
+	encoder := yaml.NewEncoder(w)
+	defer encoder.Close()
+	return encoder.Encode(result.Rows)
+}
+
+// csvPrinter renders Result as comma-separated values, with Headers as the
+// first row.
+type csvPrinter struct{}
+
+func (csvPrinter) Print(w io.Writer, result Result) error {
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write(result.Headers); err != nil {
+		return err
+	}
+
+	for _, row := range result.Rows {
+		record := make([]string, len(result.Headers))
+		for i, header := range result.Headers {
+			record[i] = fmt.Sprintf("%v", row[header])
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// templatePrinter renders Result.Rows by executing a parsed Go
+// text/template against them, the same approach `kubectl -o
+// go-template` uses.
+type templatePrinter struct {
+	tmpl *template.Template
+}
+
+func (p templatePrinter) Print(w io.Writer, result Result) error {
+	return p.tmpl.Execute(w, result.Rows)
+}
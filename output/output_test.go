@@ -0,0 +1,95 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func testResult() Result {
+	return Result{
+		Headers: []string{"name", "score"},
+		Rows: []map[string]interface{}{
+			{"name": "app_sec", "score": 80},
+			{"name": "data_sec", "score": 60},
+		},
+	}
+}
+
+func TestTablePrinter(t *testing.T) {
+	printer, err := NewPrinter(TableFormat, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := printer.Print(&buf, testResult()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "name") || !strings.Contains(out, "app_sec") {
+		t.Fatalf("expected the table to contain headers and row data, got %q", out)
+	}
+}
+
+func TestJSONPrinter(t *testing.T) {
+	printer, err := NewPrinter(JSONFormat, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := printer.Print(&buf, testResult()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(buf.String(), `"app_sec"`) {
+		t.Fatalf("expected JSON output to contain the row value, got %q", buf.String())
+	}
+}
+
+func TestCSVPrinter(t *testing.T) {
+	printer, err := NewPrinter(CSVFormat, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := printer.Print(&buf, testResult()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 || lines[0] != "name,score" {
+		t.Fatalf("unexpected CSV output: %v", lines)
+	}
+}
+
+func TestTemplatePrinter(t *testing.T) {
+	printer, err := NewPrinter(TemplateFormat, `{{range .}}{{.name}}={{.score}} {{end}}`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := printer.Print(&buf, testResult()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if buf.String() != "app_sec=80 data_sec=60 " {
+		t.Fatalf("unexpected template output: %q", buf.String())
+	}
+}
+
+func TestNewPrinterTemplateRequiresTemplateString(t *testing.T) {
+	if _, err := NewPrinter(TemplateFormat, ""); err == nil {
+		t.Fatal("expected an error when --template is empty")
+	}
+}
+
+func TestNewPrinterUnknownFormat(t *testing.T) {
+	if _, err := NewPrinter(Format("bogus"), ""); err == nil {
+		t.Fatal("expected an error for an unknown format")
+	}
+}
@@ -0,0 +1,623 @@
+package pulse
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// query.go implements a small PromQL-lite aggregation language over
+// MetricsProcessor's loaded metrics: selectors like "SEC.KPI.*" (glob on
+// the three-part reference, see referenceMatchesGlob in metric_rules.go),
+// aggregations (avg/sum/min/max/count/stddev) with optional "by category"
+// grouping, a rate() range-vector function over HistoryStore, the
+// weight(category) lookup, and +, -, *, / between results. It exists so
+// users can compute derived KPIs and category rollups (e.g. "sum(*.KPI.*)
+// * weight(category)") without writing Go code; MetricsProcessor.Query is
+// the entry point, and "pulse query" is its CLI.
+
+// QueryResult is what MetricsProcessor.Query returns: either a single
+// Scalar (the common case), or, when the query's top-level aggregation
+// grouped "by category", a Series keyed by category ID instead.
+type QueryResult struct {
+	Scalar  float64
+	Series  map[string]float64
+	Grouped bool
+}
+
+// queryToken is one lexical token of a query expression.
+type queryToken struct {
+	kind string // "word", "number", "op", "eof"
+	text string
+}
+
+// isGlobStar reports whether the '*' at runes[i] is a glob segment (as in
+// "SEC.KPI.*" or "*.KPI.*") rather than the multiply operator: a glob '*'
+// always sits in place of a whole dot-separated reference segment, so it's
+// adjacent to a '.' on at least one side, while "a * b" has whitespace (or
+// nothing glob-like) on both.
+func isGlobStar(runes []rune, i int) bool {
+	return (i > 0 && runes[i-1] == '.') || (i+1 < len(runes) && runes[i+1] == '.')
+}
+
+// lexQuery tokenizes expr. A "word" token is a maximal run of letters,
+// digits, '.', '_', and glob '*'s — broad enough to cover function names,
+// bare identifiers (e.g. "category"), and dotted glob selectors (e.g.
+// "SEC.KPI.*") as a single token kind, since the parser only needs to
+// distinguish them by the context they appear in. A '*' that isn't part of
+// a glob segment (see isGlobStar) is instead lexed as the multiply "op", so
+// "sec.KPI.availability * 2" parses as multiplication rather than a bad
+// glob-shaped word.
+func lexQuery(expr string) ([]queryToken, error) {
+	var tokens []queryToken
+	runes := []rune(expr)
+	i := 0
+	for i < len(runes) {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '.' || r == '_' || (r == '*' && isGlobStar(runes, i)):
+			start := i
+			for i < len(runes) {
+				c := runes[i]
+				if unicode.IsLetter(c) || unicode.IsDigit(c) || c == '.' || c == '_' || (c == '*' && isGlobStar(runes, i)) {
+					i++
+					continue
+				}
+				break
+			}
+			text := string(runes[start:i])
+			if _, err := strconv.ParseFloat(text, 64); err == nil {
+				tokens = append(tokens, queryToken{kind: "number", text: text})
+			} else {
+				tokens = append(tokens, queryToken{kind: "word", text: text})
+			}
+		case strings.ContainsRune("()[],+-*/", r):
+			tokens = append(tokens, queryToken{kind: "op", text: string(r)})
+			i++
+		default:
+			return nil, fmt.Errorf("unexpected character %q", r)
+		}
+	}
+	tokens = append(tokens, queryToken{kind: "eof"})
+	return tokens, nil
+}
+
+// queryNode is a parsed, evaluable node of a query expression.
+type queryNode interface {
+	eval(env *queryEnv) (QueryResult, error)
+}
+
+// queryEnv is the evaluation context a query expression runs against.
+type queryEnv struct {
+	processor *MetricsProcessor
+}
+
+type queryNumberNode float64
+type selectorNode string // bare or glob reference, e.g. "SEC.KPI.*"
+type queryIdentNode string
+type rangeSelectorNode struct {
+	reference string
+	window    time.Duration
+}
+type aggregationNode struct {
+	fn  string
+	arg queryNode
+	by  string // grouping key ("category"), empty when ungrouped
+}
+type functionCallNode struct {
+	name string
+	args []queryNode
+}
+type binaryOpNode struct {
+	op          string
+	left, right queryNode
+}
+
+func (n queryNumberNode) eval(env *queryEnv) (QueryResult, error) {
+	return QueryResult{Scalar: float64(n)}, nil
+}
+
+func (s selectorNode) eval(env *queryEnv) (QueryResult, error) {
+	ref := string(s)
+	if strings.Contains(ref, "*") {
+		return QueryResult{}, fmt.Errorf("selector %q is a glob and must be wrapped in an aggregation (avg/sum/min/max/count/stddev)", ref)
+	}
+	metric, err := env.processor.GetMetricByReference(ref)
+	if err != nil {
+		return QueryResult{}, err
+	}
+	return QueryResult{Scalar: metric.Value}, nil
+}
+
+func (r rangeSelectorNode) eval(env *queryEnv) (QueryResult, error) {
+	history := env.processor.History()
+	if history == nil {
+		return QueryResult{}, fmt.Errorf("rate(%s[%s]) requires a HistoryStore; none is attached", r.reference, r.window)
+	}
+
+	now := time.Now()
+	points, err := history.Query(r.reference, now.Add(-r.window), now)
+	if err != nil {
+		return QueryResult{}, err
+	}
+	if len(points) < 2 {
+		return QueryResult{}, fmt.Errorf("rate(%s[%s]): need at least 2 history points in the window, found %d", r.reference, r.window, len(points))
+	}
+
+	first, last := points[0], points[len(points)-1]
+	elapsedDays := last.Timestamp.Sub(first.Timestamp).Hours() / 24
+	if elapsedDays == 0 {
+		return QueryResult{}, fmt.Errorf("rate(%s[%s]): history points span no time", r.reference, r.window)
+	}
+	return QueryResult{Scalar: (last.Value - first.Value) / elapsedDays}, nil
+}
+
+// selectorMetrics resolves a glob selector (e.g. "SEC.KPI.*") to every
+// matching Metric, for aggregationNode.eval to group or fold over.
+func selectorMetrics(env *queryEnv, pattern string) []Metric {
+	var matched []Metric
+	for _, metric := range env.processor.metricsData.Metrics {
+		if referenceMatchesGlob(metric.Reference, pattern) {
+			matched = append(matched, metric)
+		}
+	}
+	return matched
+}
+
+func (a aggregationNode) eval(env *queryEnv) (QueryResult, error) {
+	selector, ok := a.arg.(selectorNode)
+	if !ok {
+		return QueryResult{}, fmt.Errorf("%s() expects a metric selector argument", a.fn)
+	}
+	metrics := selectorMetrics(env, string(selector))
+
+	if a.by == "" {
+		values := make([]float64, len(metrics))
+		for i, m := range metrics {
+			values[i] = m.Value
+		}
+		value, err := aggregateQueryValues(a.fn, values)
+		if err != nil {
+			return QueryResult{}, err
+		}
+		return QueryResult{Scalar: value}, nil
+	}
+
+	if a.by != "category" {
+		return QueryResult{}, fmt.Errorf("unsupported grouping key %q (only \"category\" is supported)", a.by)
+	}
+
+	grouped := make(map[string][]float64)
+	var order []string
+	for _, m := range metrics {
+		category := strings.SplitN(m.Reference, ".", 2)[0]
+		if _, seen := grouped[category]; !seen {
+			order = append(order, category)
+		}
+		grouped[category] = append(grouped[category], m.Value)
+	}
+
+	series := make(map[string]float64, len(grouped))
+	for _, category := range order {
+		value, err := aggregateQueryValues(a.fn, grouped[category])
+		if err != nil {
+			return QueryResult{}, err
+		}
+		series[category] = value
+	}
+	return QueryResult{Series: series, Grouped: true}, nil
+}
+
+// aggregateQueryValues applies fn over values the same way
+// aggregateValues does for metric_rules.go's selectors, plus stddev
+// (population standard deviation), which rule thresholds don't need.
+func aggregateQueryValues(fn string, values []float64) (float64, error) {
+	if fn == "stddev" {
+		if len(values) == 0 {
+			return 0, fmt.Errorf("stddev of an empty selector is undefined")
+		}
+		var sum float64
+		for _, v := range values {
+			sum += v
+		}
+		mean := sum / float64(len(values))
+		var variance float64
+		for _, v := range values {
+			variance += (v - mean) * (v - mean)
+		}
+		variance /= float64(len(values))
+		return math.Sqrt(variance), nil
+	}
+	if fn != "count" && len(values) == 0 {
+		return 0, fmt.Errorf("%s of an empty selector is undefined", fn)
+	}
+	return aggregateValues(fn, values)
+}
+
+// weightPlaceholder is the special identifier weight() accepts instead of
+// a literal category name, meaning "the category this result is currently
+// grouped by" — only meaningful on one side of a binaryOpNode whose other
+// side is a grouped aggregation.
+const weightPlaceholder = "category"
+
+func (f functionCallNode) eval(env *queryEnv) (QueryResult, error) {
+	if f.name != "weight" {
+		return QueryResult{}, fmt.Errorf("unknown function %q", f.name)
+	}
+	if len(f.args) != 1 {
+		return QueryResult{}, fmt.Errorf("weight() expects exactly one argument")
+	}
+	ident, ok := f.args[0].(queryIdentNode)
+	if !ok {
+		return QueryResult{}, fmt.Errorf("weight() expects a bare category name or %q", weightPlaceholder)
+	}
+	if string(ident) == weightPlaceholder {
+		return QueryResult{}, fmt.Errorf("weight(%s) must be used alongside a \"by category\" aggregation in a binary expression", weightPlaceholder)
+	}
+	return QueryResult{Scalar: env.processor.leversConfig.Weights.Categories[string(ident)]}, nil
+}
+
+func (id queryIdentNode) eval(env *queryEnv) (QueryResult, error) {
+	return QueryResult{}, fmt.Errorf("unexpected bare identifier %q", string(id))
+}
+
+// isWeightByCategory reports whether node is exactly weight(category), the
+// per-group broadcast form functionCallNode.eval rejects on its own.
+func isWeightByCategory(node queryNode) bool {
+	call, ok := node.(functionCallNode)
+	if !ok || call.name != "weight" || len(call.args) != 1 {
+		return false
+	}
+	ident, ok := call.args[0].(queryIdentNode)
+	return ok && string(ident) == weightPlaceholder
+}
+
+func (b binaryOpNode) eval(env *queryEnv) (QueryResult, error) {
+	// weight(category) only resolves per-group, against whichever side of
+	// this expression is itself grouped, so it's special-cased before
+	// either side is evaluated normally.
+	if isWeightByCategory(b.right) {
+		left, err := b.left.eval(env)
+		if err != nil {
+			return QueryResult{}, err
+		}
+		return combineWithPerCategoryWeight(env, left, b.op, false)
+	}
+	if isWeightByCategory(b.left) {
+		right, err := b.right.eval(env)
+		if err != nil {
+			return QueryResult{}, err
+		}
+		return combineWithPerCategoryWeight(env, right, b.op, true)
+	}
+
+	left, err := b.left.eval(env)
+	if err != nil {
+		return QueryResult{}, err
+	}
+	right, err := b.right.eval(env)
+	if err != nil {
+		return QueryResult{}, err
+	}
+
+	switch {
+	case left.Grouped && right.Grouped:
+		series := make(map[string]float64)
+		for category, leftValue := range left.Series {
+			if rightValue, ok := right.Series[category]; ok {
+				result, err := applyBinaryOp(b.op, leftValue, rightValue)
+				if err != nil {
+					return QueryResult{}, err
+				}
+				series[category] = result
+			}
+		}
+		return QueryResult{Series: series, Grouped: true}, nil
+	case left.Grouped:
+		return broadcastBinaryOp(b.op, left.Series, right.Scalar, false)
+	case right.Grouped:
+		return broadcastBinaryOp(b.op, right.Series, left.Scalar, true)
+	default:
+		value, err := applyBinaryOp(b.op, left.Scalar, right.Scalar)
+		if err != nil {
+			return QueryResult{}, err
+		}
+		return QueryResult{Scalar: value}, nil
+	}
+}
+
+// combineWithPerCategoryWeight evaluates "<grouped> op weight(category)"
+// (or, when scalarIsLeft, "weight(category) op <grouped>"), looking up
+// each group's own weight rather than a single broadcast scalar.
+func combineWithPerCategoryWeight(env *queryEnv, grouped QueryResult, op string, scalarIsLeft bool) (QueryResult, error) {
+	if !grouped.Grouped {
+		return QueryResult{}, fmt.Errorf("weight(%s) requires the other side of the expression to be a \"by category\" aggregation", weightPlaceholder)
+	}
+
+	series := make(map[string]float64, len(grouped.Series))
+	for category, value := range grouped.Series {
+		weight := env.processor.leversConfig.Weights.Categories[category]
+		left, right := value, weight
+		if scalarIsLeft {
+			left, right = weight, value
+		}
+		result, err := applyBinaryOp(op, left, right)
+		if err != nil {
+			return QueryResult{}, err
+		}
+		series[category] = result
+	}
+	return QueryResult{Series: series, Grouped: true}, nil
+}
+
+// broadcastBinaryOp applies op between every value in series and scalar,
+// preserving series's grouping; scalarIsLeft controls operand order for
+// non-commutative operators (-, /).
+func broadcastBinaryOp(op string, series map[string]float64, scalar float64, scalarIsLeft bool) (QueryResult, error) {
+	result := make(map[string]float64, len(series))
+	for category, value := range series {
+		left, right := value, scalar
+		if scalarIsLeft {
+			left, right = scalar, value
+		}
+		combined, err := applyBinaryOp(op, left, right)
+		if err != nil {
+			return QueryResult{}, err
+		}
+		result[category] = combined
+	}
+	return QueryResult{Series: result, Grouped: true}, nil
+}
+
+func applyBinaryOp(op string, left, right float64) (float64, error) {
+	switch op {
+	case "+":
+		return left + right, nil
+	case "-":
+		return left - right, nil
+	case "*":
+		return left * right, nil
+	case "/":
+		if right == 0 {
+			return 0, fmt.Errorf("division by zero")
+		}
+		return left / right, nil
+	default:
+		return 0, fmt.Errorf("unknown operator %q", op)
+	}
+}
+
+// queryParser parses a lexed query expression via recursive descent, with
+// precedence (low to high): + / - , then * / /.
+type queryParser struct {
+	tokens []queryToken
+	pos    int
+}
+
+func (p *queryParser) peek() queryToken { return p.tokens[p.pos] }
+
+func (p *queryParser) next() queryToken {
+	t := p.tokens[p.pos]
+	if t.kind != "eof" {
+		p.pos++
+	}
+	return t
+}
+
+func (p *queryParser) expectOp(op string) error {
+	t := p.next()
+	if t.kind != "op" || t.text != op {
+		return fmt.Errorf("expected %q, got %q", op, t.text)
+	}
+	return nil
+}
+
+// parseQueryExpression parses expr into a queryNode tree.
+func parseQueryExpression(expr string) (queryNode, error) {
+	tokens, err := lexQuery(expr)
+	if err != nil {
+		return nil, err
+	}
+	p := &queryParser{tokens: tokens}
+	node, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if p.peek().kind != "eof" {
+		return nil, fmt.Errorf("unexpected trailing token %q", p.peek().text)
+	}
+	return node, nil
+}
+
+func (p *queryParser) parseExpr() (queryNode, error) {
+	left, err := p.parseTerm()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == "op" && (p.peek().text == "+" || p.peek().text == "-") {
+		op := p.next().text
+		right, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		left = binaryOpNode{op: op, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *queryParser) parseTerm() (queryNode, error) {
+	left, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == "op" && (p.peek().text == "*" || p.peek().text == "/") {
+		op := p.next().text
+		right, err := p.parsePrimary()
+		if err != nil {
+			return nil, err
+		}
+		left = binaryOpNode{op: op, left: left, right: right}
+	}
+	return left, nil
+}
+
+var aggregationFuncs = map[string]bool{"avg": true, "sum": true, "min": true, "max": true, "count": true, "stddev": true}
+
+func (p *queryParser) parsePrimary() (queryNode, error) {
+	t := p.next()
+
+	switch t.kind {
+	case "number":
+		v, err := strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q: %w", t.text, err)
+		}
+		return queryNumberNode(v), nil
+
+	case "word":
+		if p.peek().kind == "op" && p.peek().text == "(" {
+			return p.parseCallOrAggregation(t.text)
+		}
+		if p.peek().kind == "op" && p.peek().text == "[" {
+			return p.parseRangeSelector(t.text)
+		}
+		if aggregationFuncs[t.text] || t.text == "weight" || t.text == "rate" {
+			return nil, fmt.Errorf("%q is a function name and must be followed by \"(\"", t.text)
+		}
+		if strings.Contains(t.text, ".") {
+			return selectorNode(t.text), nil
+		}
+		return queryIdentNode(t.text), nil
+
+	case "op":
+		if t.text == "(" {
+			inner, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expectOp(")"); err != nil {
+				return nil, err
+			}
+			return inner, nil
+		}
+		return nil, fmt.Errorf("unexpected token %q", t.text)
+
+	default:
+		return nil, fmt.Errorf("unexpected token %q", t.text)
+	}
+}
+
+// parseCallOrAggregation parses "name(args...)" and, for the aggregation
+// functions, an optional trailing "by category".
+func (p *queryParser) parseCallOrAggregation(name string) (queryNode, error) {
+	if err := p.expectOp("("); err != nil {
+		return nil, err
+	}
+
+	var args []queryNode
+	for !(p.peek().kind == "op" && p.peek().text == ")") {
+		arg, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+		if p.peek().kind == "op" && p.peek().text == "," {
+			p.next()
+		}
+	}
+	if err := p.expectOp(")"); err != nil {
+		return nil, err
+	}
+
+	if name == "rate" {
+		if len(args) != 1 {
+			return nil, fmt.Errorf("rate() expects exactly one range-vector argument")
+		}
+		rangeArg, ok := args[0].(rangeSelectorNode)
+		if !ok {
+			return nil, fmt.Errorf("rate() expects a range vector argument, e.g. rate(FIN.KPI.revenue[30d])")
+		}
+		return rangeArg, nil
+	}
+
+	if !aggregationFuncs[name] {
+		if len(args) != 1 {
+			return nil, fmt.Errorf("%s() expects exactly one argument", name)
+		}
+		return functionCallNode{name: name, args: args}, nil
+	}
+
+	if len(args) != 1 {
+		return nil, fmt.Errorf("%s() expects exactly one selector argument", name)
+	}
+
+	by := ""
+	if p.peek().kind == "word" && p.peek().text == "by" {
+		p.next()
+		key := p.next()
+		if key.kind != "word" {
+			return nil, fmt.Errorf("expected a grouping key after \"by\", got %q", key.text)
+		}
+		by = key.text
+	}
+
+	return aggregationNode{fn: name, arg: args[0], by: by}, nil
+}
+
+// parseRangeSelector parses "reference[window]", e.g. "FIN.KPI.revenue[30d]".
+func (p *queryParser) parseRangeSelector(reference string) (queryNode, error) {
+	if err := p.expectOp("["); err != nil {
+		return nil, err
+	}
+	windowTok := p.next()
+	if windowTok.kind != "word" {
+		return nil, fmt.Errorf("expected a duration inside [], got %q", windowTok.text)
+	}
+	if err := p.expectOp("]"); err != nil {
+		return nil, err
+	}
+	window, err := parseQueryDuration(windowTok.text)
+	if err != nil {
+		return nil, err
+	}
+	return rangeSelectorNode{reference: reference, window: window}, nil
+}
+
+// parseQueryDuration parses a range-vector window like "30d" or "6h" (a
+// number followed by a single unit letter: d=days, h=hours, m=minutes).
+func parseQueryDuration(spec string) (time.Duration, error) {
+	if len(spec) < 2 {
+		return 0, fmt.Errorf("invalid duration %q", spec)
+	}
+	unit := spec[len(spec)-1]
+	amount, err := strconv.Atoi(spec[:len(spec)-1])
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q: %w", spec, err)
+	}
+	switch unit {
+	case 'd':
+		return time.Duration(amount) * 24 * time.Hour, nil
+	case 'h':
+		return time.Duration(amount) * time.Hour, nil
+	case 'm':
+		return time.Duration(amount) * time.Minute, nil
+	default:
+		return 0, fmt.Errorf("invalid duration unit %q in %q (expected d, h, or m)", string(unit), spec)
+	}
+}
+
+// Query parses and evaluates expr (e.g. "avg(SEC.KPI.*)", "max(OPS.KRI.*)
+// by category", "sum(*.KPI.*) * weight(category)") against m's loaded
+// metrics and configuration.
+func (m *MetricsProcessor) Query(expr string) (QueryResult, error) {
+	node, err := parseQueryExpression(expr)
+	if err != nil {
+		return QueryResult{}, fmt.Errorf("invalid query %q: %w", expr, err)
+	}
+	return node.eval(&queryEnv{processor: m})
+}
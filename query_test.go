@@ -0,0 +1,110 @@
+package pulse
+
+import "testing"
+
+func queryFixture(metrics []Metric, weights CategoryWeights) *MetricsProcessor {
+	metricsConfig := &MetricsConfig{Categories: []Category{{ID: "sec"}, {ID: "ops"}}}
+	leversConfig := &LeversConfig{Weights: Weights{Categories: weights}}
+	metricsData := &MetricsData{Metrics: metrics}
+	return NewMetricsProcessor(metricsConfig, leversConfig, metricsData)
+}
+
+func TestQueryBareSelector(t *testing.T) {
+	processor := queryFixture([]Metric{{Reference: "sec.KPI.availability", Value: 99.9}}, nil)
+
+	result, err := processor.Query("sec.KPI.availability")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Grouped || result.Scalar != 99.9 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+}
+
+func TestQueryAggregation(t *testing.T) {
+	processor := queryFixture([]Metric{
+		{Reference: "sec.KRI.critical", Value: 60},
+		{Reference: "sec.KRI.high", Value: 80},
+	}, nil)
+
+	result, err := processor.Query("avg(sec.KRI.*)")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Scalar != 70 {
+		t.Errorf("expected avg(60,80)=70, got %.2f", result.Scalar)
+	}
+}
+
+func TestQueryAggregationByCategory(t *testing.T) {
+	processor := queryFixture([]Metric{
+		{Reference: "sec.KPI.availability", Value: 10},
+		{Reference: "sec.KPI.coverage", Value: 20},
+		{Reference: "ops.KPI.uptime", Value: 90},
+	}, nil)
+
+	result, err := processor.Query("sum(*.KPI.*) by category")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.Grouped {
+		t.Fatalf("expected a grouped result, got %+v", result)
+	}
+	if result.Series["sec"] != 30 || result.Series["ops"] != 90 {
+		t.Errorf("unexpected series: %+v", result.Series)
+	}
+}
+
+func TestQueryWeightByCategoryBroadcast(t *testing.T) {
+	processor := queryFixture([]Metric{
+		{Reference: "sec.KPI.availability", Value: 10},
+		{Reference: "ops.KPI.uptime", Value: 20},
+	}, CategoryWeights{"sec": 0.5, "ops": 2})
+
+	result, err := processor.Query("sum(*.KPI.*) by category * weight(category)")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.Grouped {
+		t.Fatalf("expected a grouped result, got %+v", result)
+	}
+	if result.Series["sec"] != 5 || result.Series["ops"] != 40 {
+		t.Errorf("unexpected series: %+v", result.Series)
+	}
+}
+
+func TestQueryArithmetic(t *testing.T) {
+	processor := queryFixture([]Metric{{Reference: "sec.KPI.availability", Value: 10}}, nil)
+
+	result, err := processor.Query("sec.KPI.availability * 2 + 1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Scalar != 21 {
+		t.Errorf("expected 21, got %.2f", result.Scalar)
+	}
+}
+
+func TestQueryRejectsUnwrappedGlob(t *testing.T) {
+	processor := queryFixture(nil, nil)
+
+	if _, err := processor.Query("sec.KPI.*"); err == nil {
+		t.Error("expected an error for a bare glob selector")
+	}
+}
+
+func TestQueryRejectsMalformedExpression(t *testing.T) {
+	processor := queryFixture(nil, nil)
+
+	if _, err := processor.Query("avg(sec.KRI.*"); err == nil {
+		t.Error("expected an error for an unbalanced expression")
+	}
+}
+
+func TestQueryRateWithoutHistoryStore(t *testing.T) {
+	processor := queryFixture([]Metric{{Reference: "fin.KPI.revenue", Value: 100}}, nil)
+
+	if _, err := processor.Query("rate(fin.KPI.revenue[30d])"); err == nil {
+		t.Error("expected an error when no HistoryStore is attached")
+	}
+}
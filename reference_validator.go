@@ -0,0 +1,201 @@
+package pulse
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// defaultMiddleTokens, defaultMaxLength, and defaultSegmentPattern are the
+// built-in reference scheme used when levers.yaml has no reference_schema
+// block: a 3-part dot-separated reference whose middle part is "KPI" or
+// "KRI", at most 100 characters, using only letters, digits, '.', '_', and
+// '-'. They match the historical behavior of the unexported isValidReference
+// check this validator replaces.
+var (
+	defaultMiddleTokens   = []string{"KPI", "KRI"}
+	defaultMaxLength      = 100
+	defaultSegmentPattern = `^[A-Za-z0-9_-]+$`
+)
+
+// segmentCharClassRe extracts the character class from a SegmentPattern of
+// the simple anchored form "^[...]+$", so ReferenceValidator can point at
+// the exact offending character instead of just failing the whole segment.
+// Patterns that don't fit this shape still validate correctly; they just
+// can't be narrowed down to a single offset.
+var segmentCharClassRe = regexp.MustCompile(`^\^\[(.+)\]\+\$$`)
+
+// ReferenceError is a single violation found by ReferenceValidator.Validate.
+// Segment is the 0-based dot-separated segment index the violation applies
+// to, or -1 for a violation of the reference as a whole (e.g. wrong segment
+// count). Offset is the byte offset within the full reference string of the
+// offending character, or -1 when not applicable.
+type ReferenceError struct {
+	Segment int
+	Offset  int
+	Message string
+}
+
+func (e ReferenceError) Error() string {
+	return e.Message
+}
+
+// ReferenceValidator checks metric references against a ReferenceSchema. A
+// zero-value schema (or nil) validates against the built-in KPI/KRI scheme.
+type ReferenceValidator struct {
+	schema           *ReferenceSchema
+	middleTokens     map[string]bool
+	maxLength        int
+	segmentPattern   *regexp.Regexp
+	charClassPattern *regexp.Regexp // nil if segmentPattern isn't a simple "^[...]+$" char class
+}
+
+// NewReferenceValidator builds a ReferenceValidator from schema, applying
+// the built-in defaults to any field schema leaves unset. It errors if
+// schema.SegmentPattern is not a valid regular expression.
+func NewReferenceValidator(schema *ReferenceSchema) (*ReferenceValidator, error) {
+	if schema == nil {
+		schema = &ReferenceSchema{}
+	}
+
+	middleTokens := schema.MiddleTokens
+	if len(middleTokens) == 0 {
+		middleTokens = defaultMiddleTokens
+	}
+	middleSet := make(map[string]bool, len(middleTokens))
+	for _, token := range middleTokens {
+		middleSet[token] = true
+	}
+
+	maxLength := schema.MaxLength
+	if maxLength == 0 {
+		maxLength = defaultMaxLength
+	}
+
+	segmentPatternSrc := schema.SegmentPattern
+	if segmentPatternSrc == "" {
+		segmentPatternSrc = defaultSegmentPattern
+	}
+	segmentPattern, err := regexp.Compile(segmentPatternSrc)
+	if err != nil {
+		return nil, fmt.Errorf("invalid reference_schema segment_pattern: %w", err)
+	}
+
+	var charClassPattern *regexp.Regexp
+	if m := segmentCharClassRe.FindStringSubmatch(segmentPatternSrc); m != nil {
+		if re, err := regexp.Compile("^[" + m[1] + "]$"); err == nil {
+			charClassPattern = re
+		}
+	}
+
+	return &ReferenceValidator{
+		schema:           schema,
+		middleTokens:     middleSet,
+		maxLength:        maxLength,
+		segmentPattern:   segmentPattern,
+		charClassPattern: charClassPattern,
+	}, nil
+}
+
+// Validate reports every violation found in ref, rather than stopping at
+// the first one, so a caller can point a user at every problem in a single
+// pass. An empty result means ref is well-formed.
+func (v *ReferenceValidator) Validate(ref string) []ReferenceError {
+	var errs []ReferenceError
+
+	if ref == "" {
+		return []ReferenceError{{Segment: -1, Offset: -1, Message: "reference must not be empty"}}
+	}
+	if len(ref) > v.maxLength {
+		errs = append(errs, ReferenceError{Segment: -1, Offset: -1, Message: fmt.Sprintf("reference exceeds max length of %d characters", v.maxLength)})
+	}
+
+	parts := strings.Split(ref, ".")
+	minParts, maxParts := 3, 3
+	if v.schema.TeamSegment {
+		maxParts = 4
+	}
+	if len(parts) < minParts || len(parts) > maxParts {
+		errs = append(errs, ReferenceError{Segment: -1, Offset: -1, Message: fmt.Sprintf("reference must have %s dot-separated segments, got %d", partCountDescription(minParts, maxParts), len(parts))})
+		return errs
+	}
+
+	typeIndex := 1
+	if len(parts) == 4 {
+		typeIndex = 2
+	}
+
+	offset := 0
+	for i, part := range parts {
+		segmentStart := offset
+		offset += len(part) + 1
+
+		if part == "" {
+			errs = append(errs, ReferenceError{Segment: i, Offset: segmentStart, Message: fmt.Sprintf("segment %d is empty", i)})
+			continue
+		}
+
+		toCheck, prefixLen := part, 0
+		if i == 0 && len(parts) == 4 {
+			toCheck, prefixLen = strings.TrimPrefix(part, "$"), len(part)-len(strings.TrimPrefix(part, "$"))
+			if toCheck == "" {
+				errs = append(errs, ReferenceError{Segment: i, Offset: segmentStart, Message: fmt.Sprintf("team segment %d must not be empty after \"$\"", i)})
+				continue
+			}
+		}
+
+		if !v.segmentPattern.MatchString(toCheck) {
+			if badOffset := v.firstBadRuneOffset(toCheck); badOffset >= 0 {
+				badRune, _ := utf8.DecodeRuneInString(toCheck[badOffset:])
+				errs = append(errs, ReferenceError{
+					Segment: i,
+					Offset:  segmentStart + prefixLen + badOffset,
+					Message: fmt.Sprintf("segment %d contains an invalid character %q at position %d", i, badRune, badOffset),
+				})
+			} else {
+				errs = append(errs, ReferenceError{Segment: i, Offset: segmentStart + prefixLen, Message: fmt.Sprintf("segment %d does not match the configured pattern", i)})
+			}
+		}
+
+		if i == typeIndex && !v.middleTokens[part] {
+			errs = append(errs, ReferenceError{Segment: i, Offset: segmentStart, Message: fmt.Sprintf("segment %d has unknown type %q (expected one of %s)", i, part, strings.Join(v.sortedMiddleTokens(), ", "))})
+		}
+	}
+
+	return errs
+}
+
+// firstBadRuneOffset returns the byte offset of the first rune in segment
+// that doesn't match v's character class, or -1 if segmentPattern isn't a
+// simple char class or every rune matches.
+func (v *ReferenceValidator) firstBadRuneOffset(segment string) int {
+	if v.charClassPattern == nil {
+		return -1
+	}
+	for i, r := range segment {
+		if !v.charClassPattern.MatchString(string(r)) {
+			return i
+		}
+	}
+	return -1
+}
+
+// sortedMiddleTokens returns v's allowed middle tokens for use in an error
+// message; schema.MiddleTokens's original order is preserved when set,
+// since that's usually the order the user wrote them in levers.yaml.
+func (v *ReferenceValidator) sortedMiddleTokens() []string {
+	if len(v.schema.MiddleTokens) > 0 {
+		return v.schema.MiddleTokens
+	}
+	return defaultMiddleTokens
+}
+
+// partCountDescription renders a human-readable segment-count requirement,
+// e.g. "3" or "3 or 4".
+func partCountDescription(min, max int) string {
+	if min == max {
+		return fmt.Sprintf("%d", min)
+	}
+	return fmt.Sprintf("%d or %d", min, max)
+}
@@ -0,0 +1,105 @@
+package pulse
+
+import "testing"
+
+func TestReferenceValidatorDefaultSchemaAcceptsValidReference(t *testing.T) {
+	validator, err := NewReferenceValidator(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if errs := validator.Validate("sec.KPI.availability"); len(errs) != 0 {
+		t.Errorf("expected no violations, got %+v", errs)
+	}
+}
+
+func TestReferenceValidatorReportsUnknownMiddleToken(t *testing.T) {
+	validator, err := NewReferenceValidator(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	errs := validator.Validate("sec.OKR.availability")
+	if len(errs) != 1 || errs[0].Segment != 1 {
+		t.Fatalf("expected 1 violation on segment 1, got %+v", errs)
+	}
+}
+
+func TestReferenceValidatorCustomMiddleTokens(t *testing.T) {
+	validator, err := NewReferenceValidator(&ReferenceSchema{MiddleTokens: []string{"KPI", "KRI", "OKR", "SLO", "SLI"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if errs := validator.Validate("sec.OKR.availability"); len(errs) != 0 {
+		t.Errorf("expected OKR to be accepted, got %+v", errs)
+	}
+}
+
+func TestReferenceValidatorReportsAllViolations(t *testing.T) {
+	validator, err := NewReferenceValidator(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	errs := validator.Validate("sec..bad!char")
+	if len(errs) < 2 {
+		t.Fatalf("expected at least 2 violations (empty segment, bad char), got %+v", errs)
+	}
+}
+
+func TestReferenceValidatorFlagsBadCharOffset(t *testing.T) {
+	validator, err := NewReferenceValidator(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	errs := validator.Validate("sec.KPI.bad!char")
+	if len(errs) != 1 {
+		t.Fatalf("expected 1 violation, got %+v", errs)
+	}
+	if errs[0].Offset != len("sec.KPI.bad") {
+		t.Errorf("expected offset %d, got %d", len("sec.KPI.bad"), errs[0].Offset)
+	}
+}
+
+func TestReferenceValidatorMaxLength(t *testing.T) {
+	validator, err := NewReferenceValidator(&ReferenceSchema{MaxLength: 10})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	errs := validator.Validate("sec.KPI.availability")
+	found := false
+	for _, e := range errs {
+		if e.Segment == -1 {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected a whole-reference max-length violation, got %+v", errs)
+	}
+}
+
+func TestReferenceValidatorTeamSegment(t *testing.T) {
+	validator, err := NewReferenceValidator(&ReferenceSchema{TeamSegment: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if errs := validator.Validate("$payments.sec.KPI.availability"); len(errs) != 0 {
+		t.Errorf("expected team-prefixed reference to be accepted, got %+v", errs)
+	}
+	if errs := validator.Validate("sec.KPI.availability"); len(errs) != 0 {
+		t.Errorf("expected plain 3-part reference to still be accepted, got %+v", errs)
+	}
+}
+
+func TestReferenceValidatorRejectsInvalidSegmentPattern(t *testing.T) {
+	if _, err := NewReferenceValidator(&ReferenceSchema{SegmentPattern: "["}); err == nil {
+		t.Error("expected an error for an invalid segment_pattern regex")
+	}
+}
+
+func TestReferenceValidatorEmptyReference(t *testing.T) {
+	validator, err := NewReferenceValidator(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if errs := validator.Validate(""); len(errs) != 1 {
+		t.Errorf("expected 1 violation for an empty reference, got %+v", errs)
+	}
+}
@@ -6,8 +6,10 @@ import (
 	"fmt"
 	"html"
 	"regexp"
+	"sort"
 	"strings"
 	"text/tabwriter"
+	"text/template"
 	"time"
 
 	"github.com/phpdave11/gofpdf"
@@ -35,9 +37,12 @@ type jsonCategory struct {
 }
 
 type jsonMetric struct {
-	Reference string `json:"reference"`
-	Score     int    `json:"score"`
-	Status    string `json:"status"`
+	Reference  string           `json:"reference"`
+	Score      int              `json:"score"`
+	Status     string           `json:"status"`
+	Confidence float64          `json:"confidence,omitempty"`
+	Stale      bool             `json:"stale,omitempty"`
+	History    []jsonTrendPoint `json:"history,omitempty"`
 }
 
 type jsonCategoryReport struct {
@@ -66,14 +71,110 @@ const (
 type ReportGenerator struct {
 	scoreCalculator *ScoreCalculator
 	labelType       ThresholdLabelType
+	history         *HistoryStore
+	historyProvider HistoryProvider
+	color           bool
+	printReady      bool
+
+	// Now is the clock PDF output uses for its "Report Date" cell. It
+	// defaults to time.Now; tests that need deterministic, golden-file-
+	// comparable PDF output (see internal/pdftest) can pin it to a fixed
+	// time instead.
+	Now func() time.Time
+}
+
+// ReportGeneratorOption configures optional ReportGenerator behavior.
+type ReportGeneratorOption func(*ReportGenerator)
+
+// HistoricalScore is one point in a metric's scored history, as returned by
+// a HistoryProvider: the score and traffic light status it carried at
+// Timestamp.
+type HistoricalScore struct {
+	Timestamp time.Time
+	Score     int
+	Status    TrafficLightStatus
+}
+
+// HistoryProvider supplies a metric's recent scored history so a report can
+// render a trend alongside its current score (see drawMetricSparkline).
+// GetMetricHistory returns up to n points, oldest first.
+type HistoryProvider interface {
+	GetMetricHistory(reference string, n int) []HistoricalScore
+}
+
+// scoreSeriesHistoryProvider adapts a HistoryStore's raw values into scored
+// history by replaying them through a ScoreCalculator's current bands (see
+// ScoreCalculator.CalculateScoreSeries) - the same mechanism metricTrendSuffix
+// and metricHistory already use for their own trend rendering.
+type scoreSeriesHistoryProvider struct {
+	history         *HistoryStore
+	scoreCalculator *ScoreCalculator
+}
+
+// GetMetricHistory implements HistoryProvider.
+func (p *scoreSeriesHistoryProvider) GetMetricHistory(reference string, n int) []HistoricalScore {
+	now := time.Now()
+	series, err := p.scoreCalculator.CalculateScoreSeries(reference, now.AddDate(0, 0, -90), now, 24*time.Hour)
+	if err != nil || len(series) == 0 {
+		return nil
+	}
+
+	if len(series) > n {
+		series = series[len(series)-n:]
+	}
+
+	points := make([]HistoricalScore, 0, len(series))
+	for _, point := range series {
+		points = append(points, HistoricalScore{Timestamp: point.Timestamp, Score: point.Score, Status: point.Status})
+	}
+	return points
+}
+
+// WithTrend attaches a HistoryStore so GenerateOverallReport and
+// GenerateCategoryReport render a per-metric trend alongside each score: a
+// sparkline and 7/30/90-day delta in text/table output, a history array in
+// JSON output, and an inline chart next to each KPI/KRI row in PDF output.
+func WithTrend(history *HistoryStore) ReportGeneratorOption {
+	return func(r *ReportGenerator) {
+		r.history = history
+		r.historyProvider = &scoreSeriesHistoryProvider{history: history, scoreCalculator: r.scoreCalculator}
+	}
+}
+
+// WithColor wraps each status cell in formatOverallReportAsTable and
+// formatCategoryReportAsTable with an ANSI color escape matching its
+// TrafficLightStatus (green/yellow/red), so a table report printed to a
+// TTY is easier to scan at a glance. It has no effect on text, JSON, or PDF
+// output.
+func WithColor(enabled bool) ReportGeneratorOption {
+	return func(r *ReportGenerator) {
+		r.color = enabled
+	}
+}
+
+// WithPrintReady switches PDF status rendering from on-screen RGB
+// "GREEN/YELLOW/RED" text to CMYK spot-colored traffic-light circles (see
+// setupPrintReadySpotColors and drawStatusIndicator), so the resulting PDF
+// reproduces consistent brand inks on an offset press rather than relying
+// on an RGB->CMYK conversion at print time. It has no effect on text,
+// table, or JSON output.
+func WithPrintReady(enabled bool) ReportGeneratorOption {
+	return func(r *ReportGenerator) {
+		r.printReady = enabled
+	}
 }
 
 // NewReportGenerator creates a new ReportGenerator
-func NewReportGenerator(scoreCalculator *ScoreCalculator, labelType ThresholdLabelType) *ReportGenerator {
-	return &ReportGenerator{
+func NewReportGenerator(scoreCalculator *ScoreCalculator, labelType ThresholdLabelType, opts ...ReportGeneratorOption) *ReportGenerator {
+	r := &ReportGenerator{
 		scoreCalculator: scoreCalculator,
 		labelType:       labelType,
+		Now:             time.Now,
+	}
+	for _, opt := range opts {
+		opt(r)
 	}
+	return r
 }
 
 // ReportFormat defines the format of the report
@@ -84,6 +185,26 @@ const (
 	JSONFormat  ReportFormat = "json"
 	TableFormat ReportFormat = "table"
 	PDFFormat   ReportFormat = "pdf"
+	// TemplateFormat renders a report through a user-supplied Go template
+	// (see GenerateOverallReportWithTemplate), mirroring kubectl's
+	// -o go-template=.
+	TemplateFormat ReportFormat = "template"
+	// JSONPathFormat extracts values from a report via a JSONPath
+	// expression (see GenerateOverallReportWithTemplate), mirroring
+	// kubectl's -o jsonpath=.
+	JSONPathFormat ReportFormat = "jsonpath"
+	// PrometheusFormat renders a report in the Prometheus/OpenMetrics text
+	// exposition format, for scraping by a textfile collector or an
+	// embedded /metrics HTTP handler (see formatOverallReportAsPrometheus).
+	PrometheusFormat ReportFormat = "prometheus"
+	// CSVFormat renders a report as two CSV tables (category summary, then
+	// detailed metrics) separated by a blank line, for spreadsheet import
+	// (see formatOverallReportAsCSV).
+	CSVFormat ReportFormat = "csv"
+	// MarkdownFormat renders a report as GitHub-flavored Markdown tables,
+	// suitable for pasting into a PR description or wiki page (see
+	// formatOverallReportAsMarkdown).
+	MarkdownFormat ReportFormat = "markdown"
 )
 
 // ReportOutput represents the output of a report generation
@@ -118,6 +239,15 @@ func (r *ReportGenerator) GenerateOverallReport(format ReportFormat) (*ReportOut
 			return nil, err
 		}
 		return &ReportOutput{Content: content, ContentType: "binary"}, nil
+	case PrometheusFormat:
+		content := r.formatOverallReportAsPrometheus(overallScore)
+		return &ReportOutput{Content: []byte(content), ContentType: "text"}, nil
+	case CSVFormat:
+		content := r.formatOverallReportAsCSV(overallScore)
+		return &ReportOutput{Content: []byte(content), ContentType: "text"}, nil
+	case MarkdownFormat:
+		content := r.formatOverallReportAsMarkdown(overallScore)
+		return &ReportOutput{Content: []byte(content), ContentType: "text"}, nil
 	default:
 		return nil, fmt.Errorf("unsupported report format: %s", format)
 	}
@@ -149,11 +279,137 @@ func (r *ReportGenerator) GenerateCategoryReport(categoryID string, format Repor
 			return nil, err
 		}
 		return &ReportOutput{Content: content, ContentType: "binary"}, nil
+	case PrometheusFormat:
+		content := r.formatCategoryReportAsPrometheus(categoryScore)
+		return &ReportOutput{Content: []byte(content), ContentType: "text"}, nil
+	case CSVFormat:
+		content := r.formatCategoryReportAsCSV(categoryScore)
+		return &ReportOutput{Content: []byte(content), ContentType: "text"}, nil
+	case MarkdownFormat:
+		content := r.formatCategoryReportAsMarkdown(categoryScore)
+		return &ReportOutput{Content: []byte(content), ContentType: "text"}, nil
 	default:
 		return nil, fmt.Errorf("unsupported report format: %s", format)
 	}
 }
 
+// TemplateReportData is the value GenerateOverallReportWithTemplate executes
+// tmpl against: the computed OverallScore (embedded, so its fields are
+// addressed directly, e.g. {{.KPIScore}}) plus each category's weight
+// percentage, keyed by category ID, since weights live in LeversConfig
+// rather than on CategoryScore itself.
+type TemplateReportData struct {
+	OverallScore
+	WeightPercent map[string]int
+}
+
+// CategoryTemplateData is the value GenerateCategoryReportWithTemplate
+// executes tmpl against; see TemplateReportData.
+type CategoryTemplateData struct {
+	CategoryScore
+	WeightPercent int
+}
+
+// templateFuncs returns the functions available inside a report template,
+// used by GenerateOverallReportWithTemplate/
+// GenerateCategoryReportWithTemplate's TemplateFormat path: statusColor maps
+// a status to its ANSI color escape (the same one colorizeStatus uses),
+// weightPercent resolves a category's configured weight share, and
+// metricType extracts "KPI"/"KRI" from a metric reference.
+func (r *ReportGenerator) templateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"statusColor": ansiStatusColor,
+		"weightPercent": func(categoryID string) int {
+			return r.categoryWeightPercent(categoryID, len(r.scoreCalculator.metricsProcessor.GetAllCategories()))
+		},
+		"metricType": func(reference string) string {
+			metricType, _ := GetMetricType(reference)
+			return metricType
+		},
+	}
+}
+
+// GenerateOverallReportWithTemplate computes the overall score and renders
+// it via tmpl, a Go template (format == TemplateFormat) or a JSONPath
+// expression (format == JSONPathFormat). This lets callers script custom
+// rollups (e.g. only red KRIs, only categories over a weight threshold)
+// without shell-parsing the JSON report.
+func (r *ReportGenerator) GenerateOverallReportWithTemplate(tmpl string, format ReportFormat) (*ReportOutput, error) {
+	overallScore, err := r.scoreCalculator.CalculateOverallScore()
+	if err != nil {
+		return nil, err
+	}
+
+	switch format {
+	case TemplateFormat:
+		weightPercent := make(map[string]int, len(overallScore.Categories))
+		for _, category := range overallScore.Categories {
+			weightPercent[category.ID] = r.categoryWeightPercent(category.ID, len(overallScore.Categories))
+		}
+		data := TemplateReportData{OverallScore: *overallScore, WeightPercent: weightPercent}
+		content, err := r.renderTemplate(tmpl, data)
+		if err != nil {
+			return nil, err
+		}
+		return &ReportOutput{Content: []byte(content), ContentType: "text"}, nil
+	case JSONPathFormat:
+		content, err := queryJSONPath(r.buildJSONReport(overallScore), tmpl)
+		if err != nil {
+			return nil, err
+		}
+		return &ReportOutput{Content: []byte(content), ContentType: "text"}, nil
+	default:
+		return nil, fmt.Errorf("unsupported template report format: %s", format)
+	}
+}
+
+// GenerateCategoryReportWithTemplate is the category-scoped equivalent of
+// GenerateOverallReportWithTemplate; see its doc comment.
+func (r *ReportGenerator) GenerateCategoryReportWithTemplate(categoryID, tmpl string, format ReportFormat) (*ReportOutput, error) {
+	categoryScore, err := r.scoreCalculator.CalculateCategoryScore(categoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch format {
+	case TemplateFormat:
+		totalCategories := len(r.scoreCalculator.metricsProcessor.GetAllCategories())
+		data := CategoryTemplateData{
+			CategoryScore: *categoryScore,
+			WeightPercent: r.categoryWeightPercent(categoryScore.ID, totalCategories),
+		}
+		content, err := r.renderTemplate(tmpl, data)
+		if err != nil {
+			return nil, err
+		}
+		return &ReportOutput{Content: []byte(content), ContentType: "text"}, nil
+	case JSONPathFormat:
+		content, err := queryJSONPath(r.buildJSONCategoryReport(categoryScore), tmpl)
+		if err != nil {
+			return nil, err
+		}
+		return &ReportOutput{Content: []byte(content), ContentType: "text"}, nil
+	default:
+		return nil, fmt.Errorf("unsupported template report format: %s", format)
+	}
+}
+
+// renderTemplate parses tmpl with templateFuncs registered and executes it
+// against data.
+func (r *ReportGenerator) renderTemplate(tmpl string, data interface{}) (string, error) {
+	t, err := template.New("report").Funcs(r.templateFuncs()).Parse(tmpl)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse report template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to execute report template: %w", err)
+	}
+
+	return buf.String(), nil
+}
+
 // sanitizeString sanitizes a string for safe output
 func sanitizeString(input string) string {
 	// Remove any control characters
@@ -183,15 +439,7 @@ func (r *ReportGenerator) formatOverallReportAsText(score *OverallScore) string
 	sb.WriteString("Category Scores:\n")
 	sb.WriteString("----------------\n")
 	for _, category := range score.Categories {
-		// Get the weight for this category
-		weight, exists := r.scoreCalculator.metricsProcessor.leversConfig.Weights.Categories[category.ID]
-		if !exists {
-			// Use equal weights if not specified
-			weight = 1.0 / float64(len(score.Categories))
-		}
-
-		// Format weight as percentage
-		weightPercentage := int(weight * 100)
+		weightPercentage := r.categoryWeightPercent(category.ID, len(score.Categories))
 
 		sb.WriteString(fmt.Sprintf("- %s (weight: %d%%):\n", sanitizeString(category.Name), weightPercentage))
 		sb.WriteString(fmt.Sprintf("  KPI: %d (%s), KRI: %d (%s)\n",
@@ -201,6 +449,7 @@ func (r *ReportGenerator) formatOverallReportAsText(score *OverallScore) string
 
 	sb.WriteString("\nDetailed Metrics:\n")
 	sb.WriteString("----------------\n")
+	now := time.Now()
 	for _, category := range score.Categories {
 		sb.WriteString(fmt.Sprintf("\n%s:\n", sanitizeString(category.Name)))
 		for _, metric := range category.Metrics {
@@ -208,7 +457,7 @@ func (r *ReportGenerator) formatOverallReportAsText(score *OverallScore) string
 			if len(parts) == 3 {
 				metricType := parts[1]
 				metricID := parts[2]
-				sb.WriteString(fmt.Sprintf("  - %s %s: %d (%s)\n", sanitizeString(metricType), sanitizeString(metricID), metric.Score, r.formatStatus(metric.Status)))
+				sb.WriteString(fmt.Sprintf("  - %s %s: %d (%s)%s%s\n", sanitizeString(metricType), sanitizeString(metricID), metric.Score, r.formatStatus(metric.Status), staleSuffix(metric), r.metricTrendSuffix(metric, now)))
 			}
 		}
 	}
@@ -220,20 +469,8 @@ func (r *ReportGenerator) formatOverallReportAsText(score *OverallScore) string
 func (r *ReportGenerator) formatCategoryReportAsText(score *CategoryScore) string {
 	var sb strings.Builder
 
-	// Get the weight for this category
-	weight, exists := r.scoreCalculator.metricsProcessor.leversConfig.Weights.Categories[score.ID]
-	if !exists {
-		// Use equal weights if not specified
-		totalCategories := len(r.scoreCalculator.metricsProcessor.GetAllCategories())
-		if totalCategories > 0 {
-			weight = 1.0 / float64(totalCategories)
-		} else {
-			weight = 1.0
-		}
-	}
-
-	// Format weight as percentage
-	weightPercentage := int(weight * 100)
+	totalCategories := len(r.scoreCalculator.metricsProcessor.GetAllCategories())
+	weightPercentage := r.categoryWeightPercent(score.ID, totalCategories)
 
 	sb.WriteString(fmt.Sprintf("===== %s REPORT (WEIGHT: %d%%) =====\n\n", strings.ToUpper(sanitizeString(score.Name)), weightPercentage))
 	sb.WriteString(fmt.Sprintf("KPI Score: %d (%s)\n", score.KPIScore, r.formatStatus(score.KPIStatus)))
@@ -243,21 +480,8 @@ func (r *ReportGenerator) formatCategoryReportAsText(score *CategoryScore) strin
 	sb.WriteString("Metrics:\n")
 	sb.WriteString("--------\n")
 
-	// Group metrics by type
-	var kpiMetrics []MetricScore
-	var kriMetrics []MetricScore
-
-	for _, metric := range score.Metrics {
-		parts := strings.Split(metric.Reference, ".")
-		if len(parts) == 3 {
-			metricType := parts[1]
-			if metricType == "KPI" {
-				kpiMetrics = append(kpiMetrics, metric)
-			} else if metricType == "KRI" {
-				kriMetrics = append(kriMetrics, metric)
-			}
-		}
-	}
+	kpiMetrics, kriMetrics := splitMetricsByType(score.Metrics)
+	now := time.Now()
 
 	// Display KPIs
 	if len(kpiMetrics) > 0 {
@@ -266,7 +490,7 @@ func (r *ReportGenerator) formatCategoryReportAsText(score *CategoryScore) strin
 			parts := strings.Split(metric.Reference, ".")
 			if len(parts) == 3 {
 				metricID := parts[2]
-				sb.WriteString(fmt.Sprintf("- KPI %s: %d (%s)\n", sanitizeString(metricID), metric.Score, r.formatStatus(metric.Status)))
+				sb.WriteString(fmt.Sprintf("- KPI %s: %d (%s)%s%s\n", sanitizeString(metricID), metric.Score, r.formatStatus(metric.Status), staleSuffix(metric), r.metricTrendSuffix(metric, now)))
 			}
 		}
 	}
@@ -278,7 +502,7 @@ func (r *ReportGenerator) formatCategoryReportAsText(score *CategoryScore) strin
 			parts := strings.Split(metric.Reference, ".")
 			if len(parts) == 3 {
 				metricID := parts[2]
-				sb.WriteString(fmt.Sprintf("- KRI %s: %d (%s)\n", sanitizeString(metricID), metric.Score, r.formatStatus(metric.Status)))
+				sb.WriteString(fmt.Sprintf("- KRI %s: %d (%s)%s%s\n", sanitizeString(metricID), metric.Score, r.formatStatus(metric.Status), staleSuffix(metric), r.metricTrendSuffix(metric, now)))
 			}
 		}
 	}
@@ -288,32 +512,41 @@ func (r *ReportGenerator) formatCategoryReportAsText(score *CategoryScore) strin
 
 // formatOverallReportAsJSON formats the overall report as JSON
 func (r *ReportGenerator) formatOverallReportAsJSON(score *OverallScore) (string, error) {
+	report := r.buildJSONReport(score)
+
+	jsonData, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal report to JSON: %w", err)
+	}
+
+	return string(jsonData), nil
+}
+
+// buildJSONReport assembles the jsonReport struct formatOverallReportAsJSON
+// marshals, factored out so GenerateOverallReportWithTemplate's
+// JSONPathFormat path can query the exact same field names/shape without
+// duplicating this logic.
+func (r *ReportGenerator) buildJSONReport(score *OverallScore) jsonReport {
+	now := time.Now()
 
 	var categories []jsonCategory
 	for _, category := range score.Categories {
 		var metrics []jsonMetric
 		for _, metric := range category.Metrics {
 			metrics = append(metrics, jsonMetric{
-				Reference: sanitizeString(metric.Reference),
-				Score:     metric.Score,
-				Status:    string(metric.Status),
+				Reference:  sanitizeString(metric.Reference),
+				Score:      metric.Score,
+				Status:     string(metric.Status),
+				Confidence: metric.Confidence,
+				Stale:      metric.Stale,
+				History:    r.metricHistory(metric, now),
 			})
 		}
 
-		// Get the weight for this category
-		weight, exists := r.scoreCalculator.metricsProcessor.leversConfig.Weights.Categories[category.ID]
-		if !exists {
-			// Use equal weights if not specified
-			weight = 1.0 / float64(len(score.Categories))
-		}
-
-		// Format weight as percentage
-		weightPercentage := int(weight * 100)
-
 		categories = append(categories, jsonCategory{
 			ID:            sanitizeString(category.ID),
 			Name:          sanitizeString(category.Name),
-			WeightPercent: weightPercentage,
+			WeightPercent: r.categoryWeightPercent(category.ID, len(score.Categories)),
 			KPIScore:      category.KPIScore,
 			KRIScore:      category.KRIScore,
 			KPIStatus:     string(category.KPIStatus),
@@ -322,7 +555,7 @@ func (r *ReportGenerator) formatOverallReportAsJSON(score *OverallScore) (string
 		})
 	}
 
-	report := jsonReport{
+	return jsonReport{
 		ReportDate: time.Now().Format(time.RFC3339),
 		KPIScore:   score.KPIScore,
 		KRIScore:   score.KRIScore,
@@ -330,6 +563,11 @@ func (r *ReportGenerator) formatOverallReportAsJSON(score *OverallScore) (string
 		KRIStatus:  string(score.KRIStatus),
 		Categories: categories,
 	}
+}
+
+// formatCategoryReportAsJSON formats a category report as JSON
+func (r *ReportGenerator) formatCategoryReportAsJSON(score *CategoryScore) (string, error) {
+	report := r.buildJSONCategoryReport(score)
 
 	jsonData, err := json.MarshalIndent(report, "", "  ")
 	if err != nil {
@@ -339,51 +577,157 @@ func (r *ReportGenerator) formatOverallReportAsJSON(score *OverallScore) (string
 	return string(jsonData), nil
 }
 
-// formatCategoryReportAsJSON formats a category report as JSON
-func (r *ReportGenerator) formatCategoryReportAsJSON(score *CategoryScore) (string, error) {
+// buildJSONCategoryReport assembles the jsonCategoryReport struct
+// formatCategoryReportAsJSON marshals; see buildJSONReport.
+func (r *ReportGenerator) buildJSONCategoryReport(score *CategoryScore) jsonCategoryReport {
+	now := time.Now()
 
 	var metrics []jsonMetric
 	for _, metric := range score.Metrics {
 		metrics = append(metrics, jsonMetric{
-			Reference: sanitizeString(metric.Reference),
-			Score:     metric.Score,
-			Status:    string(metric.Status),
+			Reference:  sanitizeString(metric.Reference),
+			Score:      metric.Score,
+			Status:     string(metric.Status),
+			Confidence: metric.Confidence,
+			Stale:      metric.Stale,
+			History:    r.metricHistory(metric, now),
 		})
 	}
 
-	// Get the weight for this category
-	weight, exists := r.scoreCalculator.metricsProcessor.leversConfig.Weights.Categories[score.ID]
-	if !exists {
-		// Use equal weights if not specified
-		totalCategories := len(r.scoreCalculator.metricsProcessor.GetAllCategories())
-		if totalCategories > 0 {
-			weight = 1.0 / float64(totalCategories)
-		} else {
-			weight = 1.0
-		}
-	}
+	totalCategories := len(r.scoreCalculator.metricsProcessor.GetAllCategories())
 
-	// Format weight as percentage
-	weightPercentage := int(weight * 100)
-
-	report := jsonCategoryReport{
+	return jsonCategoryReport{
 		ReportDate:    time.Now().Format(time.RFC3339),
 		CategoryID:    sanitizeString(score.ID),
 		CategoryName:  sanitizeString(score.Name),
-		WeightPercent: weightPercentage,
+		WeightPercent: r.categoryWeightPercent(score.ID, totalCategories),
 		KPIScore:      score.KPIScore,
 		KRIScore:      score.KRIScore,
 		KPIStatus:     string(score.KPIStatus),
 		KRIStatus:     string(score.KRIStatus),
 		Metrics:       metrics,
 	}
+}
 
-	jsonData, err := json.MarshalIndent(report, "", "  ")
-	if err != nil {
-		return "", fmt.Errorf("failed to marshal report to JSON: %w", err)
+// categoryWeightPercent resolves categoryID's configured Weights.Categories
+// share as a whole-number percentage, falling back to an equal split across
+// totalCategories when none is configured (matching the same fallback
+// CalculateOverallScore/CalculateCategoryScore use for scoring).
+func (r *ReportGenerator) categoryWeightPercent(categoryID string, totalCategories int) int {
+	weight, exists := r.scoreCalculator.metricsProcessor.leversConfig.Weights.Categories[categoryID]
+	if !exists {
+		if totalCategories > 0 {
+			weight = 1.0 / float64(totalCategories)
+		} else {
+			weight = 1.0
+		}
 	}
+	return int(weight * 100)
+}
 
-	return string(jsonData), nil
+// splitMetricsByType partitions metrics into KPIs and KRIs by parsing each
+// Reference with GetMetricType, preserving order within each group.
+// References that fail to parse are dropped from both groups, the same as
+// the inline `len(parts) == 3` guards this replaces.
+func splitMetricsByType(metrics []MetricScore) (kpiMetrics, kriMetrics []MetricScore) {
+	for _, metric := range metrics {
+		metricType, err := GetMetricType(metric.Reference)
+		if err != nil {
+			continue
+		}
+		switch metricType {
+		case "KPI":
+			kpiMetrics = append(kpiMetrics, metric)
+		case "KRI":
+			kriMetrics = append(kriMetrics, metric)
+		}
+	}
+	return kpiMetrics, kriMetrics
+}
+
+// staleSuffix renders " [STALE]" when metric's Confidence was decayed below
+// its configured value by WeightedScoring's data-age penalty, so a report
+// reader can tell a low aggregate score from an out-of-date one.
+func staleSuffix(metric MetricScore) string {
+	if !metric.Stale {
+		return ""
+	}
+	return fmt.Sprintf(" [STALE confidence=%.2f]", metric.Confidence)
+}
+
+// trendArrow renders a TrendDirection as a single directional glyph.
+func trendArrow(direction TrendDirection) string {
+	switch direction {
+	case TrendImproving:
+		return "↑"
+	case TrendDeclining:
+		return "↓"
+	default:
+		return "→"
+	}
+}
+
+// metricTrendSuffix renders a sparkline, 7/30/90-day value deltas, and a
+// trend arrow for metric, suitable for appending to its line in text/table
+// output. It returns "" when no HistoryStore is attached (WithTrend wasn't
+// used) or no history has been recorded for the metric yet.
+func (r *ReportGenerator) metricTrendSuffix(metric MetricScore, now time.Time) string {
+	if r.history == nil {
+		return ""
+	}
+
+	current, ok, err := r.history.ValueAt(metric.Reference, now)
+	if err != nil || !ok {
+		return ""
+	}
+
+	series, err := r.scoreCalculator.CalculateScoreSeries(metric.Reference, now.AddDate(0, 0, -90), now, 24*time.Hour)
+	if err != nil || len(series) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString(" ")
+	sb.WriteString(sparklineFor(series))
+	for _, days := range []int{7, 30, 90} {
+		past, ok, err := r.history.ValueAt(metric.Reference, now.AddDate(0, 0, -days))
+		if err != nil || !ok {
+			continue
+		}
+		sb.WriteString(fmt.Sprintf(" %dd:%+.2f", days, current-past))
+	}
+	sb.WriteString(" ")
+	sb.WriteString(trendArrow(metric.Trend))
+	if metric.TrendRuleForced {
+		sb.WriteString(" [trend rule]")
+	}
+
+	return sb.String()
+}
+
+// metricHistory returns up to 90 days of {timestamp, value, score, status}
+// points for metric, for embedding in JSON report output. It returns nil
+// when no HistoryStore is attached.
+func (r *ReportGenerator) metricHistory(metric MetricScore, now time.Time) []jsonTrendPoint {
+	if r.history == nil {
+		return nil
+	}
+
+	series, err := r.scoreCalculator.CalculateScoreSeries(metric.Reference, now.AddDate(0, 0, -90), now, 24*time.Hour)
+	if err != nil || len(series) == 0 {
+		return nil
+	}
+
+	points := make([]jsonTrendPoint, 0, len(series))
+	for _, point := range series {
+		points = append(points, jsonTrendPoint{
+			Timestamp: point.Timestamp.Format(time.RFC3339),
+			Value:     point.Value,
+			Score:     point.Score,
+			Status:    string(point.Status),
+		})
+	}
+	return points
 }
 
 // formatStatus formats a traffic light status for display
@@ -414,6 +758,38 @@ func (r *ReportGenerator) formatStatus(status TrafficLightStatus) string {
 	}
 }
 
+// ansiStatusColor maps a TrafficLightStatus to its ANSI foreground color
+// escape code (green/yellow/red), so colorizeStatus doesn't repeat the
+// switch at every call site.
+func ansiStatusColor(status TrafficLightStatus) string {
+	switch status {
+	case Green:
+		return "\x1b[32m"
+	case Yellow:
+		return "\x1b[33m"
+	case Red:
+		return "\x1b[31m"
+	default:
+		return ""
+	}
+}
+
+const ansiReset = "\x1b[0m"
+
+// colorizeStatus wraps label in status's ANSI color escape when the
+// ReportGenerator was built WithColor(true), and returns label unchanged
+// otherwise.
+func (r *ReportGenerator) colorizeStatus(status TrafficLightStatus, label string) string {
+	if !r.color {
+		return label
+	}
+	color := ansiStatusColor(status)
+	if color == "" {
+		return label
+	}
+	return color + label + ansiReset
+}
+
 // formatOverallReportAsTable formats the overall report as a table
 func (r *ReportGenerator) formatOverallReportAsTable(score *OverallScore) string {
 	var buf bytes.Buffer
@@ -422,8 +798,8 @@ func (r *ReportGenerator) formatOverallReportAsTable(score *OverallScore) string
 	// Report header
 	fmt.Fprintln(w, "===== SECURITY POSTURE REPORT =====")
 	fmt.Fprintln(w)
-	fmt.Fprintf(w, "KPI Score:\t%d\t(%s)\n", score.KPIScore, r.formatStatus(score.KPIStatus))
-	fmt.Fprintf(w, "KRI Score:\t%d\t(%s)\n", score.KRIScore, r.formatStatus(score.KRIStatus))
+	fmt.Fprintf(w, "KPI Score:\t%d\t(%s)\n", score.KPIScore, r.colorizeStatus(score.KPIStatus, r.formatStatus(score.KPIStatus)))
+	fmt.Fprintf(w, "KRI Score:\t%d\t(%s)\n", score.KRIScore, r.colorizeStatus(score.KRIStatus, r.formatStatus(score.KRIStatus)))
 	fmt.Fprintf(w, "Report Date:\t%s\n", time.Now().Format("2006-01-02 15:04:05"))
 	fmt.Fprintln(w)
 
@@ -433,23 +809,15 @@ func (r *ReportGenerator) formatOverallReportAsTable(score *OverallScore) string
 	fmt.Fprintln(w, "--------\t------\t---------\t----------\t---------\t----------")
 
 	for _, category := range score.Categories {
-		// Get the weight for this category
-		weight, exists := r.scoreCalculator.metricsProcessor.leversConfig.Weights.Categories[category.ID]
-		if !exists {
-			// Use equal weights if not specified
-			weight = 1.0 / float64(len(score.Categories))
-		}
-
-		// Format weight as percentage
-		weightPercentage := int(weight * 100)
+		weightPercentage := r.categoryWeightPercent(category.ID, len(score.Categories))
 
 		fmt.Fprintf(w, "%s\t%d%%\t%d\t%s\t%d\t%s\n",
 			sanitizeString(category.Name),
 			weightPercentage,
 			category.KPIScore,
-			r.formatStatus(category.KPIStatus),
+			r.colorizeStatus(category.KPIStatus, r.formatStatus(category.KPIStatus)),
 			category.KRIScore,
-			r.formatStatus(category.KRIStatus))
+			r.colorizeStatus(category.KRIStatus, r.formatStatus(category.KRIStatus)))
 	}
 	fmt.Fprintln(w)
 
@@ -469,7 +837,7 @@ func (r *ReportGenerator) formatOverallReportAsTable(score *OverallScore) string
 					sanitizeString(metricType),
 					sanitizeString(metricID),
 					metric.Score,
-					r.formatStatus(metric.Status))
+					r.colorizeStatus(metric.Status, r.formatStatus(metric.Status)))
 			}
 		}
 	}
@@ -483,44 +851,18 @@ func (r *ReportGenerator) formatCategoryReportAsTable(score *CategoryScore) stri
 	var buf bytes.Buffer
 	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
 
-	// Get the weight for this category
-	weight, exists := r.scoreCalculator.metricsProcessor.leversConfig.Weights.Categories[score.ID]
-	if !exists {
-		// Use equal weights if not specified
-		totalCategories := len(r.scoreCalculator.metricsProcessor.GetAllCategories())
-		if totalCategories > 0 {
-			weight = 1.0 / float64(totalCategories)
-		} else {
-			weight = 1.0
-		}
-	}
-
-	// Format weight as percentage
-	weightPercentage := int(weight * 100)
+	totalCategories := len(r.scoreCalculator.metricsProcessor.GetAllCategories())
+	weightPercentage := r.categoryWeightPercent(score.ID, totalCategories)
 
 	// Report header
 	fmt.Fprintf(w, "===== %s REPORT (WEIGHT: %d%%) =====\n", strings.ToUpper(sanitizeString(score.Name)), weightPercentage)
 	fmt.Fprintln(w)
-	fmt.Fprintf(w, "KPI Score:\t%d\t(%s)\n", score.KPIScore, r.formatStatus(score.KPIStatus))
-	fmt.Fprintf(w, "KRI Score:\t%d\t(%s)\n", score.KRIScore, r.formatStatus(score.KRIStatus))
+	fmt.Fprintf(w, "KPI Score:\t%d\t(%s)\n", score.KPIScore, r.colorizeStatus(score.KPIStatus, r.formatStatus(score.KPIStatus)))
+	fmt.Fprintf(w, "KRI Score:\t%d\t(%s)\n", score.KRIScore, r.colorizeStatus(score.KRIStatus, r.formatStatus(score.KRIStatus)))
 	fmt.Fprintf(w, "Report Date:\t%s\n", time.Now().Format("2006-01-02 15:04:05"))
 	fmt.Fprintln(w)
 
-	// Group metrics by type
-	var kpiMetrics []MetricScore
-	var kriMetrics []MetricScore
-
-	for _, metric := range score.Metrics {
-		parts := strings.Split(metric.Reference, ".")
-		if len(parts) == 3 {
-			metricType := parts[1]
-			if metricType == "KPI" {
-				kpiMetrics = append(kpiMetrics, metric)
-			} else if metricType == "KRI" {
-				kriMetrics = append(kriMetrics, metric)
-			}
-		}
-	}
+	kpiMetrics, kriMetrics := splitMetricsByType(score.Metrics)
 
 	// Display metrics table
 	fmt.Fprintln(w, "METRICS:")
@@ -535,7 +877,7 @@ func (r *ReportGenerator) formatCategoryReportAsTable(score *CategoryScore) stri
 			fmt.Fprintf(w, "KPI\t%s\t%d\t%s\n",
 				sanitizeString(metricID),
 				metric.Score,
-				r.formatStatus(metric.Status))
+				r.colorizeStatus(metric.Status, r.formatStatus(metric.Status)))
 		}
 	}
 
@@ -547,7 +889,7 @@ func (r *ReportGenerator) formatCategoryReportAsTable(score *CategoryScore) stri
 			fmt.Fprintf(w, "KRI\t%s\t%d\t%s\n",
 				sanitizeString(metricID),
 				metric.Score,
-				r.formatStatus(metric.Status))
+				r.colorizeStatus(metric.Status, r.formatStatus(metric.Status)))
 		}
 	}
 
@@ -559,6 +901,9 @@ func (r *ReportGenerator) formatCategoryReportAsTable(score *CategoryScore) stri
 func (r *ReportGenerator) formatOverallReportAsPDF(score *OverallScore) ([]byte, error) {
 	pdf := gofpdf.New("P", "mm", "A4", "")
 	pdf.AddPage()
+	if r.printReady {
+		r.setupPrintReadySpotColors(pdf)
+	}
 
 	// Set up fonts
 	pdf.SetFont("Arial", "B", 16)
@@ -580,7 +925,7 @@ func (r *ReportGenerator) formatOverallReportAsPDF(score *OverallScore) ([]byte,
 	pdf.Ln(10)
 
 	pdf.CellFormat(40, 10, "Report Date:", "", 0, "", false, 0, "")
-	pdf.CellFormat(60, 10, time.Now().Format("2006-01-02 15:04:05"), "", 0, "", false, 0, "")
+	pdf.CellFormat(60, 10, r.Now().Format("2006-01-02 15:04:05"), "", 0, "", false, 0, "")
 	pdf.Ln(15)
 
 	// Category scores table
@@ -605,15 +950,7 @@ func (r *ReportGenerator) formatOverallReportAsPDF(score *OverallScore) ([]byte,
 	// Table rows
 	pdf.SetFont("Arial", "", 10)
 	for _, category := range score.Categories {
-		// Get the weight for this category
-		weight, exists := r.scoreCalculator.metricsProcessor.leversConfig.Weights.Categories[category.ID]
-		if !exists {
-			// Use equal weights if not specified
-			weight = 1.0 / float64(len(score.Categories))
-		}
-
-		// Format weight as percentage
-		weightPercentage := int(weight * 100)
+		weightPercentage := r.categoryWeightPercent(category.ID, len(score.Categories))
 
 		// Draw the row cells
 		pdf.CellFormat(colWidths[0], 10, sanitizeString(category.Name), "1", 0, "L", false, 0, "")
@@ -621,45 +958,13 @@ func (r *ReportGenerator) formatOverallReportAsPDF(score *OverallScore) ([]byte,
 		pdf.CellFormat(colWidths[2], 10, fmt.Sprintf("%d", category.KPIScore), "1", 0, "C", false, 0, "")
 
 		// KPI Status
-		statusText := ""
-		switch category.KPIStatus {
-		case Green:
-			pdf.SetTextColor(0, 128, 0) // Dark green
-			statusText = "GREEN"
-		case Yellow:
-			pdf.SetTextColor(255, 165, 0) // Orange
-			statusText = "YELLOW"
-		case Red:
-			pdf.SetTextColor(255, 0, 0) // Red
-			statusText = "RED"
-		default:
-			pdf.SetTextColor(128, 128, 128) // Gray
-			statusText = "UNKNOWN"
-		}
-		pdf.CellFormat(colWidths[3], 10, statusText, "1", 0, "C", false, 0, "")
-		pdf.SetTextColor(0, 0, 0) // Reset to black
+		r.renderPDFStatus(pdf, colWidths[3], 10, category.KPIStatus, "1", 0)
 
 		// KRI Score and Status
 		pdf.CellFormat(colWidths[4], 10, fmt.Sprintf("%d", category.KRIScore), "1", 0, "C", false, 0, "")
 
 		// KRI Status
-		statusText = ""
-		switch category.KRIStatus {
-		case Green:
-			pdf.SetTextColor(0, 128, 0) // Dark green
-			statusText = "GREEN"
-		case Yellow:
-			pdf.SetTextColor(255, 165, 0) // Orange
-			statusText = "YELLOW"
-		case Red:
-			pdf.SetTextColor(255, 0, 0) // Red
-			statusText = "RED"
-		default:
-			pdf.SetTextColor(128, 128, 128) // Gray
-			statusText = "UNKNOWN"
-		}
-		pdf.CellFormat(colWidths[5], 10, statusText, "1", 1, "C", false, 0, "")
-		pdf.SetTextColor(0, 0, 0) // Reset to black
+		r.renderPDFStatus(pdf, colWidths[5], 10, category.KRIStatus, "1", 1)
 	}
 
 	pdf.Ln(15)
@@ -674,13 +979,14 @@ func (r *ReportGenerator) formatOverallReportAsPDF(score *OverallScore) ([]byte,
 	pdf.SetFont("Arial", "B", 10)
 
 	// Define table dimensions for detailed metrics
-	detailColWidths := []float64{60, 30, 30, 30, 40}
+	detailColWidths := []float64{55, 25, 25, 25, 30, sparklineChartWidth}
 
 	pdf.CellFormat(detailColWidths[0], 10, "Category", "1", 0, "C", true, 0, "")
 	pdf.CellFormat(detailColWidths[1], 10, "Metric Type", "1", 0, "C", true, 0, "")
 	pdf.CellFormat(detailColWidths[2], 10, "Metric ID", "1", 0, "C", true, 0, "")
 	pdf.CellFormat(detailColWidths[3], 10, "Score", "1", 0, "C", true, 0, "")
-	pdf.CellFormat(detailColWidths[4], 10, "Status", "1", 1, "C", true, 0, "")
+	pdf.CellFormat(detailColWidths[4], 10, "Status", "1", 0, "C", true, 0, "")
+	pdf.CellFormat(detailColWidths[5], 10, "Trend", "1", 1, "C", true, 0, "")
 
 	// Table rows
 	pdf.SetFont("Arial", "", 10)
@@ -698,23 +1004,11 @@ func (r *ReportGenerator) formatOverallReportAsPDF(score *OverallScore) ([]byte,
 				pdf.CellFormat(detailColWidths[3], 10, fmt.Sprintf("%d", metric.Score), "1", 0, "C", false, 0, "")
 
 				// Status
-				statusText := ""
-				switch metric.Status {
-				case Green:
-					pdf.SetTextColor(0, 128, 0) // Dark green
-					statusText = "GREEN"
-				case Yellow:
-					pdf.SetTextColor(255, 165, 0) // Orange
-					statusText = "YELLOW"
-				case Red:
-					pdf.SetTextColor(255, 0, 0) // Red
-					statusText = "RED"
-				default:
-					pdf.SetTextColor(128, 128, 128) // Gray
-					statusText = "UNKNOWN"
-				}
-				pdf.CellFormat(detailColWidths[4], 10, statusText, "1", 1, "C", false, 0, "")
-				pdf.SetTextColor(0, 0, 0) // Reset to black
+				r.renderPDFStatus(pdf, detailColWidths[4], 10, metric.Status, "1", 0)
+
+				trendX, trendY := pdf.GetX(), pdf.GetY()
+				pdf.CellFormat(detailColWidths[5], 10, "", "1", 1, "C", false, 0, "")
+				r.drawMetricSparkline(pdf, trendX, trendY, detailColWidths[5], sparklineChartHeight, metric.Reference)
 			}
 		}
 	}
@@ -740,21 +1034,12 @@ func (r *ReportGenerator) formatOverallReportAsPDF(score *OverallScore) ([]byte,
 func (r *ReportGenerator) formatCategoryReportAsPDF(score *CategoryScore) ([]byte, error) {
 	pdf := gofpdf.New("P", "mm", "A4", "")
 	pdf.AddPage()
-
-	// Get the weight for this category
-	weight, exists := r.scoreCalculator.metricsProcessor.leversConfig.Weights.Categories[score.ID]
-	if !exists {
-		// Use equal weights if not specified
-		totalCategories := len(r.scoreCalculator.metricsProcessor.GetAllCategories())
-		if totalCategories > 0 {
-			weight = 1.0 / float64(totalCategories)
-		} else {
-			weight = 1.0
-		}
+	if r.printReady {
+		r.setupPrintReadySpotColors(pdf)
 	}
 
-	// Format weight as percentage
-	weightPercentage := int(weight * 100)
+	totalCategories := len(r.scoreCalculator.metricsProcessor.GetAllCategories())
+	weightPercentage := r.categoryWeightPercent(score.ID, totalCategories)
 
 	// Set up fonts
 	pdf.SetFont("Arial", "B", 16)
@@ -776,24 +1061,10 @@ func (r *ReportGenerator) formatCategoryReportAsPDF(score *CategoryScore) ([]byt
 	pdf.Ln(10)
 
 	pdf.CellFormat(40, 10, "Report Date:", "", 0, "", false, 0, "")
-	pdf.CellFormat(60, 10, time.Now().Format("2006-01-02 15:04:05"), "", 0, "", false, 0, "")
+	pdf.CellFormat(60, 10, r.Now().Format("2006-01-02 15:04:05"), "", 0, "", false, 0, "")
 	pdf.Ln(15)
 
-	// Group metrics by type
-	var kpiMetrics []MetricScore
-	var kriMetrics []MetricScore
-
-	for _, metric := range score.Metrics {
-		parts := strings.Split(metric.Reference, ".")
-		if len(parts) == 3 {
-			metricType := parts[1]
-			if metricType == "KPI" {
-				kpiMetrics = append(kpiMetrics, metric)
-			} else if metricType == "KRI" {
-				kriMetrics = append(kriMetrics, metric)
-			}
-		}
-	}
+	kpiMetrics, kriMetrics := splitMetricsByType(score.Metrics)
 
 	// Metrics table
 	pdf.SetFont("Arial", "B", 12)
@@ -805,12 +1076,13 @@ func (r *ReportGenerator) formatCategoryReportAsPDF(score *CategoryScore) ([]byt
 	pdf.SetFont("Arial", "B", 10)
 
 	// Define table dimensions
-	colWidths := []float64{30, 60, 30, 70}
+	colWidths := []float64{30, 60, 30, 40, sparklineChartWidth}
 
 	pdf.CellFormat(colWidths[0], 10, "Type", "1", 0, "C", true, 0, "")
 	pdf.CellFormat(colWidths[1], 10, "ID", "1", 0, "C", true, 0, "")
 	pdf.CellFormat(colWidths[2], 10, "Score", "1", 0, "C", true, 0, "")
-	pdf.CellFormat(colWidths[3], 10, "Status", "1", 1, "C", true, 0, "")
+	pdf.CellFormat(colWidths[3], 10, "Status", "1", 0, "C", true, 0, "")
+	pdf.CellFormat(colWidths[4], 10, "Trend", "1", 1, "C", true, 0, "")
 
 	// Table rows for KPIs
 	pdf.SetFont("Arial", "", 10)
@@ -825,23 +1097,11 @@ func (r *ReportGenerator) formatCategoryReportAsPDF(score *CategoryScore) ([]byt
 			pdf.CellFormat(colWidths[2], 10, fmt.Sprintf("%d", metric.Score), "1", 0, "C", false, 0, "")
 
 			// Status
-			statusText := ""
-			switch metric.Status {
-			case Green:
-				pdf.SetTextColor(0, 128, 0) // Dark green
-				statusText = "GREEN"
-			case Yellow:
-				pdf.SetTextColor(255, 165, 0) // Orange
-				statusText = "YELLOW"
-			case Red:
-				pdf.SetTextColor(255, 0, 0) // Red
-				statusText = "RED"
-			default:
-				pdf.SetTextColor(128, 128, 128) // Gray
-				statusText = "UNKNOWN"
-			}
-			pdf.CellFormat(colWidths[3], 10, statusText, "1", 1, "C", false, 0, "")
-			pdf.SetTextColor(0, 0, 0) // Reset to black
+			r.renderPDFStatus(pdf, colWidths[3], 10, metric.Status, "1", 0)
+
+			trendX, trendY := pdf.GetX(), pdf.GetY()
+			pdf.CellFormat(colWidths[4], 10, "", "1", 1, "C", false, 0, "")
+			r.drawMetricSparkline(pdf, trendX, trendY, colWidths[4], sparklineChartHeight, metric.Reference)
 		}
 	}
 
@@ -857,23 +1117,11 @@ func (r *ReportGenerator) formatCategoryReportAsPDF(score *CategoryScore) ([]byt
 			pdf.CellFormat(colWidths[2], 10, fmt.Sprintf("%d", metric.Score), "1", 0, "C", false, 0, "")
 
 			// Status
-			statusText := ""
-			switch metric.Status {
-			case Green:
-				pdf.SetTextColor(0, 128, 0) // Dark green
-				statusText = "GREEN"
-			case Yellow:
-				pdf.SetTextColor(255, 165, 0) // Orange
-				statusText = "YELLOW"
-			case Red:
-				pdf.SetTextColor(255, 0, 0) // Red
-				statusText = "RED"
-			default:
-				pdf.SetTextColor(128, 128, 128) // Gray
-				statusText = "UNKNOWN"
-			}
-			pdf.CellFormat(colWidths[3], 10, statusText, "1", 1, "C", false, 0, "")
-			pdf.SetTextColor(0, 0, 0) // Reset to black
+			r.renderPDFStatus(pdf, colWidths[3], 10, metric.Status, "1", 0)
+
+			trendX, trendY := pdf.GetX(), pdf.GetY()
+			pdf.CellFormat(colWidths[4], 10, "", "1", 1, "C", false, 0, "")
+			r.drawMetricSparkline(pdf, trendX, trendY, colWidths[4], sparklineChartHeight, metric.Reference)
 		}
 	}
 
@@ -896,27 +1144,549 @@ func (r *ReportGenerator) formatCategoryReportAsPDF(score *CategoryScore) ([]byt
 
 // formatPDFStatus formats a traffic light status for display in PDF
 func (r *ReportGenerator) formatPDFStatus(pdf *gofpdf.Fpdf, status TrafficLightStatus) string {
+	r.renderPDFStatus(pdf, 30, 10, status, "", 0)
+	return ""
+}
+
+// setupPrintReadySpotColors registers each traffic light status as a named
+// CMYK spot color. Called once per document when PrintReady mode is
+// enabled, before any status cell is drawn.
+func (r *ReportGenerator) setupPrintReadySpotColors(pdf *gofpdf.Fpdf) {
+	pdf.AddSpotColor("PulseGreen", 80, 0, 100, 0)
+	pdf.AddSpotColor("PulseYellow", 0, 35, 100, 0)
+	pdf.AddSpotColor("PulseRed", 0, 100, 100, 0)
+	pdf.AddSpotColor("PulseGray", 0, 0, 0, 50)
+}
+
+// renderPDFStatus draws a single status cell at the table's current cursor
+// position, honoring PrintReady mode (see WithPrintReady): a CMYK
+// spot-colored circle when enabled, or the existing RGB
+// "GREEN/YELLOW/RED" text otherwise. border and ln are passed straight
+// through to the underlying CellFormat call.
+func (r *ReportGenerator) renderPDFStatus(pdf *gofpdf.Fpdf, width, height float64, status TrafficLightStatus, border string, ln int) {
+	if r.printReady {
+		x, y := pdf.GetX(), pdf.GetY()
+		pdf.CellFormat(width, height, "", border, ln, "C", false, 0, "")
+		diameter := height * 0.6
+		if width < height {
+			diameter = width * 0.6
+		}
+		r.drawStatusIndicator(pdf, x+width/2, y+height/2, diameter, status)
+		return
+	}
+
+	statusText := ""
 	switch status {
 	case Green:
 		pdf.SetTextColor(0, 128, 0) // Dark green
-		// Always use text labels for PDF to avoid encoding issues
-		pdf.CellFormat(30, 10, "GREEN", "", 0, "C", false, 0, "")
-		pdf.SetTextColor(0, 0, 0) // Reset to black
-		return ""
+		statusText = "GREEN"
 	case Yellow:
 		pdf.SetTextColor(255, 165, 0) // Orange
-		pdf.CellFormat(30, 10, "YELLOW", "", 0, "C", false, 0, "")
-		pdf.SetTextColor(0, 0, 0) // Reset to black
-		return ""
+		statusText = "YELLOW"
 	case Red:
 		pdf.SetTextColor(255, 0, 0) // Red
-		pdf.CellFormat(30, 10, "RED", "", 0, "C", false, 0, "")
-		pdf.SetTextColor(0, 0, 0) // Reset to black
-		return ""
+		statusText = "RED"
 	default:
 		pdf.SetTextColor(128, 128, 128) // Gray
-		pdf.CellFormat(30, 10, "UNKNOWN", "", 0, "C", false, 0, "")
-		pdf.SetTextColor(0, 0, 0) // Reset to black
+		statusText = "UNKNOWN"
+	}
+	pdf.CellFormat(width, height, statusText, border, ln, "C", false, 0, "")
+	pdf.SetTextColor(0, 0, 0) // Reset to black
+}
+
+// drawStatusIndicator renders a traffic-light dot for status, centered at
+// (cx, cy) with the given diameter: a spot color (see
+// setupPrintReadySpotColors) clipped to a circle and filled with a radial
+// gradient, so the printed circle reproduces the exact brand ink an offset
+// press expects rather than an RGB approximation of it.
+func (r *ReportGenerator) drawStatusIndicator(pdf *gofpdf.Fpdf, cx, cy, diameter float64, status TrafficLightStatus) {
+	spotName, highlight, base := printReadySpotColor(status)
+	radius := diameter / 2
+
+	pdf.SetFillSpotColor(spotName, 100)
+	pdf.ClipCircle(cx, cy, radius, false)
+	pdf.RadialGradient(cx-radius, cy-radius, diameter, diameter,
+		highlight[0], highlight[1], highlight[2],
+		base[0], base[1], base[2],
+		0.5, 0.5, 0.5, 0.5, 1)
+	pdf.ClipEnd()
+}
+
+// printReadySpotColor maps status to its registered spot color name (see
+// setupPrintReadySpotColors) and the highlight/base RGB pair used for the
+// radial gradient fill inside drawStatusIndicator.
+func printReadySpotColor(status TrafficLightStatus) (name string, highlight, base [3]int) {
+	switch status {
+	case Green:
+		return "PulseGreen", [3]int{200, 255, 200}, [3]int{0, 128, 0}
+	case Yellow:
+		return "PulseYellow", [3]int{255, 240, 200}, [3]int{255, 165, 0}
+	case Red:
+		return "PulseRed", [3]int{255, 200, 200}, [3]int{255, 0, 0}
+	default:
+		return "PulseGray", [3]int{220, 220, 220}, [3]int{128, 128, 128}
+	}
+}
+
+// Dimensions (mm) for the inline sparkline drawn by drawMetricSparkline, and
+// the number of historical points it plots. sparklineChartHeight matches the
+// 10mm row height used throughout the PDF tables.
+const (
+	sparklineChartWidth    = 30.0
+	sparklineChartHeight   = 10.0
+	sparklineHistoryPoints = 8
+)
+
+// drawMetricSparkline draws a small trend chart for reference inside the
+// cell area at (x, y) sized colWidth x rowHeight: a polyline connecting each
+// historical score (normalized to the window's own min/max) with a dot
+// colored per that period's traffic light status. It draws nothing, leaving
+// the already-drawn empty bordered cell intact, when no HistoryProvider is
+// attached (WithTrend wasn't used) or fewer than two points are available.
+func (r *ReportGenerator) drawMetricSparkline(pdf *gofpdf.Fpdf, x, y, colWidth, rowHeight float64, reference string) {
+	if r.historyProvider == nil {
+		return
+	}
+
+	points := r.historyProvider.GetMetricHistory(reference, sparklineHistoryPoints)
+	if len(points) < 2 {
+		return
+	}
+
+	const margin = 1.5
+	plotWidth := colWidth - 2*margin
+	plotHeight := rowHeight - 2*margin
+
+	minScore, maxScore := points[0].Score, points[0].Score
+	for _, p := range points {
+		if p.Score < minScore {
+			minScore = p.Score
+		}
+		if p.Score > maxScore {
+			maxScore = p.Score
+		}
+	}
+	if maxScore == minScore {
+		maxScore = minScore + 1
+	}
+
+	pointX := func(i int) float64 {
+		return x + margin + plotWidth*float64(i)/float64(len(points)-1)
+	}
+	pointY := func(score int) float64 {
+		return y + margin + plotHeight*float64(maxScore-score)/float64(maxScore-minScore)
+	}
+
+	pdf.SetDrawColor(100, 100, 100)
+	for i := 1; i < len(points); i++ {
+		pdf.Line(pointX(i-1), pointY(points[i-1].Score), pointX(i), pointY(points[i].Score))
+	}
+
+	for i, p := range points {
+		switch p.Status {
+		case Green:
+			pdf.SetFillColor(0, 128, 0)
+		case Yellow:
+			pdf.SetFillColor(255, 165, 0)
+		case Red:
+			pdf.SetFillColor(255, 0, 0)
+		default:
+			pdf.SetFillColor(128, 128, 128)
+		}
+		pdf.Circle(pointX(i), pointY(p.Score), 0.6, "F")
+	}
+
+	pdf.SetDrawColor(0, 0, 0)
+	pdf.SetFillColor(255, 255, 255)
+}
+
+// jsonTrendPoint is a single point in a trend report's JSON output.
+type jsonTrendPoint struct {
+	Timestamp string  `json:"timestamp"`
+	Value     float64 `json:"value"`
+	Score     int     `json:"score"`
+	Status    string  `json:"status"`
+}
+
+// jsonTrendReport is the JSON-format output of GenerateTrendReport: the
+// requested period's points plus its score delta against the immediately
+// preceding period of equal length.
+type jsonTrendReport struct {
+	Points        []jsonTrendPoint `json:"points"`
+	PreviousDelta *float64         `json:"previous_period_delta,omitempty"`
+}
+
+// sparklineTicks maps a score, lowest to highest, onto a single Unicode bar
+// character for compact text-mode trend rendering.
+var sparklineTicks = []rune("▁▂▃▄▅▆▇█")
+
+// sparklineFor renders series as a single line of Unicode block characters,
+// one per point, scaled from the lowest to the highest score.
+func sparklineFor(series []ScorePoint) string {
+	if len(series) == 0 {
 		return ""
 	}
+
+	min, max := series[0].Score, series[0].Score
+	for _, point := range series {
+		if point.Score < min {
+			min = point.Score
+		}
+		if point.Score > max {
+			max = point.Score
+		}
+	}
+
+	var sb strings.Builder
+	for _, point := range series {
+		if max == min {
+			sb.WriteRune(sparklineTicks[len(sparklineTicks)-1])
+			continue
+		}
+		ratio := float64(point.Score-min) / float64(max-min)
+		idx := int(ratio * float64(len(sparklineTicks)-1))
+		sb.WriteRune(sparklineTicks[idx])
+	}
+
+	return sb.String()
+}
+
+// averageScore returns the mean score across series, or 0 for an empty series.
+func averageScore(series []ScorePoint) float64 {
+	if len(series) == 0 {
+		return 0
+	}
+	var sum int
+	for _, point := range series {
+		sum += point.Score
+	}
+	return float64(sum) / float64(len(series))
+}
+
+// previousPeriodDelta compares reference's average score over [from, to] to
+// its average score over the immediately preceding period of equal length,
+// [from-(to-from), from] (CalculateScoreSeries includes both ends of a range),
+// so trend reports can answer "is this improving?" rather than only "what
+// is it now?". It returns false when the preceding period has no history.
+func (r *ReportGenerator) previousPeriodDelta(reference string, from, to time.Time, step time.Duration, current []ScorePoint) (float64, bool) {
+	periodLength := to.Sub(from)
+	previous, err := r.scoreCalculator.CalculateScoreSeries(reference, from.Add(-periodLength), from, step)
+	if err != nil || len(previous) == 0 {
+		return 0, false
+	}
+	return averageScore(current) - averageScore(previous), true
+}
+
+// GenerateTrendReport replays a metric's history between from and to and
+// renders it as a sparkline plus a delta against the preceding period of
+// equal length (text/table formats), or an array of
+// {timestamp, value, score, status} points alongside that same delta (JSON
+// format), so users can see how a KPI/KRI moved across the traffic-light
+// statuses over time, and whether it's improving period over period.
+func (r *ReportGenerator) GenerateTrendReport(reference string, from, to time.Time, step time.Duration, format ReportFormat) (*ReportOutput, error) {
+	series, err := r.scoreCalculator.CalculateScoreSeries(reference, from, to, step)
+	if err != nil {
+		return nil, err
+	}
+
+	delta, hasDelta := r.previousPeriodDelta(reference, from, to, step, series)
+
+	switch format {
+	case JSONFormat:
+		points := make([]jsonTrendPoint, 0, len(series))
+		for _, point := range series {
+			points = append(points, jsonTrendPoint{
+				Timestamp: point.Timestamp.Format(time.RFC3339),
+				Value:     point.Value,
+				Score:     point.Score,
+				Status:    string(point.Status),
+			})
+		}
+
+		report := jsonTrendReport{Points: points}
+		if hasDelta {
+			report.PreviousDelta = &delta
+		}
+
+		content, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal trend report: %w", err)
+		}
+		return &ReportOutput{Content: content, ContentType: "text"}, nil
+	case TextFormat, TableFormat:
+		var buf bytes.Buffer
+		fmt.Fprintf(&buf, "Trend: %s (%s to %s)\n", reference, from.Format("2006-01-02"), to.Format("2006-01-02"))
+		if len(series) == 0 {
+			fmt.Fprintln(&buf, "No history recorded for this range.")
+			return &ReportOutput{Content: buf.Bytes(), ContentType: "text"}, nil
+		}
+		fmt.Fprintf(&buf, "%s\n", sparklineFor(series))
+		latest := series[len(series)-1]
+		fmt.Fprintf(&buf, "Latest: %.2f, score %d, status %s\n", latest.Value, latest.Score, r.formatStatus(latest.Status))
+		if hasDelta {
+			fmt.Fprintf(&buf, "Vs previous period: %+.1f avg score\n", delta)
+		}
+		return &ReportOutput{Content: buf.Bytes(), ContentType: "text"}, nil
+	default:
+		return nil, fmt.Errorf("unsupported report format: %s", format)
+	}
+}
+
+// metricDiff describes how a single metric's score changed between two
+// OverallScore snapshots. Added is true when the metric was absent from
+// oldReport, Removed when it's absent from newReport; OldScore/NewScore and
+// OldStatus/NewStatus are meaningless (zero-valued) on the absent side.
+type metricDiff struct {
+	Reference            string
+	OldScore, NewScore   int
+	OldStatus, NewStatus TrafficLightStatus
+	Added, Removed       bool
+}
+
+// categoryDiff describes how a single category's score changed between two
+// OverallScore snapshots, and carries the metricDiff for each of its
+// metrics that was added, removed, or whose score or status changed.
+type categoryDiff struct {
+	ID                   string
+	OldScore, NewScore   int
+	OldStatus, NewStatus TrafficLightStatus
+	Metrics              []metricDiff
+}
+
+// reportDiff is the full result of diffing two OverallScore snapshots: the
+// overall score/status movement plus every category that was added,
+// removed, or changed. A reportDiff with ScoreDelta 0 and no Categories
+// means nothing moved between the two snapshots.
+type reportDiff struct {
+	OldScore, NewScore   int
+	OldStatus, NewStatus TrafficLightStatus
+	ScoreDelta           int
+	Categories           []categoryDiff
+}
+
+// diffMetrics compares the metrics of an old and new category (matched by
+// Reference) and returns a metricDiff for each one that was added, removed,
+// or whose score or status changed. Unchanged metrics are omitted.
+func diffMetrics(oldMetrics, newMetrics []MetricScore) []metricDiff {
+	oldByRef := make(map[string]MetricScore, len(oldMetrics))
+	for _, m := range oldMetrics {
+		oldByRef[m.Reference] = m
+	}
+	newByRef := make(map[string]MetricScore, len(newMetrics))
+	for _, m := range newMetrics {
+		newByRef[m.Reference] = m
+	}
+
+	var diffs []metricDiff
+	for _, ref := range sortedMetricRefs(oldMetrics, newMetrics) {
+		oldMetric, inOld := oldByRef[ref]
+		newMetric, inNew := newByRef[ref]
+
+		switch {
+		case inOld && !inNew:
+			diffs = append(diffs, metricDiff{Reference: ref, OldScore: oldMetric.Score, OldStatus: oldMetric.Status, Removed: true})
+		case !inOld && inNew:
+			diffs = append(diffs, metricDiff{Reference: ref, NewScore: newMetric.Score, NewStatus: newMetric.Status, Added: true})
+		case oldMetric.Score != newMetric.Score || oldMetric.Status != newMetric.Status:
+			diffs = append(diffs, metricDiff{
+				Reference: ref,
+				OldScore:  oldMetric.Score, NewScore: newMetric.Score,
+				OldStatus: oldMetric.Status, NewStatus: newMetric.Status,
+			})
+		}
+	}
+	return diffs
+}
+
+// sortedMetricRefs returns the deduplicated, sorted union of oldMetrics'
+// and newMetrics' References, so diffMetrics produces stable, reviewable
+// output regardless of input ordering.
+func sortedMetricRefs(oldMetrics, newMetrics []MetricScore) []string {
+	seen := make(map[string]bool)
+	var refs []string
+	for _, m := range oldMetrics {
+		if !seen[m.Reference] {
+			seen[m.Reference] = true
+			refs = append(refs, m.Reference)
+		}
+	}
+	for _, m := range newMetrics {
+		if !seen[m.Reference] {
+			seen[m.Reference] = true
+			refs = append(refs, m.Reference)
+		}
+	}
+	sort.Strings(refs)
+	return refs
+}
+
+// diffCategories compares oldReport and newReport by CategoryScore.ID and
+// returns a categoryDiff for each category that was added, removed, or
+// whose score, status, or metrics changed. Categories present in both with
+// no changes anywhere are omitted.
+func diffCategories(oldReport, newReport OverallScore) []categoryDiff {
+	oldByID := make(map[string]CategoryScore, len(oldReport.Categories))
+	for _, c := range oldReport.Categories {
+		oldByID[c.ID] = c
+	}
+	newByID := make(map[string]CategoryScore, len(newReport.Categories))
+	for _, c := range newReport.Categories {
+		newByID[c.ID] = c
+	}
+
+	var ids []string
+	seen := make(map[string]bool)
+	for _, c := range oldReport.Categories {
+		if !seen[c.ID] {
+			seen[c.ID] = true
+			ids = append(ids, c.ID)
+		}
+	}
+	for _, c := range newReport.Categories {
+		if !seen[c.ID] {
+			seen[c.ID] = true
+			ids = append(ids, c.ID)
+		}
+	}
+	sort.Strings(ids)
+
+	var diffs []categoryDiff
+	for _, id := range ids {
+		oldCategory, inOld := oldByID[id]
+		newCategory, inNew := newByID[id]
+
+		var metrics []metricDiff
+		switch {
+		case inOld && inNew:
+			metrics = diffMetrics(oldCategory.Metrics, newCategory.Metrics)
+		case inOld:
+			metrics = diffMetrics(oldCategory.Metrics, nil)
+		default:
+			metrics = diffMetrics(nil, newCategory.Metrics)
+		}
+
+		scoreChanged := inOld && inNew && (oldCategory.Score != newCategory.Score || oldCategory.Status != newCategory.Status)
+		if !inOld || !inNew || scoreChanged || len(metrics) > 0 {
+			diffs = append(diffs, categoryDiff{
+				ID:       id,
+				OldScore: oldCategory.Score, NewScore: newCategory.Score,
+				OldStatus: oldCategory.Status, NewStatus: newCategory.Status,
+				Metrics: metrics,
+			})
+		}
+	}
+	return diffs
+}
+
+// buildReportDiff computes the full reportDiff between two OverallScore
+// snapshots, for consumption by both the text and JSON branches of
+// GenerateDiffReport.
+func buildReportDiff(oldReport, newReport OverallScore) reportDiff {
+	return reportDiff{
+		OldScore: oldReport.Score, NewScore: newReport.Score,
+		OldStatus: oldReport.Status, NewStatus: newReport.Status,
+		ScoreDelta: newReport.Score - oldReport.Score,
+		Categories: diffCategories(oldReport, newReport),
+	}
+}
+
+// jsonMetricDiff, jsonCategoryDiff, and jsonReportDiff mirror
+// metricDiff/categoryDiff/reportDiff for JSON output, using the same
+// snake_case and omitempty conventions as jsonReport/jsonCategory/jsonMetric.
+type jsonMetricDiff struct {
+	Reference string `json:"reference"`
+	OldScore  int    `json:"old_score,omitempty"`
+	NewScore  int    `json:"new_score,omitempty"`
+	OldStatus string `json:"old_status,omitempty"`
+	NewStatus string `json:"new_status,omitempty"`
+	Added     bool   `json:"added,omitempty"`
+	Removed   bool   `json:"removed,omitempty"`
+}
+
+type jsonCategoryDiff struct {
+	ID        string           `json:"id"`
+	OldScore  int              `json:"old_score"`
+	NewScore  int              `json:"new_score"`
+	OldStatus string           `json:"old_status"`
+	NewStatus string           `json:"new_status"`
+	Metrics   []jsonMetricDiff `json:"metrics,omitempty"`
+}
+
+type jsonReportDiff struct {
+	OldScore   int                `json:"old_score"`
+	NewScore   int                `json:"new_score"`
+	OldStatus  string             `json:"old_status"`
+	NewStatus  string             `json:"new_status"`
+	ScoreDelta int                `json:"score_delta"`
+	Categories []jsonCategoryDiff `json:"categories,omitempty"`
+}
+
+// GenerateDiffReport compares two OverallScore snapshots - typically the
+// same metrics data scored before and after a change - and reports which
+// categories and metrics moved: score deltas, status transitions (e.g.
+// green to yellow), and metrics that were added or removed between the
+// two. It's meant for PR-style CI workflows that want a reviewable summary
+// of what a metrics data change actually did to the posture, the same way
+// `helm diff` summarizes a chart upgrade before it's applied.
+func (r *ReportGenerator) GenerateDiffReport(oldReport, newReport OverallScore, format ReportFormat) (*ReportOutput, error) {
+	diff := buildReportDiff(oldReport, newReport)
+
+	switch format {
+	case JSONFormat:
+		jsonDiff := jsonReportDiff{
+			OldScore: diff.OldScore, NewScore: diff.NewScore,
+			OldStatus: string(diff.OldStatus), NewStatus: string(diff.NewStatus),
+			ScoreDelta: diff.ScoreDelta,
+		}
+		for _, c := range diff.Categories {
+			jc := jsonCategoryDiff{
+				ID:       c.ID,
+				OldScore: c.OldScore, NewScore: c.NewScore,
+				OldStatus: string(c.OldStatus), NewStatus: string(c.NewStatus),
+			}
+			for _, m := range c.Metrics {
+				jc.Metrics = append(jc.Metrics, jsonMetricDiff{
+					Reference: m.Reference,
+					OldScore:  m.OldScore, NewScore: m.NewScore,
+					OldStatus: string(m.OldStatus), NewStatus: string(m.NewStatus),
+					Added: m.Added, Removed: m.Removed,
+				})
+			}
+			jsonDiff.Categories = append(jsonDiff.Categories, jc)
+		}
+
+		content, err := json.MarshalIndent(jsonDiff, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal diff report: %w", err)
+		}
+		return &ReportOutput{Content: content, ContentType: "text"}, nil
+	case TextFormat, TableFormat:
+		var buf bytes.Buffer
+		fmt.Fprintf(&buf, "Overall: %d (%s) -> %d (%s) [%+d]\n",
+			diff.OldScore, r.formatStatus(diff.OldStatus), diff.NewScore, r.formatStatus(diff.NewStatus), diff.ScoreDelta)
+
+		if len(diff.Categories) == 0 {
+			fmt.Fprintln(&buf, "No category or metric changes.")
+			return &ReportOutput{Content: buf.Bytes(), ContentType: "text"}, nil
+		}
+
+		for _, c := range diff.Categories {
+			fmt.Fprintf(&buf, "\n%s: %d (%s) -> %d (%s) [%+d]\n",
+				sanitizeString(c.ID), c.OldScore, r.formatStatus(c.OldStatus), c.NewScore, r.formatStatus(c.NewStatus), c.NewScore-c.OldScore)
+			for _, m := range c.Metrics {
+				switch {
+				case m.Added:
+					fmt.Fprintf(&buf, "  + %s: %d (%s)\n", sanitizeString(m.Reference), m.NewScore, r.formatStatus(m.NewStatus))
+				case m.Removed:
+					fmt.Fprintf(&buf, "  - %s: %d (%s)\n", sanitizeString(m.Reference), m.OldScore, r.formatStatus(m.OldStatus))
+				default:
+					fmt.Fprintf(&buf, "  ~ %s: %d (%s) -> %d (%s)\n",
+						sanitizeString(m.Reference), m.OldScore, r.formatStatus(m.OldStatus), m.NewScore, r.formatStatus(m.NewStatus))
+				}
+			}
+		}
+		return &ReportOutput{Content: buf.Bytes(), ContentType: "text"}, nil
+	default:
+		return nil, fmt.Errorf("unsupported report format: %s", format)
+	}
 }
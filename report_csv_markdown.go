@@ -0,0 +1,142 @@
+package pulse
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// formatOverallReportAsCSV renders score as two CSV tables separated by a
+// blank line: a category summary (Category,Weight,KPIScore,KPIStatus,
+// KRIScore,KRIStatus) and the detailed metrics (Category,Type,ID,Score,
+// Status), the same columns formatOverallReportAsTable prints.
+func (r *ReportGenerator) formatOverallReportAsCSV(score *OverallScore) string {
+	var buf bytes.Buffer
+
+	categoryWriter := csv.NewWriter(&buf)
+	categoryWriter.Write([]string{"Category", "Weight", "KPIScore", "KPIStatus", "KRIScore", "KRIStatus"})
+	for _, category := range score.Categories {
+		weightPercentage := r.categoryWeightPercent(category.ID, len(score.Categories))
+		categoryWriter.Write([]string{
+			category.Name,
+			fmt.Sprintf("%d%%", weightPercentage),
+			strconv.Itoa(category.KPIScore),
+			r.formatStatus(category.KPIStatus),
+			strconv.Itoa(category.KRIScore),
+			r.formatStatus(category.KRIStatus),
+		})
+	}
+	categoryWriter.Flush()
+
+	buf.WriteString("\n")
+
+	metricWriter := csv.NewWriter(&buf)
+	metricWriter.Write([]string{"Category", "Type", "ID", "Score", "Status"})
+	for _, category := range score.Categories {
+		for _, metric := range category.Metrics {
+			metricType, id := promMetricLabels(metric.Reference)
+			metricWriter.Write([]string{category.Name, metricType, id, strconv.Itoa(metric.Score), r.formatStatus(metric.Status)})
+		}
+	}
+	metricWriter.Flush()
+
+	return buf.String()
+}
+
+// formatCategoryReportAsCSV is the category-scoped equivalent of
+// formatOverallReportAsCSV: a single-row category summary table followed by
+// that category's detailed metrics.
+func (r *ReportGenerator) formatCategoryReportAsCSV(score *CategoryScore) string {
+	totalCategories := len(r.scoreCalculator.metricsProcessor.GetAllCategories())
+	weightPercentage := r.categoryWeightPercent(score.ID, totalCategories)
+
+	var buf bytes.Buffer
+
+	categoryWriter := csv.NewWriter(&buf)
+	categoryWriter.Write([]string{"Category", "Weight", "KPIScore", "KPIStatus", "KRIScore", "KRIStatus"})
+	categoryWriter.Write([]string{
+		score.Name,
+		fmt.Sprintf("%d%%", weightPercentage),
+		strconv.Itoa(score.KPIScore),
+		r.formatStatus(score.KPIStatus),
+		strconv.Itoa(score.KRIScore),
+		r.formatStatus(score.KRIStatus),
+	})
+	categoryWriter.Flush()
+
+	buf.WriteString("\n")
+
+	metricWriter := csv.NewWriter(&buf)
+	metricWriter.Write([]string{"Category", "Type", "ID", "Score", "Status"})
+	for _, metric := range score.Metrics {
+		metricType, id := promMetricLabels(metric.Reference)
+		metricWriter.Write([]string{score.Name, metricType, id, strconv.Itoa(metric.Score), r.formatStatus(metric.Status)})
+	}
+	metricWriter.Flush()
+
+	return buf.String()
+}
+
+// formatOverallReportAsMarkdown renders score as GitHub-flavored Markdown: a
+// category summary table and a detailed metrics table, using the same
+// status rendering (text or emoji, per labelType) as the other formatters.
+func (r *ReportGenerator) formatOverallReportAsMarkdown(score *OverallScore) string {
+	var sb strings.Builder
+
+	sb.WriteString("# Security Posture Report\n\n")
+	fmt.Fprintf(&sb, "KPI Score: %d (%s)  \nKRI Score: %d (%s)  \nReport Date: %s\n\n",
+		score.KPIScore, r.formatStatus(score.KPIStatus), score.KRIScore, r.formatStatus(score.KRIStatus), time.Now().Format("2006-01-02 15:04:05"))
+
+	sb.WriteString("## Category Scores\n\n")
+	sb.WriteString("| Category | Weight | KPI Score | KPI Status | KRI Score | KRI Status |\n")
+	sb.WriteString("| --- | --- | --- | --- | --- | --- |\n")
+	for _, category := range score.Categories {
+		weightPercentage := r.categoryWeightPercent(category.ID, len(score.Categories))
+		fmt.Fprintf(&sb, "| %s | %d%% | %d | %s | %d | %s |\n",
+			sanitizeString(category.Name), weightPercentage, category.KPIScore, r.formatStatus(category.KPIStatus), category.KRIScore, r.formatStatus(category.KRIStatus))
+	}
+
+	sb.WriteString("\n## Detailed Metrics\n\n")
+	sb.WriteString("| Category | Type | ID | Score | Status |\n")
+	sb.WriteString("| --- | --- | --- | --- | --- |\n")
+	for _, category := range score.Categories {
+		for _, metric := range category.Metrics {
+			metricType, id := promMetricLabels(metric.Reference)
+			fmt.Fprintf(&sb, "| %s | %s | %s | %d | %s |\n", sanitizeString(category.Name), metricType, sanitizeString(id), metric.Score, r.formatStatus(metric.Status))
+		}
+	}
+
+	return sb.String()
+}
+
+// formatCategoryReportAsMarkdown is the category-scoped equivalent of
+// formatOverallReportAsMarkdown.
+func (r *ReportGenerator) formatCategoryReportAsMarkdown(score *CategoryScore) string {
+	totalCategories := len(r.scoreCalculator.metricsProcessor.GetAllCategories())
+	weightPercentage := r.categoryWeightPercent(score.ID, totalCategories)
+
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "# %s Report (Weight: %d%%)\n\n", sanitizeString(score.Name), weightPercentage)
+	fmt.Fprintf(&sb, "KPI Score: %d (%s)  \nKRI Score: %d (%s)  \nReport Date: %s\n\n",
+		score.KPIScore, r.formatStatus(score.KPIStatus), score.KRIScore, r.formatStatus(score.KRIStatus), time.Now().Format("2006-01-02 15:04:05"))
+
+	sb.WriteString("## Metrics\n\n")
+	sb.WriteString("| Type | ID | Score | Status |\n")
+	sb.WriteString("| --- | --- | --- | --- |\n")
+
+	kpiMetrics, kriMetrics := splitMetricsByType(score.Metrics)
+	for _, metric := range kpiMetrics {
+		_, id := promMetricLabels(metric.Reference)
+		fmt.Fprintf(&sb, "| KPI | %s | %d | %s |\n", sanitizeString(id), metric.Score, r.formatStatus(metric.Status))
+	}
+	for _, metric := range kriMetrics {
+		_, id := promMetricLabels(metric.Reference)
+		fmt.Fprintf(&sb, "| KRI | %s | %d | %s |\n", sanitizeString(id), metric.Score, r.formatStatus(metric.Status))
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,84 @@
+package pulse
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateOverallReportAsCSVHasTwoTables(t *testing.T) {
+	reportGenerator := reportQueryFixture()
+
+	output, err := reportGenerator.GenerateOverallReport(CSVFormat)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	content := string(output.Content)
+
+	tables := strings.Split(strings.TrimRight(content, "\n"), "\n\n")
+	if len(tables) != 2 {
+		t.Fatalf("expected 2 blank-line-separated tables, got %d:\n%s", len(tables), content)
+	}
+	if !strings.HasPrefix(tables[0], "Category,Weight,KPIScore,KPIStatus,KRIScore,KRIStatus") {
+		t.Errorf("expected the category table header, got:\n%s", tables[0])
+	}
+	if !strings.HasPrefix(tables[1], "Category,Type,ID,Score,Status") {
+		t.Errorf("expected the metrics table header, got:\n%s", tables[1])
+	}
+	if !strings.Contains(tables[1], "Application Security,KPI,coverage,90") {
+		t.Errorf("expected a coverage row, got:\n%s", tables[1])
+	}
+}
+
+func TestGenerateCategoryReportAsCSVSingleSummaryRow(t *testing.T) {
+	reportGenerator := reportQueryFixture()
+
+	output, err := reportGenerator.GenerateCategoryReport("app_sec", CSVFormat)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	content := string(output.Content)
+
+	if !strings.Contains(content, "Application Security,100%,90") {
+		t.Errorf("expected the category summary row, got:\n%s", content)
+	}
+	if !strings.Contains(content, "Application Security,KRI,incidents,40") {
+		t.Errorf("expected an incidents row, got:\n%s", content)
+	}
+}
+
+func TestGenerateOverallReportAsMarkdownHasBothTables(t *testing.T) {
+	reportGenerator := reportQueryFixture()
+
+	output, err := reportGenerator.GenerateOverallReport(MarkdownFormat)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	content := string(output.Content)
+
+	if !strings.Contains(content, "## Category Scores") || !strings.Contains(content, "## Detailed Metrics") {
+		t.Errorf("expected both markdown sections, got:\n%s", content)
+	}
+	if !strings.Contains(content, "| Application Security | 100% | 90 |") {
+		t.Errorf("expected the app_sec category row, got:\n%s", content)
+	}
+	if !strings.Contains(content, "| Application Security | KRI | incidents | 40 |") {
+		t.Errorf("expected the incidents metric row, got:\n%s", content)
+	}
+}
+
+func TestGenerateCategoryReportAsMarkdownGroupsByType(t *testing.T) {
+	reportGenerator := reportQueryFixture()
+
+	output, err := reportGenerator.GenerateCategoryReport("app_sec", MarkdownFormat)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	content := string(output.Content)
+
+	if !strings.Contains(content, "| KPI | coverage | 90 |") {
+		t.Errorf("expected the coverage KPI row, got:\n%s", content)
+	}
+	if !strings.Contains(content, "| KRI | incidents | 40 |") {
+		t.Errorf("expected the incidents KRI row, got:\n%s", content)
+	}
+}
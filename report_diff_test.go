@@ -0,0 +1,92 @@
+package pulse
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func diffTestReportGenerator() *ReportGenerator {
+	metricsConfig := &MetricsConfig{Categories: []Category{{ID: "app_sec"}}}
+	leversConfig := &LeversConfig{Weights: Weights{Categories: CategoryWeights{"app_sec": 1.0}}}
+	processor := NewMetricsProcessor(metricsConfig, leversConfig, &MetricsData{})
+	calculator := NewScoreCalculator(processor, MedianScoring)
+	return NewReportGenerator(calculator, TextLabels)
+}
+
+func TestGenerateDiffReportDetectsStatusAndScoreChanges(t *testing.T) {
+	oldReport := OverallScore{
+		Score: 80, Status: Green,
+		Categories: []CategoryScore{
+			{
+				ID: "app_sec", Score: 80, Status: Green,
+				Metrics: []MetricScore{
+					{Reference: "app_sec.KPI.coverage", Score: 80, Status: Green},
+					{Reference: "app_sec.KPI.removed_metric", Score: 60, Status: Yellow},
+				},
+			},
+		},
+	}
+	newReport := OverallScore{
+		Score: 55, Status: Yellow,
+		Categories: []CategoryScore{
+			{
+				ID: "app_sec", Score: 55, Status: Yellow,
+				Metrics: []MetricScore{
+					{Reference: "app_sec.KPI.coverage", Score: 55, Status: Yellow},
+					{Reference: "app_sec.KPI.added_metric", Score: 40, Status: Red},
+				},
+			},
+		},
+	}
+
+	generator := diffTestReportGenerator()
+
+	output, err := generator.GenerateDiffReport(oldReport, newReport, TextFormat)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	text := string(output.Content)
+	for _, want := range []string{"app_sec", "+ app_sec.KPI.added_metric", "- app_sec.KPI.removed_metric", "~ app_sec.KPI.coverage"} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("expected diff text to contain %q, got:\n%s", want, text)
+		}
+	}
+
+	jsonOutput, err := generator.GenerateDiffReport(oldReport, newReport, JSONFormat)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var decoded jsonReportDiff
+	if err := json.Unmarshal(jsonOutput.Content, &decoded); err != nil {
+		t.Fatalf("expected valid JSON diff report, got error: %v", err)
+	}
+	if decoded.ScoreDelta != -25 {
+		t.Fatalf("expected score_delta -25, got %d", decoded.ScoreDelta)
+	}
+	if len(decoded.Categories) != 1 || len(decoded.Categories[0].Metrics) != 3 {
+		t.Fatalf("expected 1 category with 3 metric diffs, got %+v", decoded.Categories)
+	}
+}
+
+func TestGenerateDiffReportReportsNoChanges(t *testing.T) {
+	report := OverallScore{
+		Score: 80, Status: Green,
+		Categories: []CategoryScore{
+			{ID: "app_sec", Score: 80, Status: Green, Metrics: []MetricScore{
+				{Reference: "app_sec.KPI.coverage", Score: 80, Status: Green},
+			}},
+		},
+	}
+
+	generator := diffTestReportGenerator()
+
+	output, err := generator.GenerateDiffReport(report, report, TextFormat)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(string(output.Content), "No category or metric changes.") {
+		t.Fatalf("expected an identical pair of reports to produce no diff, got:\n%s", output.Content)
+	}
+}
@@ -0,0 +1,160 @@
+package pulse
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+var (
+	catalogObjPattern  = regexp.MustCompile(`(\d+) 0 obj\s*<<([^>]*?/Type\s*/Catalog[^>]*?)>>\s*endobj`)
+	startxrefPattern   = regexp.MustCompile(`startxref\s*(\d+)\s*\r?\n%%EOF`)
+	trailerSizePattern = regexp.MustCompile(`/Size\s+(\d+)`)
+)
+
+// accessibleStatusAlt is the screen-reader alt text GenerateAccessiblePDFReport
+// attaches to each status cell's /Alt entry, conveying the traffic light
+// status in words rather than only via color.
+func accessibleStatusAlt(status TrafficLightStatus) string {
+	switch status {
+	case Green:
+		return "Status: GREEN"
+	case Yellow:
+		return "Status: YELLOW"
+	case Red:
+		return "Status: RED"
+	default:
+		return "Status: UNKNOWN"
+	}
+}
+
+// GenerateAccessiblePDFReport renders the overall report as a PDF and
+// layers a best-effort accessibility structure on top of gofpdf's output
+// (see appendAccessibilityStructure): /MarkInfo << /Marked true >>, /Lang
+// (en-US), and a /StructTreeRoot describing the report as a heading
+// followed by a table, with each status cell's /Alt carrying its status in
+// words.
+//
+// gofpdf exposes no hook for tagging its content streams with BDC/EMC
+// marked-content operators, so this falls short of full PDF/UA conformance
+// (which requires every StructElem to resolve to tagged content via MCID):
+// the structure tree this produces describes the report's logical shape
+// but its elements aren't linked to specific marked content runs. It is a
+// step toward PDF/UA, built as a PDF incremental update layered on top of
+// gofpdf's normal output, not a replacement for true content tagging.
+func (r *ReportGenerator) GenerateAccessiblePDFReport() (*ReportOutput, error) {
+	overallScore, err := r.scoreCalculator.CalculateOverallScore()
+	if err != nil {
+		return nil, err
+	}
+
+	base, err := r.formatOverallReportAsPDF(overallScore)
+	if err != nil {
+		return nil, err
+	}
+
+	tagged, err := appendAccessibilityStructure(base, overallScore)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add PDF/UA accessibility structure: %w", err)
+	}
+
+	return &ReportOutput{Content: tagged, ContentType: "binary"}, nil
+}
+
+// appendAccessibilityStructure layers a best-effort PDF/UA structure onto
+// base (a gofpdf-generated PDF) via a PDF incremental update: it appends a
+// replacement Catalog object carrying /MarkInfo, /Lang, and a new
+// /StructTreeRoot, plus a StructElem hierarchy (Document > H1 > Table >
+// TR > TH/TD, one TR per metric) with each status TD's /Alt set via
+// accessibleStatusAlt, then a fresh xref/trailer chained to the original
+// via /Prev. Every original object is left untouched, so the rendered page
+// content is unaffected.
+func appendAccessibilityStructure(base []byte, score *OverallScore) ([]byte, error) {
+	catalogMatch := catalogObjPattern.FindSubmatch(base)
+	if catalogMatch == nil {
+		return nil, fmt.Errorf("could not locate the /Catalog object in the generated PDF")
+	}
+	catalogBody := string(catalogMatch[2])
+
+	sizeMatch := trailerSizePattern.FindSubmatch(base)
+	if sizeMatch == nil {
+		return nil, fmt.Errorf("could not locate /Size in the generated PDF's trailer")
+	}
+	originalSize, err := strconv.Atoi(string(sizeMatch[1]))
+	if err != nil {
+		return nil, fmt.Errorf("could not parse trailer /Size: %w", err)
+	}
+
+	startxrefMatch := startxrefPattern.FindSubmatch(base)
+	if startxrefMatch == nil {
+		return nil, fmt.Errorf("could not locate startxref in the generated PDF")
+	}
+	previousStartXref := string(startxrefMatch[1])
+
+	var statusAlts []string
+	for _, category := range score.Categories {
+		for _, metric := range category.Metrics {
+			statusAlts = append(statusAlts, accessibleStatusAlt(metric.Status))
+		}
+	}
+
+	newCatalogNum := originalSize
+	structTreeRootNum := originalSize + 1
+	documentElemNum := originalSize + 2
+	headingElemNum := originalSize + 3
+	tableElemNum := originalSize + 4
+	headerRowElemNum := originalSize + 5
+
+	rowElemNums := make([]int, 0, len(statusAlts))
+	nextNum := headerRowElemNum + 1
+	for range statusAlts {
+		rowElemNums = append(rowElemNums, nextNum)
+		nextNum++
+	}
+
+	var buf bytes.Buffer
+	buf.Write(base)
+
+	objOffsets := make(map[int]int, nextNum-newCatalogNum)
+	writeObj := func(num int, body string) {
+		objOffsets[num] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", num, body)
+	}
+
+	writeObj(newCatalogNum, fmt.Sprintf("<<%s /MarkInfo << /Marked true >> /Lang (en-US) /StructTreeRoot %d 0 R>>",
+		catalogBody, structTreeRootNum))
+
+	writeObj(structTreeRootNum, fmt.Sprintf("<< /Type /StructTreeRoot /K [%d 0 R] >>", documentElemNum))
+
+	writeObj(documentElemNum, fmt.Sprintf("<< /Type /StructElem /S /Document /K [%d 0 R %d 0 R] >>", headingElemNum, tableElemNum))
+
+	writeObj(headingElemNum, "<< /Type /StructElem /S /H1 >>")
+
+	tableKids := fmt.Sprintf("%d 0 R", headerRowElemNum)
+	for _, num := range rowElemNums {
+		tableKids += fmt.Sprintf(" %d 0 R", num)
+	}
+	writeObj(tableElemNum, fmt.Sprintf("<< /Type /StructElem /S /Table /K [%s] >>", tableKids))
+
+	writeObj(headerRowElemNum, "<< /Type /StructElem /S /TR /K "+
+		"[<< /Type /StructElem /S /TH >> << /Type /StructElem /S /TH >> "+
+		"<< /Type /StructElem /S /TH >> << /Type /StructElem /S /TH >>] >>")
+
+	for i, num := range rowElemNums {
+		writeObj(num, fmt.Sprintf("<< /Type /StructElem /S /TR /K "+
+			"[<< /Type /StructElem /S /TD >> << /Type /StructElem /S /TD /Alt (%s) >>] >>", statusAlts[i]))
+	}
+
+	newObjCount := nextNum - newCatalogNum
+	xrefOffset := buf.Len()
+
+	fmt.Fprintf(&buf, "xref\n0 1\n0000000000 65535 f \n%d %d\n", newCatalogNum, newObjCount)
+	for num := newCatalogNum; num < nextNum; num++ {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", objOffsets[num])
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root %d 0 R /Prev %s >>\nstartxref\n%d\n%%%%EOF\n",
+		nextNum, newCatalogNum, previousStartXref, xrefOffset)
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,61 @@
+package pulse
+
+import (
+	"strings"
+	"testing"
+)
+
+// fakeBasePDF is a minimal hand-built stand-in for gofpdf output, just
+// enough for appendAccessibilityStructure to locate its Catalog, trailer
+// /Size, and startxref without needing a real gofpdf-rendered PDF.
+func fakeBasePDF() []byte {
+	return []byte("%PDF-1.4\n" +
+		"1 0 obj\n<< /Type /Pages /Kids [2 0 R] /Count 1 >>\nendobj\n" +
+		"2 0 obj\n<< /Type /Page /Parent 1 0 R >>\nendobj\n" +
+		"3 0 obj\n<< /Type /Catalog /Pages 1 0 R >>\nendobj\n" +
+		"xref\n0 4\n0000000000 65535 f \n0000000009 00000 n \n0000000060 00000 n \n0000000110 00000 n \n" +
+		"trailer\n<< /Size 4 /Root 3 0 R >>\nstartxref\n160\n%%EOF\n")
+}
+
+func TestAppendAccessibilityStructureAddsMarkInfoAndStructTree(t *testing.T) {
+	score := &OverallScore{
+		Categories: []CategoryScore{
+			{
+				Name: "Test Category",
+				Metrics: []MetricScore{
+					{Reference: "test_cat.KPI.coverage", Status: Green},
+					{Reference: "test_cat.KRI.incidents", Status: Red},
+				},
+			},
+		},
+	}
+
+	tagged, err := appendAccessibilityStructure(fakeBasePDF(), score)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	content := string(tagged)
+
+	if !strings.Contains(content, "/MarkInfo") || !strings.Contains(content, "/Marked true") {
+		t.Error("expected the new Catalog to carry /MarkInfo << /Marked true >>")
+	}
+	if !strings.Contains(content, "/Lang (en-US)") {
+		t.Error("expected the new Catalog to carry /Lang (en-US)")
+	}
+	if !strings.Contains(content, "/StructTreeRoot") {
+		t.Error("expected a /StructTreeRoot object")
+	}
+	if !strings.Contains(content, "Status: GREEN") || !strings.Contains(content, "Status: RED") {
+		t.Error("expected each status cell's alt text to be present")
+	}
+	if !strings.Contains(content, "/Prev 160") {
+		t.Error("expected the new trailer to chain to the original via /Prev")
+	}
+}
+
+func TestAppendAccessibilityStructureErrorsWithoutCatalog(t *testing.T) {
+	_, err := appendAccessibilityStructure([]byte("%PDF-1.4\nnot a real pdf"), &OverallScore{})
+	if err == nil {
+		t.Error("expected an error when no /Catalog object is present")
+	}
+}
@@ -0,0 +1,208 @@
+package pulse
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/scottbrown/pulse/internal/pdftest"
+)
+
+// updateGoldenPDFs regenerates the testdata/pdf/*.pdf fixtures instead of
+// comparing against them. Run once against a real build to populate or
+// refresh the fixtures after an intentional PDF layout change, then commit
+// the result:
+//
+//	go test -run TestGeneratePDFReportsGolden -update ./...
+var updateGoldenPDFs = flag.Bool("update", false, "write golden PDF fixtures instead of comparing against them")
+
+// goldenPDFClock is the fixed "now" every golden PDF fixture renders its
+// Report Date cell against (see ReportGenerator.Now), so output is
+// deterministic across runs and machines.
+func goldenPDFClock() time.Time {
+	return time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+}
+
+// goldenPDFFixture is one table-driven case for TestGeneratePDFReportsGolden:
+// a representative score shape and the testdata file its PDF is compared
+// against.
+type goldenPDFFixture struct {
+	name      string
+	generator func() *ReportGenerator
+}
+
+// There's deliberately no "a category with neither KPIs nor KRIs" case
+// here: CalculateOverallScore skips any category with zero metrics, and
+// errors out before PDF generation ever runs once every category has been
+// skipped that way, so a report with no scoreable metrics at all can never
+// reach the PDF tables. kpi_only and kri_only already cover an empty table
+// in one dimension while the other still has data.
+var goldenPDFFixtures = []goldenPDFFixture{
+	{name: "all_green", generator: goldenPDFGeneratorFixture(90, 90)},
+	{name: "mixed", generator: goldenPDFMixedFixture()},
+	{name: "all_red", generator: goldenPDFGeneratorFixture(10, 10)},
+	{name: "kpi_only", generator: goldenPDFKPIOnlyFixture()},
+	{name: "kri_only", generator: goldenPDFKRIOnlyFixture()},
+}
+
+// goldenPDFThresholds is the Green/Yellow/Red band shared by every golden
+// fixture below, matching reportQueryFixture's thresholds.
+var goldenPDFThresholds = Thresholds{
+	Green:  ThresholdRange{Min: 80, Max: 100},
+	Yellow: ThresholdRange{Min: 50, Max: 79},
+	Red:    ThresholdRange{Min: 0, Max: 49},
+}
+
+// goldenPDFGeneratorFixture builds a single-category generator whose KPI
+// and KRI each score a fixed value, for the all_green/all_red cases.
+func goldenPDFGeneratorFixture(kpiScore, kriScore float64) func() *ReportGenerator {
+	return func() *ReportGenerator {
+		metricsConfig := &MetricsConfig{
+			Categories: []Category{
+				{
+					ID:   "golden_cat",
+					Name: "Golden Category",
+					KPIs: []KPI{{ID: "availability", ScoringBands: []ScoringBand{{Min: FloatPtr(0), Score: int(kpiScore)}}}},
+					KRIs: []KRI{{ID: "incidents", ScoringBands: []ScoringBand{{Min: FloatPtr(0), Score: int(kriScore)}}}},
+				},
+			},
+		}
+		leversConfig := &LeversConfig{
+			Global:  Global{Thresholds: goldenPDFThresholds, KPIThresholds: goldenPDFThresholds, KRIThresholds: goldenPDFThresholds},
+			Weights: Weights{Categories: CategoryWeights{"golden_cat": 1.0}},
+		}
+		metricsData := &MetricsData{
+			Metrics: []Metric{
+				{Reference: "golden_cat.KPI.availability", Value: 1},
+				{Reference: "golden_cat.KRI.incidents", Value: 1},
+			},
+		}
+		processor := NewMetricsProcessor(metricsConfig, leversConfig, metricsData)
+		return NewReportGenerator(NewScoreCalculator(processor, MedianScoring), TextLabels)
+	}
+}
+
+// goldenPDFMixedFixture builds two categories spanning green, yellow, and
+// red statuses, so the mixed fixture exercises every status color.
+func goldenPDFMixedFixture() func() *ReportGenerator {
+	return func() *ReportGenerator {
+		metricsConfig := &MetricsConfig{
+			Categories: []Category{
+				{
+					ID:   "golden_strong",
+					Name: "Golden Strong",
+					KPIs: []KPI{{ID: "coverage", ScoringBands: []ScoringBand{{Min: FloatPtr(0), Score: 90}}}},
+					KRIs: []KRI{{ID: "incidents", ScoringBands: []ScoringBand{{Min: FloatPtr(0), Score: 60}}}},
+				},
+				{
+					ID:   "golden_weak",
+					Name: "Golden Weak",
+					KPIs: []KPI{{ID: "patching", ScoringBands: []ScoringBand{{Min: FloatPtr(0), Score: 20}}}},
+					KRIs: []KRI{{ID: "exposures", ScoringBands: []ScoringBand{{Min: FloatPtr(0), Score: 55}}}},
+				},
+			},
+		}
+		leversConfig := &LeversConfig{
+			Global: Global{Thresholds: goldenPDFThresholds, KPIThresholds: goldenPDFThresholds, KRIThresholds: goldenPDFThresholds},
+			Weights: Weights{Categories: CategoryWeights{
+				"golden_strong": 0.5,
+				"golden_weak":   0.5,
+			}},
+		}
+		metricsData := &MetricsData{
+			Metrics: []Metric{
+				{Reference: "golden_strong.KPI.coverage", Value: 1},
+				{Reference: "golden_strong.KRI.incidents", Value: 1},
+				{Reference: "golden_weak.KPI.patching", Value: 1},
+				{Reference: "golden_weak.KRI.exposures", Value: 1},
+			},
+		}
+		processor := NewMetricsProcessor(metricsConfig, leversConfig, metricsData)
+		return NewReportGenerator(NewScoreCalculator(processor, MedianScoring), TextLabels)
+	}
+}
+
+// goldenPDFKPIOnlyFixture builds a category with a KPI and no KRIs.
+func goldenPDFKPIOnlyFixture() func() *ReportGenerator {
+	return func() *ReportGenerator {
+		metricsConfig := &MetricsConfig{
+			Categories: []Category{
+				{
+					ID:   "golden_kpi_only",
+					Name: "Golden KPI Only",
+					KPIs: []KPI{{ID: "coverage", ScoringBands: []ScoringBand{{Min: FloatPtr(0), Score: 85}}}},
+				},
+			},
+		}
+		leversConfig := &LeversConfig{
+			Global:  Global{Thresholds: goldenPDFThresholds, KPIThresholds: goldenPDFThresholds, KRIThresholds: goldenPDFThresholds},
+			Weights: Weights{Categories: CategoryWeights{"golden_kpi_only": 1.0}},
+		}
+		metricsData := &MetricsData{Metrics: []Metric{{Reference: "golden_kpi_only.KPI.coverage", Value: 1}}}
+		processor := NewMetricsProcessor(metricsConfig, leversConfig, metricsData)
+		return NewReportGenerator(NewScoreCalculator(processor, MedianScoring), TextLabels)
+	}
+}
+
+// goldenPDFKRIOnlyFixture builds a category with a KRI and no KPIs.
+func goldenPDFKRIOnlyFixture() func() *ReportGenerator {
+	return func() *ReportGenerator {
+		metricsConfig := &MetricsConfig{
+			Categories: []Category{
+				{
+					ID:   "golden_kri_only",
+					Name: "Golden KRI Only",
+					KRIs: []KRI{{ID: "incidents", ScoringBands: []ScoringBand{{Min: FloatPtr(0), Score: 45}}}},
+				},
+			},
+		}
+		leversConfig := &LeversConfig{
+			Global:  Global{Thresholds: goldenPDFThresholds, KPIThresholds: goldenPDFThresholds, KRIThresholds: goldenPDFThresholds},
+			Weights: Weights{Categories: CategoryWeights{"golden_kri_only": 1.0}},
+		}
+		metricsData := &MetricsData{Metrics: []Metric{{Reference: "golden_kri_only.KRI.incidents", Value: 1}}}
+		processor := NewMetricsProcessor(metricsConfig, leversConfig, metricsData)
+		return NewReportGenerator(NewScoreCalculator(processor, MedianScoring), TextLabels)
+	}
+}
+
+// TestGeneratePDFReportsGolden renders each fixture's overall report as a
+// PDF and compares it, via pdftest.ComparePDFs, against the reference copy
+// committed at testdata/pdf/<name>.pdf. Use -update to (re)generate those
+// references after an intentional PDF layout change.
+func TestGeneratePDFReportsGolden(t *testing.T) {
+	for _, fixture := range goldenPDFFixtures {
+		t.Run(fixture.name, func(t *testing.T) {
+			generator := fixture.generator()
+			generator.Now = goldenPDFClock
+
+			output, err := generator.GenerateOverallReport(PDFFormat)
+			if err != nil {
+				t.Fatalf("failed to generate PDF: %v", err)
+			}
+
+			goldenPath := filepath.Join("testdata", "pdf", fixture.name+".pdf")
+
+			if *updateGoldenPDFs {
+				if err := os.MkdirAll(filepath.Dir(goldenPath), 0750); err != nil {
+					t.Fatalf("failed to create testdata dir: %v", err)
+				}
+				if err := os.WriteFile(goldenPath, output.Content, 0600); err != nil {
+					t.Fatalf("failed to write golden PDF: %v", err)
+				}
+				return
+			}
+
+			want, err := os.ReadFile(goldenPath)
+			if err != nil {
+				t.Fatalf("missing golden PDF %s (run with -update to generate it): %v", goldenPath, err)
+			}
+
+			if err := pdftest.ComparePDFs(output.Content, want); err != nil {
+				t.Errorf("PDF output drifted from %s: %v", goldenPath, err)
+			}
+		})
+	}
+}
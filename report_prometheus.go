@@ -0,0 +1,123 @@
+package pulse
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// writePrometheusHeader writes the `# HELP`/`# TYPE` comment pair Prometheus
+// expects before a metric family's first sample.
+func writePrometheusHeader(sb *strings.Builder, name, help, metricType string) {
+	fmt.Fprintf(sb, "# HELP %s %s\n", name, help)
+	fmt.Fprintf(sb, "# TYPE %s %s\n", name, metricType)
+}
+
+// promMetricLabels splits a metric reference into the type ("KPI"/"KRI")
+// and id labels used by the pulse_metric_score/pulse_status series.
+func promMetricLabels(reference string) (metricType, id string) {
+	metricType, _ = GetMetricType(reference)
+	parts := strings.Split(reference, ".")
+	if len(parts) == 3 {
+		id = parts[2]
+	}
+	return metricType, id
+}
+
+// promStatusValue encodes a TrafficLightStatus as the pulse_status gauge
+// value: green=0, yellow=1, red=2. -1 for the empty status WithNoThresholds
+// produces, since no classification happened.
+func promStatusValue(status TrafficLightStatus) int {
+	switch status {
+	case Green:
+		return 0
+	case Yellow:
+		return 1
+	case Red:
+		return 2
+	default:
+		return -1
+	}
+}
+
+// formatOverallReportAsPrometheus renders score in the Prometheus text
+// exposition format: per-category pulse_kpi_score/pulse_kri_score, a
+// pulse_metric_score/pulse_status per metric, top-level
+// pulse_overall_kpi_score/pulse_overall_kri_score, and a
+// pulse_report_timestamp_seconds gauge.
+func (r *ReportGenerator) formatOverallReportAsPrometheus(score *OverallScore) string {
+	var sb strings.Builder
+
+	writePrometheusHeader(&sb, "pulse_report_timestamp_seconds", "Unix timestamp when this report was generated.", "gauge")
+	fmt.Fprintf(&sb, "pulse_report_timestamp_seconds %d\n", time.Now().Unix())
+
+	writePrometheusHeader(&sb, "pulse_overall_kpi_score", "Overall aggregate KPI score (0-100).", "gauge")
+	fmt.Fprintf(&sb, "pulse_overall_kpi_score %d\n", score.KPIScore)
+
+	writePrometheusHeader(&sb, "pulse_overall_kri_score", "Overall aggregate KRI score (0-100).", "gauge")
+	fmt.Fprintf(&sb, "pulse_overall_kri_score %d\n", score.KRIScore)
+
+	writePrometheusHeader(&sb, "pulse_kpi_score", "Per-category aggregate KPI score (0-100).", "gauge")
+	for _, category := range score.Categories {
+		fmt.Fprintf(&sb, "pulse_kpi_score{category=%q} %d\n", category.ID, category.KPIScore)
+	}
+
+	writePrometheusHeader(&sb, "pulse_kri_score", "Per-category aggregate KRI score (0-100).", "gauge")
+	for _, category := range score.Categories {
+		fmt.Fprintf(&sb, "pulse_kri_score{category=%q} %d\n", category.ID, category.KRIScore)
+	}
+
+	writePrometheusHeader(&sb, "pulse_metric_score", "Per-metric score (0-100).", "gauge")
+	for _, category := range score.Categories {
+		for _, metric := range category.Metrics {
+			metricType, id := promMetricLabels(metric.Reference)
+			fmt.Fprintf(&sb, "pulse_metric_score{category=%q,type=%q,id=%q} %d\n", category.ID, metricType, id, metric.Score)
+		}
+	}
+
+	writePrometheusHeader(&sb, "pulse_status", "Traffic light status (green=0, yellow=1, red=2).", "gauge")
+	fmt.Fprintf(&sb, "pulse_status{category=\"overall\",type=\"kpi\"} %d\n", promStatusValue(score.KPIStatus))
+	fmt.Fprintf(&sb, "pulse_status{category=\"overall\",type=\"kri\"} %d\n", promStatusValue(score.KRIStatus))
+	for _, category := range score.Categories {
+		fmt.Fprintf(&sb, "pulse_status{category=%q,type=\"kpi\"} %d\n", category.ID, promStatusValue(category.KPIStatus))
+		fmt.Fprintf(&sb, "pulse_status{category=%q,type=\"kri\"} %d\n", category.ID, promStatusValue(category.KRIStatus))
+		for _, metric := range category.Metrics {
+			metricType, id := promMetricLabels(metric.Reference)
+			fmt.Fprintf(&sb, "pulse_status{category=%q,type=%q,id=%q} %d\n", category.ID, metricType, id, promStatusValue(metric.Status))
+		}
+	}
+
+	return sb.String()
+}
+
+// formatCategoryReportAsPrometheus is the category-scoped equivalent of
+// formatOverallReportAsPrometheus; it omits the overall-level series since
+// score only covers a single category.
+func (r *ReportGenerator) formatCategoryReportAsPrometheus(score *CategoryScore) string {
+	var sb strings.Builder
+
+	writePrometheusHeader(&sb, "pulse_report_timestamp_seconds", "Unix timestamp when this report was generated.", "gauge")
+	fmt.Fprintf(&sb, "pulse_report_timestamp_seconds %d\n", time.Now().Unix())
+
+	writePrometheusHeader(&sb, "pulse_kpi_score", "Per-category aggregate KPI score (0-100).", "gauge")
+	fmt.Fprintf(&sb, "pulse_kpi_score{category=%q} %d\n", score.ID, score.KPIScore)
+
+	writePrometheusHeader(&sb, "pulse_kri_score", "Per-category aggregate KRI score (0-100).", "gauge")
+	fmt.Fprintf(&sb, "pulse_kri_score{category=%q} %d\n", score.ID, score.KRIScore)
+
+	writePrometheusHeader(&sb, "pulse_metric_score", "Per-metric score (0-100).", "gauge")
+	for _, metric := range score.Metrics {
+		metricType, id := promMetricLabels(metric.Reference)
+		fmt.Fprintf(&sb, "pulse_metric_score{category=%q,type=%q,id=%q} %d\n", score.ID, metricType, id, metric.Score)
+	}
+
+	writePrometheusHeader(&sb, "pulse_status", "Traffic light status (green=0, yellow=1, red=2).", "gauge")
+	fmt.Fprintf(&sb, "pulse_status{category=%q,type=\"kpi\"} %d\n", score.ID, promStatusValue(score.KPIStatus))
+	fmt.Fprintf(&sb, "pulse_status{category=%q,type=\"kri\"} %d\n", score.ID, promStatusValue(score.KRIStatus))
+	for _, metric := range score.Metrics {
+		metricType, id := promMetricLabels(metric.Reference)
+		fmt.Fprintf(&sb, "pulse_status{category=%q,type=%q,id=%q} %d\n", score.ID, metricType, id, promStatusValue(metric.Status))
+	}
+
+	return sb.String()
+}
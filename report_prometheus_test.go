@@ -0,0 +1,49 @@
+package pulse
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateOverallReportAsPrometheusIncludesCoreSeries(t *testing.T) {
+	reportGenerator := reportQueryFixture()
+
+	output, err := reportGenerator.GenerateOverallReport(PrometheusFormat)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	content := string(output.Content)
+
+	for _, want := range []string{
+		"# TYPE pulse_overall_kpi_score gauge",
+		"pulse_overall_kpi_score 90",
+		"pulse_overall_kri_score 40",
+		`pulse_kpi_score{category="app_sec"} 90`,
+		`pulse_kri_score{category="app_sec"} 40`,
+		`pulse_metric_score{category="app_sec",type="KPI",id="coverage"} 90`,
+		`pulse_metric_score{category="app_sec",type="KRI",id="incidents"} 40`,
+		`pulse_status{category="app_sec",type="kri"} 2`,
+		"pulse_report_timestamp_seconds",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, content)
+		}
+	}
+}
+
+func TestGenerateCategoryReportAsPrometheusOmitsOverallSeries(t *testing.T) {
+	reportGenerator := reportQueryFixture()
+
+	output, err := reportGenerator.GenerateCategoryReport("app_sec", PrometheusFormat)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	content := string(output.Content)
+
+	if strings.Contains(content, "pulse_overall_kpi_score") {
+		t.Errorf("expected no overall-level series in a category report, got:\n%s", content)
+	}
+	if !strings.Contains(content, `pulse_kpi_score{category="app_sec"} 90`) {
+		t.Errorf("expected pulse_kpi_score for app_sec, got:\n%s", content)
+	}
+}
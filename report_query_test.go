@@ -0,0 +1,106 @@
+package pulse
+
+import (
+	"strings"
+	"testing"
+)
+
+func reportQueryFixture() *ReportGenerator {
+	metricsConfig := &MetricsConfig{
+		Categories: []Category{
+			{
+				ID:   "app_sec",
+				Name: "Application Security",
+				KPIs: []KPI{
+					{ID: "coverage", ScoringBands: []ScoringBand{{Min: FloatPtr(0), Score: 90}}},
+				},
+				KRIs: []KRI{
+					{ID: "incidents", ScoringBands: []ScoringBand{{Min: FloatPtr(0), Score: 40}}},
+				},
+			},
+		},
+	}
+	leversConfig := &LeversConfig{
+		Global: Global{
+			Thresholds:    Thresholds{Green: ThresholdRange{Min: 80, Max: 100}, Yellow: ThresholdRange{Min: 50, Max: 79}, Red: ThresholdRange{Min: 0, Max: 49}},
+			KPIThresholds: Thresholds{Green: ThresholdRange{Min: 80, Max: 100}, Yellow: ThresholdRange{Min: 50, Max: 79}, Red: ThresholdRange{Min: 0, Max: 49}},
+			KRIThresholds: Thresholds{Green: ThresholdRange{Min: 80, Max: 100}, Yellow: ThresholdRange{Min: 50, Max: 79}, Red: ThresholdRange{Min: 0, Max: 49}},
+		},
+		Weights: Weights{Categories: CategoryWeights{"app_sec": 1.0}},
+	}
+	metricsData := &MetricsData{
+		Metrics: []Metric{
+			{Reference: "app_sec.KPI.coverage", Value: 1},
+			{Reference: "app_sec.KRI.incidents", Value: 1},
+		},
+	}
+
+	processor := NewMetricsProcessor(metricsConfig, leversConfig, metricsData)
+	calculator := NewScoreCalculator(processor, MedianScoring)
+	return NewReportGenerator(calculator, TextLabels)
+}
+
+func TestGenerateOverallReportWithTemplateRendersFields(t *testing.T) {
+	reportGenerator := reportQueryFixture()
+
+	output, err := reportGenerator.GenerateOverallReportWithTemplate("KPI={{.KPIScore}} KRI={{.KRIScore}}", TemplateFormat)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(output.Content) != "KPI=90 KRI=40" {
+		t.Fatalf("unexpected template output: %q", output.Content)
+	}
+}
+
+func TestGenerateOverallReportWithTemplateFuncs(t *testing.T) {
+	reportGenerator := reportQueryFixture()
+
+	output, err := reportGenerator.GenerateOverallReportWithTemplate(
+		`{{range .Categories}}{{.ID}}={{weightPercent .ID}}%{{end}}`, TemplateFormat)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(output.Content) != "app_sec=100%" {
+		t.Fatalf("unexpected template output: %q", output.Content)
+	}
+}
+
+func TestGenerateOverallReportWithTemplateRejectsBadSyntax(t *testing.T) {
+	reportGenerator := reportQueryFixture()
+
+	if _, err := reportGenerator.GenerateOverallReportWithTemplate("{{.Unclosed", TemplateFormat); err == nil {
+		t.Fatal("expected an error for invalid template syntax")
+	}
+}
+
+func TestGenerateOverallReportWithJSONPathExtractsRedCategories(t *testing.T) {
+	reportGenerator := reportQueryFixture()
+
+	output, err := reportGenerator.GenerateOverallReportWithTemplate(`{.categories[?(@.kri_status=="red")].id}`, JSONPathFormat)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.TrimSpace(string(output.Content)) != "app_sec" {
+		t.Fatalf("expected app_sec (KRI status red), got %q", output.Content)
+	}
+}
+
+func TestGenerateCategoryReportWithTemplateAndJSONPath(t *testing.T) {
+	reportGenerator := reportQueryFixture()
+
+	tmplOutput, err := reportGenerator.GenerateCategoryReportWithTemplate("app_sec", "{{.KPIScore}}", TemplateFormat)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(tmplOutput.Content) != "90" {
+		t.Fatalf("expected KPIScore 90, got %q", tmplOutput.Content)
+	}
+
+	jsonPathOutput, err := reportGenerator.GenerateCategoryReportWithTemplate("app_sec", "{.kpi_score}", JSONPathFormat)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(jsonPathOutput.Content) != "90" {
+		t.Fatalf("expected kpi_score 90, got %q", jsonPathOutput.Content)
+	}
+}
@@ -0,0 +1,267 @@
+package pulse
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// MetricTrendSummary is the min/max/mean score a single metric reached
+// across a ScoreTrendReport's window.
+type MetricTrendSummary struct {
+	Reference string
+	Min       int
+	Max       int
+	Mean      float64
+}
+
+// ScoreTrendCategoryReport is one category's movement across a
+// ScoreTrendReport's window: its KPI/KRI score at the start and end of the
+// window (and the delta between them), a Unicode block-character sparkline
+// (see sparklineFor) of its KRI score
+// tick by tick, every status transition observed along the way, and a
+// MetricTrendSummary per metric that appeared in at least one snapshot.
+type ScoreTrendCategoryReport struct {
+	ID                string
+	KPIScoreStart     int
+	KPIScoreEnd       int
+	KPIScoreDelta     int
+	KRIScoreStart     int
+	KRIScoreEnd       int
+	KRIScoreDelta     int
+	KRISparkline      string
+	StatusTransitions []string
+	Metrics           []MetricTrendSummary
+}
+
+// ScoreTrendReport is the result of GenerateScoreTrendReport: every
+// category that appeared in the snapshot window [From, To], each with its
+// own ScoreTrendCategoryReport.
+type ScoreTrendReport struct {
+	From       time.Time
+	To         time.Time
+	Categories []ScoreTrendCategoryReport
+}
+
+// findCategoryByID returns the CategoryScore with the given id out of
+// score.Categories, if present.
+func findCategoryByID(score OverallScore, id string) (CategoryScore, bool) {
+	for _, category := range score.Categories {
+		if category.ID == id {
+			return category, true
+		}
+	}
+	return CategoryScore{}, false
+}
+
+// categoryTransitions walks snapshots in chronological order and reports
+// every status change category id underwent, by diffing each consecutive
+// pair with buildReportDiff and keeping only the category matching id. It
+// reuses the same diffing logic as GenerateDiffReport rather than tracking
+// status changes separately.
+func categoryTransitions(id string, snapshots []ScoreSnapshot) []string {
+	var transitions []string
+	for i := 1; i < len(snapshots); i++ {
+		diff := buildReportDiff(snapshots[i-1].Score, snapshots[i].Score)
+		for _, c := range diff.Categories {
+			if c.ID != id || c.OldStatus == c.NewStatus {
+				continue
+			}
+			transitions = append(transitions, fmt.Sprintf("%s: %s -> %s",
+				snapshots[i].Timestamp.Format(time.RFC3339), c.OldStatus, c.NewStatus))
+		}
+	}
+	return transitions
+}
+
+// summarizeMetricTrend computes the min/max/mean of scores, which must be
+// non-empty.
+func summarizeMetricTrend(reference string, scores []int) MetricTrendSummary {
+	summary := MetricTrendSummary{Reference: reference, Min: scores[0], Max: scores[0]}
+	var sum int
+	for _, score := range scores {
+		if score < summary.Min {
+			summary.Min = score
+		}
+		if score > summary.Max {
+			summary.Max = score
+		}
+		sum += score
+	}
+	summary.Mean = float64(sum) / float64(len(scores))
+	return summary
+}
+
+// buildCategoryTrendReport summarizes how category id moved across
+// snapshots, which must be sorted chronologically and non-empty.
+func buildCategoryTrendReport(id string, snapshots []ScoreSnapshot) ScoreTrendCategoryReport {
+	report := ScoreTrendCategoryReport{ID: id}
+
+	if first, ok := findCategoryByID(snapshots[0].Score, id); ok {
+		report.KPIScoreStart = first.KPIScore
+		report.KRIScoreStart = first.KRIScore
+	}
+	if last, ok := findCategoryByID(snapshots[len(snapshots)-1].Score, id); ok {
+		report.KPIScoreEnd = last.KPIScore
+		report.KRIScoreEnd = last.KRIScore
+	}
+	report.KPIScoreDelta = report.KPIScoreEnd - report.KPIScoreStart
+	report.KRIScoreDelta = report.KRIScoreEnd - report.KRIScoreStart
+
+	var kriSeries []ScorePoint
+	metricScores := make(map[string][]int)
+	for _, snap := range snapshots {
+		category, ok := findCategoryByID(snap.Score, id)
+		if !ok {
+			continue
+		}
+		kriSeries = append(kriSeries, ScorePoint{Score: category.KRIScore})
+		for _, metric := range category.Metrics {
+			metricScores[metric.Reference] = append(metricScores[metric.Reference], metric.Score)
+		}
+	}
+	report.KRISparkline = sparklineFor(kriSeries)
+
+	var references []string
+	for reference := range metricScores {
+		references = append(references, reference)
+	}
+	sort.Strings(references)
+	for _, reference := range references {
+		report.Metrics = append(report.Metrics, summarizeMetricTrend(reference, metricScores[reference]))
+	}
+
+	report.StatusTransitions = categoryTransitions(id, snapshots)
+
+	return report
+}
+
+// buildScoreTrendReport groups snapshots (which must be sorted
+// chronologically) by every category ID they mention and summarizes each
+// with buildCategoryTrendReport.
+func buildScoreTrendReport(snapshots []ScoreSnapshot, from, to time.Time) ScoreTrendReport {
+	report := ScoreTrendReport{From: from, To: to}
+
+	seen := make(map[string]bool)
+	var ids []string
+	for _, snap := range snapshots {
+		for _, category := range snap.Score.Categories {
+			if !seen[category.ID] {
+				seen[category.ID] = true
+				ids = append(ids, category.ID)
+			}
+		}
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		report.Categories = append(report.Categories, buildCategoryTrendReport(id, snapshots))
+	}
+
+	return report
+}
+
+// jsonMetricTrendSummary, jsonScoreTrendCategory, and jsonScoreTrendReport
+// mirror MetricTrendSummary/ScoreTrendCategoryReport/ScoreTrendReport for
+// JSON output, using the same snake_case conventions as jsonReport.
+type jsonMetricTrendSummary struct {
+	Reference string  `json:"reference"`
+	Min       int     `json:"min"`
+	Max       int     `json:"max"`
+	Mean      float64 `json:"mean"`
+}
+
+type jsonScoreTrendCategory struct {
+	ID                string                   `json:"id"`
+	KPIScoreStart     int                      `json:"kpi_score_start"`
+	KPIScoreEnd       int                      `json:"kpi_score_end"`
+	KPIScoreDelta     int                      `json:"kpi_score_delta"`
+	KRIScoreStart     int                      `json:"kri_score_start"`
+	KRIScoreEnd       int                      `json:"kri_score_end"`
+	KRIScoreDelta     int                      `json:"kri_score_delta"`
+	KRISparkline      string                   `json:"kri_sparkline"`
+	StatusTransitions []string                 `json:"status_transitions,omitempty"`
+	Metrics           []jsonMetricTrendSummary `json:"metrics,omitempty"`
+}
+
+type jsonScoreTrendReport struct {
+	From       string                   `json:"from"`
+	To         string                   `json:"to"`
+	Categories []jsonScoreTrendCategory `json:"categories"`
+}
+
+// GenerateScoreTrendReport loads every snapshot store recorded in [from, to]
+// and reports, per category, how its KPI/KRI scores and statuses moved
+// across that window: a start/end score and delta, every status
+// transition, a Unicode block-character sparkline of its KRI trajectory,
+// and a min/max/mean per metric. Unlike GenerateTrendReport (which replays
+// a single metric's HistoryStore history), this reads from store's
+// whole-OverallScore
+// snapshots, so it can summarize a category's combined posture rather than
+// one metric at a time.
+func (r *ReportGenerator) GenerateScoreTrendReport(store *SnapshotStore, from, to time.Time, format ReportFormat) (*ReportOutput, error) {
+	snapshots, err := store.Query(from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query snapshot store: %w", err)
+	}
+
+	trend := buildScoreTrendReport(snapshots, from, to)
+
+	switch format {
+	case JSONFormat:
+		jsonReport := jsonScoreTrendReport{
+			From: trend.From.Format(time.RFC3339),
+			To:   trend.To.Format(time.RFC3339),
+		}
+		for _, category := range trend.Categories {
+			jc := jsonScoreTrendCategory{
+				ID:                category.ID,
+				KPIScoreStart:     category.KPIScoreStart,
+				KPIScoreEnd:       category.KPIScoreEnd,
+				KPIScoreDelta:     category.KPIScoreDelta,
+				KRIScoreStart:     category.KRIScoreStart,
+				KRIScoreEnd:       category.KRIScoreEnd,
+				KRIScoreDelta:     category.KRIScoreDelta,
+				KRISparkline:      category.KRISparkline,
+				StatusTransitions: category.StatusTransitions,
+			}
+			for _, metric := range category.Metrics {
+				jc.Metrics = append(jc.Metrics, jsonMetricTrendSummary{
+					Reference: metric.Reference, Min: metric.Min, Max: metric.Max, Mean: metric.Mean,
+				})
+			}
+			jsonReport.Categories = append(jsonReport.Categories, jc)
+		}
+
+		content, err := json.MarshalIndent(jsonReport, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal score trend report: %w", err)
+		}
+		return &ReportOutput{Content: content, ContentType: "text"}, nil
+	case TextFormat, TableFormat:
+		var buf bytes.Buffer
+		fmt.Fprintf(&buf, "Score Trend: %s to %s\n", trend.From.Format("2006-01-02"), trend.To.Format("2006-01-02"))
+
+		if len(trend.Categories) == 0 {
+			fmt.Fprintln(&buf, "No snapshots recorded for this range.")
+			return &ReportOutput{Content: buf.Bytes(), ContentType: "text"}, nil
+		}
+
+		for _, category := range trend.Categories {
+			fmt.Fprintf(&buf, "\n%s\n", sanitizeString(category.ID))
+			fmt.Fprintf(&buf, "  KPI: %d -> %d [%+d]\n", category.KPIScoreStart, category.KPIScoreEnd, category.KPIScoreDelta)
+			fmt.Fprintf(&buf, "  KRI: %d -> %d [%+d] %s\n", category.KRIScoreStart, category.KRIScoreEnd, category.KRIScoreDelta, category.KRISparkline)
+			for _, transition := range category.StatusTransitions {
+				fmt.Fprintf(&buf, "  status change: %s\n", transition)
+			}
+			for _, metric := range category.Metrics {
+				fmt.Fprintf(&buf, "  %s: min=%d max=%d mean=%.1f\n", sanitizeString(metric.Reference), metric.Min, metric.Max, metric.Mean)
+			}
+		}
+		return &ReportOutput{Content: buf.Bytes(), ContentType: "text"}, nil
+	default:
+		return nil, fmt.Errorf("unsupported report format: %s", format)
+	}
+}
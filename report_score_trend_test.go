@@ -0,0 +1,120 @@
+package pulse
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func scoreTrendSnapshotsFixture() []ScoreSnapshot {
+	base := time.Date(2026, 7, 20, 0, 0, 0, 0, time.UTC)
+	return []ScoreSnapshot{
+		{
+			Timestamp: base,
+			Score: OverallScore{Categories: []CategoryScore{
+				{
+					ID: "app_sec", KPIScore: 70, KRIScore: 30, Status: Red,
+					Metrics: []MetricScore{{Reference: "app_sec.KRI.incidents", Score: 30}},
+				},
+			}},
+		},
+		{
+			Timestamp: base.Add(24 * time.Hour),
+			Score: OverallScore{Categories: []CategoryScore{
+				{
+					ID: "app_sec", KPIScore: 80, KRIScore: 55, Status: Yellow,
+					Metrics: []MetricScore{{Reference: "app_sec.KRI.incidents", Score: 55}},
+				},
+			}},
+		},
+		{
+			Timestamp: base.Add(48 * time.Hour),
+			Score: OverallScore{Categories: []CategoryScore{
+				{
+					ID: "app_sec", KPIScore: 85, KRIScore: 85, Status: Green,
+					Metrics: []MetricScore{{Reference: "app_sec.KRI.incidents", Score: 85}},
+				},
+			}},
+		},
+	}
+}
+
+func TestBuildScoreTrendReportComputesDeltasAndTransitions(t *testing.T) {
+	snapshots := scoreTrendSnapshotsFixture()
+	base := snapshots[0].Timestamp
+
+	trend := buildScoreTrendReport(snapshots, base, base.Add(48*time.Hour))
+	if len(trend.Categories) != 1 {
+		t.Fatalf("expected 1 category, got %d", len(trend.Categories))
+	}
+
+	category := trend.Categories[0]
+	if category.KPIScoreStart != 70 || category.KPIScoreEnd != 85 || category.KPIScoreDelta != 15 {
+		t.Fatalf("unexpected KPI trend: %+v", category)
+	}
+	if category.KRIScoreStart != 30 || category.KRIScoreEnd != 85 || category.KRIScoreDelta != 55 {
+		t.Fatalf("unexpected KRI trend: %+v", category)
+	}
+	if len(category.KRISparkline) == 0 {
+		t.Fatal("expected a non-empty sparkline")
+	}
+	if len(category.StatusTransitions) != 2 {
+		t.Fatalf("expected 2 status transitions, got %d: %v", len(category.StatusTransitions), category.StatusTransitions)
+	}
+	if !strings.Contains(category.StatusTransitions[0], "red -> yellow") {
+		t.Errorf("expected the first transition to be red -> yellow, got %q", category.StatusTransitions[0])
+	}
+	if !strings.Contains(category.StatusTransitions[1], "yellow -> green") {
+		t.Errorf("expected the second transition to be yellow -> green, got %q", category.StatusTransitions[1])
+	}
+
+	if len(category.Metrics) != 1 {
+		t.Fatalf("expected 1 metric summary, got %d", len(category.Metrics))
+	}
+	metric := category.Metrics[0]
+	if metric.Min != 30 || metric.Max != 85 || metric.Mean != 56.666666666666664 {
+		t.Fatalf("unexpected metric summary: %+v", metric)
+	}
+}
+
+func TestGenerateScoreTrendReportJSONAndText(t *testing.T) {
+	store := NewSnapshotStore(t.TempDir())
+	for _, snap := range scoreTrendSnapshotsFixture() {
+		if err := store.Save(snap.Score, snap.Timestamp); err != nil {
+			t.Fatalf("failed to save snapshot: %v", err)
+		}
+	}
+
+	generator := NewReportGenerator(NewScoreCalculator(nil, MedianScoring), TextLabels)
+	base := time.Date(2026, 7, 20, 0, 0, 0, 0, time.UTC)
+
+	jsonOutput, err := generator.GenerateScoreTrendReport(store, base, base.Add(48*time.Hour), JSONFormat)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(string(jsonOutput.Content), `"id": "app_sec"`) {
+		t.Errorf("expected JSON output to include app_sec, got:\n%s", jsonOutput.Content)
+	}
+
+	textOutput, err := generator.GenerateScoreTrendReport(store, base, base.Add(48*time.Hour), TextFormat)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	content := string(textOutput.Content)
+	if !strings.Contains(content, "app_sec") || !strings.Contains(content, "status change") {
+		t.Errorf("expected text output to include the category and a status change, got:\n%s", content)
+	}
+}
+
+func TestGenerateScoreTrendReportEmptyWindow(t *testing.T) {
+	store := NewSnapshotStore(t.TempDir())
+	generator := NewReportGenerator(NewScoreCalculator(nil, MedianScoring), TextLabels)
+
+	output, err := generator.GenerateScoreTrendReport(store, time.Now().Add(-24*time.Hour), time.Now(), TextFormat)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(string(output.Content), "No snapshots recorded") {
+		t.Errorf("expected the no-snapshots message, got:\n%s", output.Content)
+	}
+}
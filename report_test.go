@@ -1,6 +1,7 @@
 package pulse
 
 import (
+	"bytes"
 	"encoding/json"
 	"strings"
 	"testing"
@@ -21,7 +22,7 @@ func TestReportGenerator(t *testing.T) {
 						Name:        "Test KPI",
 						Description: "Test KPI description",
 						Unit:        "count",
-						Target:      10,
+						Target:      FloatPtr(10),
 						ScoringBands: []ScoringBand{
 							{Max: FloatPtr(5), Score: 95},
 							{Min: FloatPtr(5), Max: FloatPtr(10), Score: 85},
@@ -37,7 +38,7 @@ func TestReportGenerator(t *testing.T) {
 						Name:        "Test KRI",
 						Description: "Test KRI description",
 						Unit:        "count",
-						Threshold:   5,
+						Target:      FloatPtr(5),
 						ScoringBands: []ScoringBand{
 							{Max: FloatPtr(0), Score: 95},
 							{Min: FloatPtr(0), Max: FloatPtr(2), Score: 85},
@@ -146,7 +147,7 @@ func TestReportGenerator(t *testing.T) {
 	}
 
 	for _, expected := range expectedTextContent {
-		if !strings.Contains(textReport, expected) {
+		if !strings.Contains(string(textReport.Content), expected) {
 			t.Errorf("Expected text report to contain '%s', but it doesn't", expected)
 		}
 	}
@@ -159,7 +160,7 @@ func TestReportGenerator(t *testing.T) {
 
 	// Parse the JSON report
 	var jsonData map[string]interface{}
-	if err := json.Unmarshal([]byte(jsonReport), &jsonData); err != nil {
+	if err := json.Unmarshal(jsonReport.Content, &jsonData); err != nil {
 		t.Fatalf("Failed to parse JSON report: %v", err)
 	}
 
@@ -199,7 +200,7 @@ func TestReportGenerator(t *testing.T) {
 	}
 
 	for _, expected := range expectedCategoryTextContent {
-		if !strings.Contains(categoryTextReport, expected) {
+		if !strings.Contains(string(categoryTextReport.Content), expected) {
 			t.Errorf("Expected category text report to contain '%s', but it doesn't", expected)
 		}
 	}
@@ -212,7 +213,7 @@ func TestReportGenerator(t *testing.T) {
 
 	// Parse the category JSON report
 	var categoryJsonData map[string]interface{}
-	if err := json.Unmarshal([]byte(categoryJsonReport), &categoryJsonData); err != nil {
+	if err := json.Unmarshal(categoryJsonReport.Content, &categoryJsonData); err != nil {
 		t.Fatalf("Failed to parse category JSON report: %v", err)
 	}
 
@@ -279,3 +280,323 @@ func TestReportGenerator(t *testing.T) {
 		t.Errorf("Expected emojiGenerator.formatStatus('unknown') to be '❓', got '%s'", emojiGenerator.formatStatus("unknown"))
 	}
 }
+
+func TestReportGeneratorWithTrend(t *testing.T) {
+	metricsConfig := &MetricsConfig{
+		Categories: []Category{
+			{
+				ID:   "test_cat",
+				Name: "Test Category",
+				KPIs: []KPI{
+					{
+						ID:     "test_kpi",
+						Name:   "Test KPI",
+						Target: FloatPtr(10),
+						ScoringBands: []ScoringBand{
+							{Max: FloatPtr(10), Score: 95},
+							{Min: FloatPtr(10), Score: 30},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	leversConfig := &LeversConfig{
+		Weights: Weights{
+			Categories: CategoryWeights{"test_cat": 1.0},
+		},
+	}
+
+	metricsData := &MetricsData{
+		Metrics: []Metric{
+			{Reference: "test_cat.KPI.test_kpi", Value: 3, Timestamp: time.Now()},
+		},
+	}
+
+	processor := NewMetricsProcessor(metricsConfig, leversConfig, metricsData)
+
+	history := NewHistoryStore(t.TempDir())
+	now := time.Now()
+	if err := history.Append(HistoryPoint{Reference: "test_cat.KPI.test_kpi", Value: 8, Timestamp: now.AddDate(0, 0, -30)}); err != nil {
+		t.Fatalf("failed to seed history: %v", err)
+	}
+	if err := history.Append(HistoryPoint{Reference: "test_cat.KPI.test_kpi", Value: 3, Timestamp: now}); err != nil {
+		t.Fatalf("failed to seed history: %v", err)
+	}
+	processor.SetHistoryStore(history)
+
+	calculator := NewScoreCalculator(processor, MedianScoring)
+	generator := NewReportGenerator(calculator, TextLabels, WithTrend(history))
+
+	textReport, err := generator.GenerateOverallReport(TextFormat)
+	if err != nil {
+		t.Fatalf("Failed to generate overall text report: %v", err)
+	}
+	if !strings.Contains(string(textReport.Content), "30d:") {
+		t.Errorf("Expected text report with trend to contain a 30-day delta, got:\n%s", textReport.Content)
+	}
+
+	jsonReport, err := generator.GenerateOverallReport(JSONFormat)
+	if err != nil {
+		t.Fatalf("Failed to generate overall JSON report: %v", err)
+	}
+	if !strings.Contains(string(jsonReport.Content), `"history"`) {
+		t.Errorf("Expected JSON report with trend to contain a 'history' field, got:\n%s", jsonReport.Content)
+	}
+
+	plainGenerator := NewReportGenerator(calculator, TextLabels)
+	plainReport, err := plainGenerator.GenerateOverallReport(TextFormat)
+	if err != nil {
+		t.Fatalf("Failed to generate overall text report: %v", err)
+	}
+	if strings.Contains(string(plainReport.Content), "30d:") {
+		t.Error("Expected text report without WithTrend to omit deltas")
+	}
+}
+
+func TestScoreSeriesHistoryProviderGetMetricHistory(t *testing.T) {
+	metricsConfig := &MetricsConfig{
+		Categories: []Category{
+			{
+				ID:   "test_cat",
+				Name: "Test Category",
+				KPIs: []KPI{
+					{
+						ID:     "test_kpi",
+						Name:   "Test KPI",
+						Target: FloatPtr(10),
+						ScoringBands: []ScoringBand{
+							{Max: FloatPtr(10), Score: 95},
+							{Min: FloatPtr(10), Score: 30},
+						},
+					},
+				},
+			},
+		},
+	}
+	leversConfig := &LeversConfig{Weights: Weights{Categories: CategoryWeights{"test_cat": 1.0}}}
+	metricsData := &MetricsData{
+		Metrics: []Metric{{Reference: "test_cat.KPI.test_kpi", Value: 3, Timestamp: time.Now()}},
+	}
+	processor := NewMetricsProcessor(metricsConfig, leversConfig, metricsData)
+
+	history := NewHistoryStore(t.TempDir())
+	now := time.Now()
+	if err := history.Append(HistoryPoint{Reference: "test_cat.KPI.test_kpi", Value: 8, Timestamp: now.AddDate(0, 0, -30)}); err != nil {
+		t.Fatalf("failed to seed history: %v", err)
+	}
+	if err := history.Append(HistoryPoint{Reference: "test_cat.KPI.test_kpi", Value: 3, Timestamp: now}); err != nil {
+		t.Fatalf("failed to seed history: %v", err)
+	}
+	processor.SetHistoryStore(history)
+
+	calculator := NewScoreCalculator(processor, MedianScoring)
+	provider := &scoreSeriesHistoryProvider{history: history, scoreCalculator: calculator}
+
+	points := provider.GetMetricHistory("test_cat.KPI.test_kpi", 3)
+	if len(points) != 3 {
+		t.Fatalf("expected 3 history points, got %d", len(points))
+	}
+	if points[len(points)-1].Status != Green {
+		t.Errorf("expected the most recent point to be Green, got %s", points[len(points)-1].Status)
+	}
+
+	if got := provider.GetMetricHistory("unknown.KPI.missing", 3); got != nil {
+		t.Errorf("expected nil history for an unknown metric, got %v", got)
+	}
+}
+
+func TestGenerateOverallReportAsPDFWithTrendIncludesSparklineColumn(t *testing.T) {
+	metricsConfig := &MetricsConfig{
+		Categories: []Category{
+			{
+				ID:   "test_cat",
+				Name: "Test Category",
+				KPIs: []KPI{
+					{
+						ID:     "test_kpi",
+						Name:   "Test KPI",
+						Target: FloatPtr(10),
+						ScoringBands: []ScoringBand{
+							{Max: FloatPtr(10), Score: 95},
+							{Min: FloatPtr(10), Score: 30},
+						},
+					},
+				},
+			},
+		},
+	}
+	leversConfig := &LeversConfig{Weights: Weights{Categories: CategoryWeights{"test_cat": 1.0}}}
+	metricsData := &MetricsData{
+		Metrics: []Metric{{Reference: "test_cat.KPI.test_kpi", Value: 3, Timestamp: time.Now()}},
+	}
+	processor := NewMetricsProcessor(metricsConfig, leversConfig, metricsData)
+
+	history := NewHistoryStore(t.TempDir())
+	now := time.Now()
+	if err := history.Append(HistoryPoint{Reference: "test_cat.KPI.test_kpi", Value: 8, Timestamp: now.AddDate(0, 0, -30)}); err != nil {
+		t.Fatalf("failed to seed history: %v", err)
+	}
+	if err := history.Append(HistoryPoint{Reference: "test_cat.KPI.test_kpi", Value: 3, Timestamp: now}); err != nil {
+		t.Fatalf("failed to seed history: %v", err)
+	}
+	processor.SetHistoryStore(history)
+
+	calculator := NewScoreCalculator(processor, MedianScoring)
+	generator := NewReportGenerator(calculator, TextLabels, WithTrend(history))
+
+	output, err := generator.GenerateOverallReport(PDFFormat)
+	if err != nil {
+		t.Fatalf("Failed to generate overall PDF report: %v", err)
+	}
+	if len(output.Content) == 0 {
+		t.Error("Expected non-empty PDF content with a trend sparkline column")
+	}
+}
+
+func TestGenerateOverallReportAsPDFPrintReadyUsesSpotColorIndicators(t *testing.T) {
+	calculator := reportQueryFixture().scoreCalculator
+
+	plain := NewReportGenerator(calculator, TextLabels)
+	plainOutput, err := plain.GenerateOverallReport(PDFFormat)
+	if err != nil {
+		t.Fatalf("Failed to generate overall PDF report: %v", err)
+	}
+
+	printReady := NewReportGenerator(calculator, TextLabels, WithPrintReady(true))
+	printReadyOutput, err := printReady.GenerateOverallReport(PDFFormat)
+	if err != nil {
+		t.Fatalf("Failed to generate print-ready overall PDF report: %v", err)
+	}
+
+	if len(printReadyOutput.Content) == 0 {
+		t.Error("Expected non-empty PDF content in PrintReady mode")
+	}
+	if bytes.Equal(plainOutput.Content, printReadyOutput.Content) {
+		t.Error("Expected PrintReady mode to render status cells differently from the default RGB text mode")
+	}
+}
+
+func TestReportGeneratorTableFormatWithColor(t *testing.T) {
+	metricsConfig := &MetricsConfig{
+		Categories: []Category{
+			{
+				ID:   "test_cat",
+				Name: "Test Category",
+				KPIs: []KPI{
+					{ID: "test_kpi", Name: "Test KPI", Unit: "count", Target: FloatPtr(10), ScoringBands: []ScoringBand{
+						{Max: FloatPtr(5), Score: 95},
+						{Min: FloatPtr(5), Score: 30},
+					}},
+				},
+			},
+		},
+	}
+
+	leversConfig := &LeversConfig{
+		Global: Global{Thresholds: Thresholds{
+			Green:  ThresholdRange{Min: 80, Max: 100},
+			Yellow: ThresholdRange{Min: 60, Max: 79},
+			Red:    ThresholdRange{Min: 0, Max: 59},
+		}},
+		Weights: Weights{Categories: CategoryWeights{"test_cat": 1.0}},
+	}
+
+	metricsData := &MetricsData{Metrics: []Metric{
+		{Reference: "test_cat.KPI.test_kpi", Value: 3, Timestamp: time.Now()},
+	}}
+
+	processor := NewMetricsProcessor(metricsConfig, leversConfig, metricsData)
+	calculator := NewScoreCalculator(processor, MedianScoring)
+
+	plain := NewReportGenerator(calculator, TextLabels)
+	plainReport, err := plain.GenerateOverallReport(TableFormat)
+	if err != nil {
+		t.Fatalf("Failed to generate table report: %v", err)
+	}
+	if !strings.Contains(string(plainReport.Content), "CATEGORY SCORES:") {
+		t.Errorf("Expected table report to contain a category scores section, got:\n%s", plainReport.Content)
+	}
+	if strings.Contains(string(plainReport.Content), "\x1b[") {
+		t.Error("Expected a plain ReportGenerator to omit ANSI color escapes")
+	}
+
+	colored := NewReportGenerator(calculator, TextLabels, WithColor(true))
+	coloredReport, err := colored.GenerateOverallReport(TableFormat)
+	if err != nil {
+		t.Fatalf("Failed to generate colored table report: %v", err)
+	}
+	if !strings.Contains(string(coloredReport.Content), "\x1b[") {
+		t.Errorf("Expected WithColor(true) to emit ANSI color escapes, got:\n%s", coloredReport.Content)
+	}
+}
+
+func TestGenerateTrendReportIncludesPreviousPeriodDelta(t *testing.T) {
+	metricsConfig := &MetricsConfig{
+		Categories: []Category{
+			{
+				ID:   "test_cat",
+				Name: "Test Category",
+				KPIs: []KPI{
+					{
+						ID:     "test_kpi",
+						Name:   "Test KPI",
+						Target: FloatPtr(10),
+						ScoringBands: []ScoringBand{
+							{Max: FloatPtr(10), Score: 95},
+							{Min: FloatPtr(10), Score: 30},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	leversConfig := &LeversConfig{
+		Weights: Weights{
+			Categories: CategoryWeights{"test_cat": 1.0},
+		},
+	}
+
+	metricsData := &MetricsData{
+		Metrics: []Metric{
+			{Reference: "test_cat.KPI.test_kpi", Value: 3, Timestamp: time.Now()},
+		},
+	}
+
+	processor := NewMetricsProcessor(metricsConfig, leversConfig, metricsData)
+
+	history := NewHistoryStore(t.TempDir())
+	now := time.Now()
+	// Previous 10-day period: metric was failing (score 30). Current 10-day
+	// period: metric recovered (score 95). The delta should reflect the
+	// improvement.
+	if err := history.Append(HistoryPoint{Reference: "test_cat.KPI.test_kpi", Value: 20, Timestamp: now.AddDate(0, 0, -20)}); err != nil {
+		t.Fatalf("failed to seed history: %v", err)
+	}
+	if err := history.Append(HistoryPoint{Reference: "test_cat.KPI.test_kpi", Value: 3, Timestamp: now.AddDate(0, 0, -10)}); err != nil {
+		t.Fatalf("failed to seed history: %v", err)
+	}
+	processor.SetHistoryStore(history)
+
+	calculator := NewScoreCalculator(processor, MedianScoring)
+	generator := NewReportGenerator(calculator, TextLabels)
+
+	from := now.AddDate(0, 0, -10)
+	textOutput, err := generator.GenerateTrendReport("test_cat.KPI.test_kpi", from, now, 24*time.Hour, TextFormat)
+	if err != nil {
+		t.Fatalf("Failed to generate trend report: %v", err)
+	}
+	if !strings.Contains(string(textOutput.Content), "Vs previous period:") {
+		t.Errorf("Expected text trend report to contain a previous-period delta, got:\n%s", textOutput.Content)
+	}
+
+	jsonOutput, err := generator.GenerateTrendReport("test_cat.KPI.test_kpi", from, now, 24*time.Hour, JSONFormat)
+	if err != nil {
+		t.Fatalf("Failed to generate JSON trend report: %v", err)
+	}
+	if !strings.Contains(string(jsonOutput.Content), `"previous_period_delta"`) {
+		t.Errorf("Expected JSON trend report to contain previous_period_delta, got:\n%s", jsonOutput.Content)
+	}
+}
@@ -0,0 +1,72 @@
+package pulse
+
+import "fmt"
+
+// ReportSnapshotMetric is the subset of a previously rendered JSON report's
+// per-metric fields needed to re-evaluate its status against a levers.yaml,
+// without recomputing the underlying score.
+type ReportSnapshotMetric struct {
+	Reference string `json:"reference"`
+	Score     int    `json:"score"`
+	Status    string `json:"status"`
+}
+
+// ReportSnapshotCategory is a single category entry within a ReportSnapshot.
+type ReportSnapshotCategory struct {
+	ID      string                 `json:"id"`
+	Metrics []ReportSnapshotMetric `json:"metrics"`
+}
+
+// ReportSnapshot is a previously rendered JSON report (as emitted by
+// GenerateOverallReport/GenerateCategoryReport with JSONFormat), unmarshaled
+// for use with ReevaluateThresholds. Only the fields threshold re-evaluation
+// needs are modeled here; unknown fields in the source JSON are ignored.
+type ReportSnapshot struct {
+	Categories []ReportSnapshotCategory `json:"categories"`
+}
+
+// ThresholdReevaluation is the result of re-running Green/Yellow/Red
+// classification for a single metric captured in a ReportSnapshot.
+type ThresholdReevaluation struct {
+	Reference string
+	Score     int
+	OldStatus TrafficLightStatus
+	NewStatus TrafficLightStatus
+}
+
+// ReevaluateThresholds re-runs threshold classification for every metric
+// captured in snapshot against leversConfig's current Global.KPIThresholds/
+// Global.KRIThresholds, without recomputing scores or re-fetching metric
+// data. This lets --thresholds-only iterate on levers.yaml against a frozen
+// dataset instead of recollecting metrics. It does not re-evaluate
+// Global.CriticalKRIs or Global.TrendRules, since both require the metric's
+// raw value and history, neither of which a snapshot carries.
+func ReevaluateThresholds(snapshot ReportSnapshot, leversConfig *LeversConfig) ([]ThresholdReevaluation, error) {
+	var results []ThresholdReevaluation
+
+	for _, category := range snapshot.Categories {
+		for _, metric := range category.Metrics {
+			metricType, err := GetMetricType(metric.Reference)
+			if err != nil {
+				return nil, fmt.Errorf("reevaluate thresholds for %s: %w", metric.Reference, err)
+			}
+
+			var thresholds Thresholds
+			switch metricType {
+			case "KPI":
+				thresholds = leversConfig.Global.KPIThresholds
+			case "KRI":
+				thresholds = leversConfig.Global.KRIThresholds
+			}
+
+			results = append(results, ThresholdReevaluation{
+				Reference: metric.Reference,
+				Score:     metric.Score,
+				OldStatus: TrafficLightStatus(metric.Status),
+				NewStatus: determineStatus(metric.Score, thresholds),
+			})
+		}
+	}
+
+	return results, nil
+}
@@ -0,0 +1,63 @@
+package pulse
+
+import "testing"
+
+func thresholdReevaluationFixture() *LeversConfig {
+	return &LeversConfig{
+		Global: Global{
+			KPIThresholds: Thresholds{
+				Green:  ThresholdRange{Min: 85, Max: 100},
+				Yellow: ThresholdRange{Min: 65, Max: 84},
+				Red:    ThresholdRange{Min: 0, Max: 64},
+			},
+			KRIThresholds: Thresholds{
+				Green:  ThresholdRange{Min: 75, Max: 100},
+				Yellow: ThresholdRange{Min: 55, Max: 74},
+				Red:    ThresholdRange{Min: 0, Max: 54},
+			},
+		},
+	}
+}
+
+func TestReevaluateThresholdsReclassifiesAgainstCurrentLevers(t *testing.T) {
+	leversConfig := thresholdReevaluationFixture()
+	snapshot := ReportSnapshot{
+		Categories: []ReportSnapshotCategory{
+			{
+				ID: "app_sec",
+				Metrics: []ReportSnapshotMetric{
+					{Reference: "app_sec.KPI.coverage", Score: 90, Status: "yellow"},
+					{Reference: "app_sec.KRI.incidents", Score: 60, Status: "green"},
+				},
+			},
+		},
+	}
+
+	results, err := ReevaluateThresholds(snapshot, leversConfig)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 reevaluations, got %d", len(results))
+	}
+
+	if results[0].OldStatus != Yellow || results[0].NewStatus != Green {
+		t.Errorf("expected coverage to move from stale yellow to current green, got %s -> %s", results[0].OldStatus, results[0].NewStatus)
+	}
+	if results[1].OldStatus != Green || results[1].NewStatus != Yellow {
+		t.Errorf("expected incidents to move from stale green to current yellow, got %s -> %s", results[1].OldStatus, results[1].NewStatus)
+	}
+}
+
+func TestReevaluateThresholdsRejectsInvalidReference(t *testing.T) {
+	leversConfig := thresholdReevaluationFixture()
+	snapshot := ReportSnapshot{
+		Categories: []ReportSnapshotCategory{
+			{ID: "app_sec", Metrics: []ReportSnapshotMetric{{Reference: "not-a-reference", Score: 50}}},
+		},
+	}
+
+	if _, err := ReevaluateThresholds(snapshot, leversConfig); err == nil {
+		t.Fatal("expected an error for a malformed metric reference")
+	}
+}
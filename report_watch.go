@@ -0,0 +1,180 @@
+package pulse
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// clearScreenEscape clears the terminal and moves the cursor to the
+// top-left, written before each watch tick when w is a TTY.
+const clearScreenEscape = "\x1b[2J\x1b[H"
+
+const hideCursorEscape = "\x1b[?25l"
+const showCursorEscape = "\x1b[?25h"
+
+// isTerminalWriter reports whether w is a character device (a terminal),
+// the same check resolveReportColor uses for --format table auto-color
+// detection.
+func isTerminalWriter(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// statusRank orders TrafficLightStatus from best to worst, so
+// diffMetricStatuses can tell whether a transition improved or degraded.
+func statusRank(status TrafficLightStatus) int {
+	switch status {
+	case Green:
+		return 0
+	case Yellow:
+		return 1
+	case Red:
+		return 2
+	default:
+		return 1
+	}
+}
+
+// diffMetricStatuses compares current against previous by Reference and
+// returns one flagged line per metric whose status changed, e.g.
+// "app_sec.KRI.incidents ↓GREEN -> RED". previous may be nil or empty (the
+// first tick never reports changes).
+func diffMetricStatuses(previous, current []MetricScore) []string {
+	previousStatuses := make(map[string]TrafficLightStatus, len(previous))
+	for _, metric := range previous {
+		previousStatuses[metric.Reference] = metric.Status
+	}
+
+	var changes []string
+	for _, metric := range current {
+		oldStatus, existed := previousStatuses[metric.Reference]
+		if !existed || oldStatus == metric.Status {
+			continue
+		}
+
+		arrow := "↓"
+		if statusRank(metric.Status) < statusRank(oldStatus) {
+			arrow = "↑"
+		}
+		changes = append(changes, fmt.Sprintf("%s %s%s -> %s", metric.Reference, arrow, strings.ToUpper(string(oldStatus)), strings.ToUpper(string(metric.Status))))
+	}
+
+	return changes
+}
+
+// flattenMetrics collects every metric across score's categories, for
+// comparison by diffMetricStatuses between watch ticks.
+func flattenMetrics(score *OverallScore) []MetricScore {
+	var metrics []MetricScore
+	for _, category := range score.Categories {
+		metrics = append(metrics, category.Metrics...)
+	}
+	return metrics
+}
+
+// renderWatchTick writes a single watch iteration to w: a clear-screen
+// escape before the report when w is a TTY, or a timestamped separator line
+// when it isn't (so a non-TTY writer, e.g. a redirected log file, keeps a
+// scrollback of every tick instead of just the latest one), followed by the
+// report content and any flagged status changes.
+func renderWatchTick(w io.Writer, tty bool, content string, changes []string) {
+	if tty {
+		fmt.Fprint(w, clearScreenEscape)
+	} else {
+		fmt.Fprintf(w, "--- %s ---\n", time.Now().Format(time.RFC3339))
+	}
+
+	fmt.Fprintln(w, content)
+
+	if len(changes) > 0 {
+		fmt.Fprintln(w, "Changes since last tick:")
+		for _, change := range changes {
+			fmt.Fprintln(w, "  "+change)
+		}
+	}
+}
+
+// WatchOverallReport recomputes and re-renders the overall report every
+// interval until ctx is canceled, clearing the terminal between ticks when
+// w is a TTY (appending timestamped snapshots instead when it isn't).
+// Metrics whose status changed since the previous tick are flagged with an
+// arrow (↑ improving, ↓ degrading) and their old/new status. The cursor,
+// hidden for the duration of the watch, is always restored before
+// returning, including on cancellation.
+func (r *ReportGenerator) WatchOverallReport(ctx context.Context, format ReportFormat, interval time.Duration, w io.Writer) error {
+	tty := isTerminalWriter(w)
+	if tty {
+		fmt.Fprint(w, hideCursorEscape)
+		defer fmt.Fprint(w, showCursorEscape)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var previous []MetricScore
+	for {
+		overallScore, err := r.scoreCalculator.CalculateOverallScore()
+		if err != nil {
+			return err
+		}
+		output, err := r.GenerateOverallReport(format)
+		if err != nil {
+			return err
+		}
+
+		current := flattenMetrics(overallScore)
+		renderWatchTick(w, tty, string(output.Content), diffMetricStatuses(previous, current))
+		previous = current
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// WatchCategoryReport is the category-scoped equivalent of
+// WatchOverallReport; see its doc comment.
+func (r *ReportGenerator) WatchCategoryReport(ctx context.Context, categoryID string, format ReportFormat, interval time.Duration, w io.Writer) error {
+	tty := isTerminalWriter(w)
+	if tty {
+		fmt.Fprint(w, hideCursorEscape)
+		defer fmt.Fprint(w, showCursorEscape)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var previous []MetricScore
+	for {
+		categoryScore, err := r.scoreCalculator.CalculateCategoryScore(categoryID)
+		if err != nil {
+			return err
+		}
+		output, err := r.GenerateCategoryReport(categoryID, format)
+		if err != nil {
+			return err
+		}
+
+		renderWatchTick(w, tty, string(output.Content), diffMetricStatuses(previous, categoryScore.Metrics))
+		previous = categoryScore.Metrics
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
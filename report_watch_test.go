@@ -0,0 +1,96 @@
+package pulse
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func watchReportFixture(t *testing.T) (*ReportGenerator, *MetricsData) {
+	t.Helper()
+
+	metricsConfig := &MetricsConfig{
+		Categories: []Category{
+			{
+				ID: "app_sec",
+				KRIs: []KRI{
+					{
+						ID: "incidents",
+						ScoringBands: []ScoringBand{
+							{Max: FloatPtr(0), Score: 95},
+							{Min: FloatPtr(0), Score: 30},
+						},
+					},
+				},
+			},
+		},
+	}
+	leversConfig := &LeversConfig{
+		Global: Global{
+			KRIThresholds: Thresholds{
+				Green:  ThresholdRange{Min: 80, Max: 100},
+				Yellow: ThresholdRange{Min: 50, Max: 79},
+				Red:    ThresholdRange{Min: 0, Max: 49},
+			},
+		},
+		Weights: Weights{Categories: CategoryWeights{"app_sec": 1.0}},
+	}
+	metricsData := &MetricsData{Metrics: []Metric{{Reference: "app_sec.KRI.incidents", Value: 0}}}
+
+	processor := NewMetricsProcessor(metricsConfig, leversConfig, metricsData)
+	calculator := NewScoreCalculator(processor, MedianScoring)
+	return NewReportGenerator(calculator, TextLabels), metricsData
+}
+
+func TestWatchOverallReportRendersUntilCanceled(t *testing.T) {
+	reportGenerator, _ := watchReportFixture(t)
+
+	var buf bytes.Buffer
+	ctx, cancel := context.WithTimeout(context.Background(), 12*time.Millisecond)
+	defer cancel()
+
+	err := reportGenerator.WatchOverallReport(ctx, TextFormat, 5*time.Millisecond, &buf)
+	if err != context.DeadlineExceeded {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if !strings.Contains(buf.String(), "KRI incidents") {
+		t.Fatalf("expected at least one rendered tick, got:\n%s", buf.String())
+	}
+}
+
+func TestDiffMetricStatusesIgnoresUnchangedAndNewMetrics(t *testing.T) {
+	previous := []MetricScore{{Reference: "a", Status: Green}}
+	current := []MetricScore{
+		{Reference: "a", Status: Green},
+		{Reference: "b", Status: Red},
+	}
+
+	changes := diffMetricStatuses(previous, current)
+	if len(changes) != 0 {
+		t.Fatalf("expected no changes (a unchanged, b is new), got %v", changes)
+	}
+}
+
+func TestDiffMetricStatusesFlagsDegradeAndImprove(t *testing.T) {
+	previous := []MetricScore{
+		{Reference: "a", Status: Green},
+		{Reference: "b", Status: Red},
+	}
+	current := []MetricScore{
+		{Reference: "a", Status: Red},
+		{Reference: "b", Status: Green},
+	}
+
+	changes := diffMetricStatuses(previous, current)
+	if len(changes) != 2 {
+		t.Fatalf("expected 2 changes, got %d: %v", len(changes), changes)
+	}
+	if !strings.Contains(changes[0], "↓") {
+		t.Fatalf("expected a degrades to be flagged with ↓, got %q", changes[0])
+	}
+	if !strings.Contains(changes[1], "↑") {
+		t.Fatalf("expected b improves to be flagged with ↑, got %q", changes[1])
+	}
+}
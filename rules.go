@@ -0,0 +1,560 @@
+package pulse
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// rules.go implements a small, dependency-free expression language for
+// CustomRule.Expression, so operators can enforce organization-specific
+// invariants over a LeversConfig (e.g. "Red band must be at least 30
+// wide") without a code change or pulling in a general-purpose policy
+// engine like CEL or Starlark. The language supports number/string
+// literals, the comparison operators >, >=, <, <=, ==, !=, the logical
+// combinators && and ||, + and - on numbers, field access (a.red,
+// a.green.min), and four built-in functions: band_width(t), covers(t, lo,
+// hi), category(name), and min_category_band_width(band).
+
+// ruleToken is one lexical token of a CustomRule expression.
+type ruleToken struct {
+	kind string // "ident", "number", "string", "op", "eof"
+	text string
+}
+
+// lexRule tokenizes expr into ruleTokens, so the recursive-descent parser
+// below can work over a flat token stream instead of raw runes.
+func lexRule(expr string) ([]ruleToken, error) {
+	var tokens []ruleToken
+	runes := []rune(expr)
+	i := 0
+	for i < len(runes) {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case unicode.IsDigit(r):
+			start := i
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, ruleToken{kind: "number", text: string(runes[start:i])})
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_') {
+				i++
+			}
+			tokens = append(tokens, ruleToken{kind: "ident", text: string(runes[start:i])})
+		case r == '"':
+			i++
+			start := i
+			for i < len(runes) && runes[i] != '"' {
+				i++
+			}
+			if i >= len(runes) {
+				return nil, fmt.Errorf("unterminated string literal")
+			}
+			tokens = append(tokens, ruleToken{kind: "string", text: string(runes[start:i])})
+			i++
+		case strings.ContainsRune("().,+-", r):
+			tokens = append(tokens, ruleToken{kind: "op", text: string(r)})
+			i++
+		case strings.ContainsRune("<>=!&|", r):
+			two := string(r)
+			if i+1 < len(runes) {
+				two += string(runes[i+1])
+			}
+			switch two {
+			case ">=", "<=", "==", "!=", "&&", "||":
+				tokens = append(tokens, ruleToken{kind: "op", text: two})
+				i += 2
+			default:
+				if r == '>' || r == '<' {
+					tokens = append(tokens, ruleToken{kind: "op", text: string(r)})
+					i++
+				} else {
+					return nil, fmt.Errorf("unexpected character %q", r)
+				}
+			}
+		default:
+			return nil, fmt.Errorf("unexpected character %q", r)
+		}
+	}
+	tokens = append(tokens, ruleToken{kind: "eof"})
+	return tokens, nil
+}
+
+// ruleExpr is a parsed, evaluable node of a CustomRule expression.
+type ruleExpr interface {
+	eval(env *ruleEnv) (interface{}, error)
+}
+
+// ruleEnv is the evaluation context a CustomRule expression runs against:
+// the loaded LeversConfig, so identifiers like "global" and functions like
+// category("name") can resolve against it.
+type ruleEnv struct {
+	leversConfig *LeversConfig
+}
+
+type numberLit float64
+type stringLit string
+type identExpr string
+type fieldExpr struct {
+	base  ruleExpr
+	field string
+}
+type callExpr struct {
+	name string
+	args []ruleExpr
+}
+type binaryExpr struct {
+	op          string
+	left, right ruleExpr
+}
+
+func (n numberLit) eval(env *ruleEnv) (interface{}, error) { return float64(n), nil }
+func (s stringLit) eval(env *ruleEnv) (interface{}, error) { return string(s), nil }
+
+func (id identExpr) eval(env *ruleEnv) (interface{}, error) {
+	switch string(id) {
+	case "global":
+		return env.leversConfig.Global.Thresholds, nil
+	default:
+		return nil, fmt.Errorf("unknown identifier %q", string(id))
+	}
+}
+
+func (f fieldExpr) eval(env *ruleEnv) (interface{}, error) {
+	base, err := f.base.eval(env)
+	if err != nil {
+		return nil, err
+	}
+
+	switch v := base.(type) {
+	case Thresholds:
+		switch f.field {
+		case "red":
+			return v.Red, nil
+		case "yellow":
+			return v.Yellow, nil
+		case "green":
+			return v.Green, nil
+		default:
+			return nil, fmt.Errorf("thresholds have no field %q", f.field)
+		}
+	case ThresholdRange:
+		lower, upper := v.normalizedBounds()
+		switch f.field {
+		case "min":
+			if lower == nil {
+				return nil, fmt.Errorf("band has no lower bound to read .min from")
+			}
+			return lower.Value, nil
+		case "max":
+			if upper == nil {
+				return nil, fmt.Errorf("band has no upper bound to read .max from")
+			}
+			return upper.Value, nil
+		default:
+			return nil, fmt.Errorf("a threshold range has no field %q", f.field)
+		}
+	default:
+		return nil, fmt.Errorf("cannot access field %q on %T", f.field, base)
+	}
+}
+
+func (c callExpr) eval(env *ruleEnv) (interface{}, error) {
+	args := make([]interface{}, len(c.args))
+	for i, a := range c.args {
+		v, err := a.eval(env)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = v
+	}
+
+	switch c.name {
+	case "band_width":
+		t, ok := asThresholdRange(args, 0)
+		if !ok {
+			return nil, fmt.Errorf("band_width expects a threshold range argument")
+		}
+		lower, upper := t.normalizedBounds()
+		if lower == nil || upper == nil {
+			return nil, fmt.Errorf("band_width requires a band with both a lower and upper bound")
+		}
+		return upper.Value - lower.Value, nil
+	case "covers":
+		t, ok := asThresholdRange(args, 0)
+		lo, loOK := asNumber(args, 1)
+		hi, hiOK := asNumber(args, 2)
+		if !ok || !loOK || !hiOK || len(args) != 3 {
+			return nil, fmt.Errorf("covers expects (threshold range, lo number, hi number)")
+		}
+		lower, upper := t.normalizedBounds()
+		return lower != nil && lower.Value <= lo && upper != nil && upper.Value >= hi, nil
+	case "category":
+		name, ok := asString(args, 0)
+		if !ok {
+			return nil, fmt.Errorf("category expects a string argument")
+		}
+		return env.leversConfig.Weights.CategoryThresholds[name], nil
+	case "min_category_band_width":
+		band, ok := asString(args, 0)
+		if !ok {
+			return nil, fmt.Errorf("min_category_band_width expects a string argument (\"red\", \"yellow\", or \"green\")")
+		}
+		return minCategoryBandWidth(env.leversConfig, band)
+	default:
+		return nil, fmt.Errorf("unknown function %q", c.name)
+	}
+}
+
+func (b binaryExpr) eval(env *ruleEnv) (interface{}, error) {
+	left, err := b.left.eval(env)
+	if err != nil {
+		return nil, err
+	}
+
+	if b.op == "&&" || b.op == "||" {
+		leftBool, ok := left.(bool)
+		if !ok {
+			return nil, fmt.Errorf("%q requires a boolean left-hand side", b.op)
+		}
+		if b.op == "&&" && !leftBool {
+			return false, nil
+		}
+		if b.op == "||" && leftBool {
+			return true, nil
+		}
+		right, err := b.right.eval(env)
+		if err != nil {
+			return nil, err
+		}
+		rightBool, ok := right.(bool)
+		if !ok {
+			return nil, fmt.Errorf("%q requires a boolean right-hand side", b.op)
+		}
+		return rightBool, nil
+	}
+
+	right, err := b.right.eval(env)
+	if err != nil {
+		return nil, err
+	}
+
+	if b.op == "+" || b.op == "-" {
+		leftNum, leftOK := left.(float64)
+		rightNum, rightOK := right.(float64)
+		if !leftOK || !rightOK {
+			return nil, fmt.Errorf("%q requires numeric operands", b.op)
+		}
+		if b.op == "+" {
+			return leftNum + rightNum, nil
+		}
+		return leftNum - rightNum, nil
+	}
+
+	leftNum, leftOK := left.(float64)
+	rightNum, rightOK := right.(float64)
+	if !leftOK || !rightOK {
+		return nil, fmt.Errorf("%q requires numeric operands", b.op)
+	}
+	switch b.op {
+	case ">":
+		return leftNum > rightNum, nil
+	case ">=":
+		return leftNum >= rightNum, nil
+	case "<":
+		return leftNum < rightNum, nil
+	case "<=":
+		return leftNum <= rightNum, nil
+	case "==":
+		return leftNum == rightNum, nil
+	case "!=":
+		return leftNum != rightNum, nil
+	default:
+		return nil, fmt.Errorf("unknown operator %q", b.op)
+	}
+}
+
+// asThresholdRange returns args[i] as a ThresholdRange, so built-in
+// functions can type-check their positional arguments without repeating
+// bounds and type assertions inline.
+func asThresholdRange(args []interface{}, i int) (ThresholdRange, bool) {
+	if i >= len(args) {
+		return ThresholdRange{}, false
+	}
+	t, ok := args[i].(ThresholdRange)
+	return t, ok
+}
+
+func asNumber(args []interface{}, i int) (float64, bool) {
+	if i >= len(args) {
+		return 0, false
+	}
+	n, ok := args[i].(float64)
+	return n, ok
+}
+
+func asString(args []interface{}, i int) (string, bool) {
+	if i >= len(args) {
+		return "", false
+	}
+	s, ok := args[i].(string)
+	return s, ok
+}
+
+// minCategoryBandWidth returns the narrowest band width, among band
+// ("red", "yellow", or "green") across every category in
+// leversConfig.Weights.CategoryThresholds. It returns posInf (1<<62) when
+// there are no categories configured, so a rule like
+// `min_category_band_width("yellow") >= 10` passes trivially rather than
+// failing on a config that hasn't defined any category overrides yet.
+func minCategoryBandWidth(leversConfig *LeversConfig, band string) (float64, error) {
+	best := float64(-1)
+	for _, thresholds := range leversConfig.Weights.CategoryThresholds {
+		var t ThresholdRange
+		switch band {
+		case "red":
+			t = thresholds.Red
+		case "yellow":
+			t = thresholds.Yellow
+		case "green":
+			t = thresholds.Green
+		default:
+			return 0, fmt.Errorf("min_category_band_width: unknown band %q", band)
+		}
+
+		lower, upper := t.normalizedBounds()
+		if lower == nil || upper == nil {
+			continue
+		}
+		width := upper.Value - lower.Value
+		if best < 0 || width < best {
+			best = width
+		}
+	}
+	if best < 0 {
+		return posInf, nil
+	}
+	return best, nil
+}
+
+// posInf is returned by minCategoryBandWidth when no category has the
+// requested band configured, so comparisons against it default to passing.
+const posInf = 1 << 62
+
+// ruleParser parses a lexed CustomRule expression via recursive descent,
+// with precedence (low to high): || , && , comparisons, + / -.
+type ruleParser struct {
+	tokens []ruleToken
+	pos    int
+}
+
+func (p *ruleParser) peek() ruleToken { return p.tokens[p.pos] }
+
+func (p *ruleParser) next() ruleToken {
+	t := p.tokens[p.pos]
+	if t.kind != "eof" {
+		p.pos++
+	}
+	return t
+}
+
+func (p *ruleParser) expectOp(op string) error {
+	t := p.next()
+	if t.kind != "op" || t.text != op {
+		return fmt.Errorf("expected %q, got %q", op, t.text)
+	}
+	return nil
+}
+
+func parseRuleExpression(expr string) (ruleExpr, error) {
+	tokens, err := lexRule(expr)
+	if err != nil {
+		return nil, err
+	}
+	p := &ruleParser{tokens: tokens}
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.peek().kind != "eof" {
+		return nil, fmt.Errorf("unexpected trailing token %q", p.peek().text)
+	}
+	return node, nil
+}
+
+func (p *ruleParser) parseOr() (ruleExpr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == "op" && p.peek().text == "||" {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = binaryExpr{op: "||", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *ruleParser) parseAnd() (ruleExpr, error) {
+	left, err := p.parseCmp()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == "op" && p.peek().text == "&&" {
+		p.next()
+		right, err := p.parseCmp()
+		if err != nil {
+			return nil, err
+		}
+		left = binaryExpr{op: "&&", left: left, right: right}
+	}
+	return left, nil
+}
+
+var cmpOps = map[string]bool{">": true, ">=": true, "<": true, "<=": true, "==": true, "!=": true}
+
+func (p *ruleParser) parseCmp() (ruleExpr, error) {
+	left, err := p.parseAdd()
+	if err != nil {
+		return nil, err
+	}
+	if p.peek().kind == "op" && cmpOps[p.peek().text] {
+		op := p.next().text
+		right, err := p.parseAdd()
+		if err != nil {
+			return nil, err
+		}
+		return binaryExpr{op: op, left: left, right: right}, nil
+	}
+	return left, nil
+}
+
+func (p *ruleParser) parseAdd() (ruleExpr, error) {
+	left, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == "op" && (p.peek().text == "+" || p.peek().text == "-") {
+		op := p.next().text
+		right, err := p.parsePrimary()
+		if err != nil {
+			return nil, err
+		}
+		left = binaryExpr{op: op, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *ruleParser) parsePrimary() (ruleExpr, error) {
+	t := p.next()
+	var node ruleExpr
+
+	switch t.kind {
+	case "number":
+		v, err := strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q: %w", t.text, err)
+		}
+		node = numberLit(v)
+	case "string":
+		node = stringLit(t.text)
+	case "ident":
+		if p.peek().kind == "op" && p.peek().text == "(" {
+			p.next()
+			var args []ruleExpr
+			for !(p.peek().kind == "op" && p.peek().text == ")") {
+				arg, err := p.parseOr()
+				if err != nil {
+					return nil, err
+				}
+				args = append(args, arg)
+				if p.peek().kind == "op" && p.peek().text == "," {
+					p.next()
+				}
+			}
+			if err := p.expectOp(")"); err != nil {
+				return nil, err
+			}
+			node = callExpr{name: t.text, args: args}
+		} else {
+			node = identExpr(t.text)
+		}
+	case "op":
+		if t.text == "(" {
+			inner, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expectOp(")"); err != nil {
+				return nil, err
+			}
+			node = inner
+		} else {
+			return nil, fmt.Errorf("unexpected token %q", t.text)
+		}
+	default:
+		return nil, fmt.Errorf("unexpected token %q", t.text)
+	}
+
+	for p.peek().kind == "op" && p.peek().text == "." {
+		p.next()
+		field := p.next()
+		if field.kind != "ident" {
+			return nil, fmt.Errorf("expected field name after \".\", got %q", field.text)
+		}
+		node = fieldExpr{base: node, field: field.text}
+	}
+
+	return node, nil
+}
+
+// EvaluateCustomRule parses and evaluates rule.Expression against
+// leversConfig, returning whether the rule passed.
+func EvaluateCustomRule(rule CustomRule, leversConfig *LeversConfig) (bool, error) {
+	expr, err := parseRuleExpression(rule.Expression)
+	if err != nil {
+		return false, fmt.Errorf("rule %q: %w", rule.ID, err)
+	}
+
+	result, err := expr.eval(&ruleEnv{leversConfig: leversConfig})
+	if err != nil {
+		return false, fmt.Errorf("rule %q: %w", rule.ID, err)
+	}
+
+	ok, isBool := result.(bool)
+	if !isBool {
+		return false, fmt.Errorf("rule %q: expression must evaluate to a boolean, got %T", rule.ID, result)
+	}
+	return ok, nil
+}
+
+// ValidateCustomRules evaluates every rule in leversConfig.Rules, returning
+// one ValidationCheck per rule: OK when the rule's expression evaluates to
+// true, failing (with rule.Message, or the evaluation error) otherwise. A
+// rule whose Severity is "warning" still produces a ValidationCheck here;
+// callers that need the Severity distinction (e.g. ValidateLevers, for its
+// ValidationIssue.Severity) should consult leversConfig.Rules directly.
+func ValidateCustomRules(leversConfig *LeversConfig) []ValidationCheck {
+	checks := make([]ValidationCheck, 0, len(leversConfig.Rules))
+	for _, rule := range leversConfig.Rules {
+		ok, err := EvaluateCustomRule(rule, leversConfig)
+		if err != nil {
+			checks = append(checks, ValidationCheck{Name: rule.ID, OK: false, Details: err.Error()})
+			continue
+		}
+		if !ok {
+			checks = append(checks, ValidationCheck{Name: rule.ID, OK: false, Details: rule.Message})
+			continue
+		}
+		checks = append(checks, ValidationCheck{Name: rule.ID, OK: true})
+	}
+	return checks
+}
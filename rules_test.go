@@ -0,0 +1,162 @@
+package pulse
+
+import "testing"
+
+func thresholdsForRuleTest(redMax, yellowMax int) Thresholds {
+	return Thresholds{
+		Red:    ThresholdRange{Min: 0, Max: redMax},
+		Yellow: ThresholdRange{Min: redMax + 1, Max: yellowMax},
+		Green:  ThresholdRange{Min: yellowMax + 1, Max: 100},
+	}
+}
+
+func TestEvaluateCustomRuleBandWidth(t *testing.T) {
+	leversConfig := &LeversConfig{Global: Global{Thresholds: thresholdsForRuleTest(30, 69)}}
+
+	rule := CustomRule{ID: "red_wide_enough", Expression: "band_width(global.red) >= 30"}
+	ok, err := EvaluateCustomRule(rule, leversConfig)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !ok {
+		t.Fatal("expected a 30-wide Red band to satisfy band_width(global.red) >= 30")
+	}
+}
+
+func TestEvaluateCustomRuleCategoryComparison(t *testing.T) {
+	leversConfig := &LeversConfig{
+		Global: Global{Thresholds: thresholdsForRuleTest(49, 79)},
+		Weights: Weights{CategoryThresholds: CategoryThresholds{
+			"app_sec": thresholdsForRuleTest(39, 84),
+		}},
+	}
+
+	rule := CustomRule{ID: "app_sec_stricter", Expression: `category("app_sec").green.min >= global.green.min + 5`}
+	ok, err := EvaluateCustomRule(rule, leversConfig)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !ok {
+		t.Fatal("expected app_sec's Green.Min (85) to be >= global Green.Min (80) + 5")
+	}
+}
+
+func TestEvaluateCustomRuleFailsWhenConditionIsNotMet(t *testing.T) {
+	leversConfig := &LeversConfig{Global: Global{Thresholds: thresholdsForRuleTest(9, 79)}}
+
+	rule := CustomRule{ID: "red_wide_enough", Message: "Red band must be at least 30 wide", Expression: "band_width(global.red) >= 30"}
+	ok, err := EvaluateCustomRule(rule, leversConfig)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ok {
+		t.Fatal("expected a 9-wide Red band to fail a >= 30 requirement")
+	}
+}
+
+func TestEvaluateCustomRuleMinCategoryBandWidth(t *testing.T) {
+	leversConfig := &LeversConfig{
+		Weights: Weights{CategoryThresholds: CategoryThresholds{
+			"app_sec":  thresholdsForRuleTest(49, 60), // Yellow 50-60, width 10
+			"data_sec": thresholdsForRuleTest(49, 80), // Yellow 50-80, width 30
+		}},
+	}
+
+	rule := CustomRule{ID: "yellow_not_too_narrow", Expression: `min_category_band_width("yellow") >= 10`}
+	ok, err := EvaluateCustomRule(rule, leversConfig)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !ok {
+		t.Fatal("expected the narrowest category Yellow band (10) to satisfy >= 10")
+	}
+
+	rule.Expression = `min_category_band_width("yellow") >= 11`
+	ok, err = EvaluateCustomRule(rule, leversConfig)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ok {
+		t.Fatal("expected the narrowest category Yellow band (10) to fail >= 11")
+	}
+}
+
+func TestEvaluateCustomRuleMinCategoryBandWidthPassesWithNoCategories(t *testing.T) {
+	leversConfig := &LeversConfig{}
+
+	rule := CustomRule{ID: "yellow_not_too_narrow", Expression: `min_category_band_width("yellow") >= 10`}
+	ok, err := EvaluateCustomRule(rule, leversConfig)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !ok {
+		t.Fatal("expected a config with no category overrides to pass trivially")
+	}
+}
+
+func TestEvaluateCustomRuleRejectsNonBooleanExpression(t *testing.T) {
+	leversConfig := &LeversConfig{Global: Global{Thresholds: thresholdsForRuleTest(49, 79)}}
+
+	rule := CustomRule{ID: "not_a_condition", Expression: "band_width(global.red)"}
+	if _, err := EvaluateCustomRule(rule, leversConfig); err == nil {
+		t.Fatal("expected a non-boolean expression to error")
+	}
+}
+
+func TestEvaluateCustomRuleRejectsSyntaxError(t *testing.T) {
+	leversConfig := &LeversConfig{}
+
+	rule := CustomRule{ID: "broken", Expression: "band_width(global.red >="}
+	if _, err := EvaluateCustomRule(rule, leversConfig); err == nil {
+		t.Fatal("expected a malformed expression to error")
+	}
+}
+
+func TestValidateCustomRulesReturnsOneCheckPerRule(t *testing.T) {
+	leversConfig := &LeversConfig{
+		Global: Global{Thresholds: thresholdsForRuleTest(30, 69)},
+		Rules: []CustomRule{
+			{ID: "red_wide_enough", Message: "Red band must be at least 30 wide", Expression: "band_width(global.red) >= 30"},
+			{ID: "always_false", Message: "this should fail", Expression: "1 == 2"},
+		},
+	}
+
+	checks := ValidateCustomRules(leversConfig)
+	if len(checks) != 2 {
+		t.Fatalf("expected one check per rule, got %d", len(checks))
+	}
+	if !checks[0].OK {
+		t.Fatalf("expected red_wide_enough to pass, got %+v", checks[0])
+	}
+	if checks[1].OK || checks[1].Details != "this should fail" {
+		t.Fatalf("expected always_false to fail with its message, got %+v", checks[1])
+	}
+}
+
+func TestValidateLeversIncludesCustomRuleIssuesWithSeverity(t *testing.T) {
+	leversConfig := &LeversConfig{
+		Weights: Weights{Categories: CategoryWeights{"app_sec": 1.0}},
+		Global:  Global{Thresholds: thresholdsForRuleTest(49, 79)},
+		Rules: []CustomRule{
+			{ID: "warn_rule", Severity: "warning", Message: "just a warning", Expression: "1 == 2"},
+		},
+	}
+
+	report := ValidateLevers(leversConfig)
+	if report.OK() {
+		t.Fatal("expected the failing custom rule to make the report not OK")
+	}
+
+	found := false
+	for _, issue := range report.Issues {
+		if issue.Code == "warn_rule" {
+			found = true
+			if issue.Severity != SeverityWarning {
+				t.Fatalf("expected warn_rule's issue to carry SeverityWarning, got %q", issue.Severity)
+			}
+		}
+	}
+	if !found {
+		t.Fatal("expected a warn_rule issue in the report")
+	}
+}
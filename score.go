@@ -2,8 +2,11 @@ package pulse
 
 import (
 	"fmt"
+	"math"
 	"sort"
 	"strings"
+	"sync"
+	"time"
 )
 
 // ScoringMethod defines the method used for score calculation
@@ -14,69 +17,610 @@ const (
 	MedianScoring ScoringMethod = "median"
 	// AverageScoring uses average for category scores and weighted average for overall score
 	AverageScoring ScoringMethod = "average"
+	// PercentileScoring aggregates category scores using the percentile
+	// configured via WithPercentile (defaultPercentile when not set) instead
+	// of the median or average: a low percentile (e.g. p25) gives a
+	// pessimistic posture that weights the worst metrics more heavily, a
+	// high one (e.g. p75) an optimistic posture.
+	PercentileScoring ScoringMethod = "percentile"
+	// WeightedScoring aggregates a category's metrics as
+	// sum(score_i * weight_i * confidence_i) / sum(weight_i * confidence_i),
+	// using each KPI/KRI's configured Weight (equal weighting when unset) and
+	// Confidence (decayed toward zero for stale observations; see
+	// Global.MaxMetricAgeDays).
+	WeightedScoring ScoringMethod = "weighted"
 )
 
+// defaultPercentile is the percentile CalculateCategoryScore/CalculateOverallScore
+// use for PercentileScoring when WithPercentile hasn't been set.
+const defaultPercentile = 50.0
+
+// defaultTrendHalfLife is the decay half-life used when a ScoreCalculator is
+// constructed without WithTrendHalfLife: a scored point this old carries
+// half the weight of a fresh one in TrendScore.
+const defaultTrendHalfLife = 30 * 24 * time.Hour
+
 // ScoreCalculator handles calculation of scores for metrics and categories
 type ScoreCalculator struct {
 	metricsProcessor *MetricsProcessor
 	scoringMethod    ScoringMethod
+	trendHalfLife    time.Duration
+	percentile       float64
+	bandResolver     *BandResolver
+
+	formulaMu    sync.Mutex
+	formulaCache map[string]*Formula
+
+	noThresholds bool
+}
+
+// ScoreCalculatorOption configures optional ScoreCalculator behavior.
+type ScoreCalculatorOption func(*ScoreCalculator)
+
+// WithTrendHalfLife sets the exponential decay half-life used by
+// CalculateMetricScore's TrendScore: a scored point halfLife old is weighted
+// half as heavily as a fresh one. Non-positive values are ignored (no
+// panic) and the default half-life, defaultTrendHalfLife, is kept.
+func WithTrendHalfLife(halfLife time.Duration) ScoreCalculatorOption {
+	return func(s *ScoreCalculator) {
+		if halfLife > 0 {
+			s.trendHalfLife = halfLife
+		}
+	}
+}
+
+// WithPercentile sets the percentile (0-100) used when scoringMethod is
+// PercentileScoring. Values outside [0, 100] are ignored and the default is
+// kept.
+func WithPercentile(p float64) ScoreCalculatorOption {
+	return func(s *ScoreCalculator) {
+		if p >= 0 && p <= 100 {
+			s.percentile = p
+		}
+	}
+}
+
+// WithBandResolver routes every Green/Yellow/Red decision through resolver
+// instead of the plain determineStatus, so Thresholds.Hysteresis (when set)
+// can keep a target from flapping bands on small score movements across a
+// boundary.
+func WithBandResolver(resolver *BandResolver) ScoreCalculatorOption {
+	return func(s *ScoreCalculator) {
+		s.bandResolver = resolver
+	}
+}
+
+// WithNoThresholds disables Green/Yellow/Red classification: status always
+// comes back as the empty TrafficLightStatus, regardless of score, bands, or
+// any configured BandResolver. Scores, trends, and violations are still
+// computed as usual. This backs --no-thresholds, for runs that only care
+// about the numeric scores and want to defer classification to a later,
+// faster pass (see ReevaluateThresholds).
+func WithNoThresholds() ScoreCalculatorOption {
+	return func(s *ScoreCalculator) {
+		s.noThresholds = true
+	}
 }
 
 // NewScoreCalculator creates a new ScoreCalculator
-func NewScoreCalculator(metricsProcessor *MetricsProcessor, scoringMethod ScoringMethod) *ScoreCalculator {
+func NewScoreCalculator(metricsProcessor *MetricsProcessor, scoringMethod ScoringMethod, opts ...ScoreCalculatorOption) *ScoreCalculator {
 	// Default to median scoring if not specified
 	if scoringMethod == "" {
 		scoringMethod = MedianScoring
 	}
 
-	return &ScoreCalculator{
+	s := &ScoreCalculator{
 		metricsProcessor: metricsProcessor,
 		scoringMethod:    scoringMethod,
+		trendHalfLife:    defaultTrendHalfLife,
+		percentile:       defaultPercentile,
+		formulaCache:     make(map[string]*Formula),
 	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
 }
 
-// CalculateMetricScore calculates the score for a single metric
+// aggregateScores collapses scores into a single score using s.scoringMethod:
+// median, average, or (for PercentileScoring) the percentile configured via
+// WithPercentile.
+func (s *ScoreCalculator) aggregateScores(scores []int) int {
+	switch s.scoringMethod {
+	case PercentileScoring:
+		return s.CalculatePercentile(scores, nil, s.percentile)
+	case AverageScoring:
+		return calculateAverage(scores)
+	default:
+		return calculateMedian(scores)
+	}
+}
+
+// categoryAggregate collapses scores into a single category score: for
+// WeightedScoring it computes sum(score_i*weight_i)/sum(weight_i) from the
+// parallel weights slice (each weight already folding in the metric's
+// Confidence); for every other scoring method it falls back to
+// aggregateScores and ignores weights.
+func (s *ScoreCalculator) categoryAggregate(scores []int, weights []float64) int {
+	if s.scoringMethod != WeightedScoring {
+		return s.aggregateScores(scores)
+	}
+
+	var weightedSum, totalWeight float64
+	for i, score := range scores {
+		weightedSum += float64(score) * weights[i]
+		totalWeight += weights[i]
+	}
+	if totalWeight <= 0 {
+		return calculateMedian(scores)
+	}
+
+	return int(weightedSum / totalWeight)
+}
+
+// CalculatePercentile returns the p-th weighted percentile (0-100) of
+// values, walking the weighted CDF the same way calculateWeightedMedian
+// does for p=50: the first value whose cumulative weight exceeds p% of the
+// total, or the integer mean of two neighboring values when the cumulative
+// weight lands exactly on it. A nil or mismatched-length weights slice is
+// treated as equal weighting.
+func (s *ScoreCalculator) CalculatePercentile(values []int, weights []float64, p float64) int {
+	return weightedPercentile(values, weights, p)
+}
+
+// weightedPercentile is the weight-aware percentile calculation shared by
+// CalculatePercentile and computeDistribution's summary statistics.
+func weightedPercentile(values []int, weights []float64, p float64) int {
+	if len(values) == 0 {
+		return 0
+	}
+	if len(weights) != len(values) {
+		weights = make([]float64, len(values))
+		for i := range weights {
+			weights[i] = 1.0
+		}
+	}
+
+	type weightedValue struct {
+		value  int
+		weight float64
+	}
+	pairs := make([]weightedValue, len(values))
+	for i := range values {
+		pairs[i] = weightedValue{value: values[i], weight: weights[i]}
+	}
+	sort.Slice(pairs, func(i, j int) bool { return pairs[i].value < pairs[j].value })
+
+	var totalWeight float64
+	for _, w := range weights {
+		totalWeight += w
+	}
+	if totalWeight <= 0 {
+		return calculateMedian(values)
+	}
+
+	target := (p / 100) * totalWeight
+	var cumulative float64
+	for i, pair := range pairs {
+		cumulative += pair.weight
+		if cumulative > target {
+			return pair.value
+		} else if cumulative == target && i < len(pairs)-1 {
+			return (pair.value + pairs[i+1].value) / 2
+		}
+	}
+
+	return pairs[len(pairs)-1].value
+}
+
+// defaultDistributionBuckets are the histogram boundaries computeDistribution
+// uses when Global.DistributionBuckets is empty.
+var defaultDistributionBuckets = []float64{0, 10, 20, 30, 40, 50, 60, 70, 80, 90, 100}
+
+// computeDistribution buckets scores against buckets (Counts[i] counts
+// scores in [buckets[i], buckets[i+1]), with the final bucket's upper bound
+// inclusive) and records the Min/Max/P25/P50/P75/P90 summary statistics
+// alongside it. An empty buckets slice falls back to defaultDistributionBuckets.
+func computeDistribution(scores []int, buckets []float64) ScoreDistribution {
+	if len(buckets) == 0 {
+		buckets = defaultDistributionBuckets
+	}
+
+	dist := ScoreDistribution{Buckets: buckets, Counts: make([]uint64, len(buckets)-1)}
+	if len(scores) == 0 {
+		return dist
+	}
+
+	sorted := make([]int, len(scores))
+	copy(sorted, scores)
+	sort.Ints(sorted)
+
+	dist.Min = sorted[0]
+	dist.Max = sorted[len(sorted)-1]
+	dist.P25 = weightedPercentile(scores, nil, 25)
+	dist.P50 = weightedPercentile(scores, nil, 50)
+	dist.P75 = weightedPercentile(scores, nil, 75)
+	dist.P90 = weightedPercentile(scores, nil, 90)
+
+	for _, score := range scores {
+		value := float64(score)
+		for i := 0; i < len(buckets)-1; i++ {
+			last := i == len(buckets)-2
+			if value >= buckets[i] && (value < buckets[i+1] || (last && value <= buckets[i+1])) {
+				dist.Counts[i]++
+				break
+			}
+		}
+	}
+
+	return dist
+}
+
+// CalculateMetricScore calculates the score for a single metric, along with
+// a TrendScore/Trend summarizing its recent scored history when a
+// HistoryStore is attached via MetricsProcessor.SetHistoryStore.
 func (s *ScoreCalculator) CalculateMetricScore(metric Metric) (*MetricScore, error) {
-	// Get the metric definition
 	metricDef, err := s.metricsProcessor.GetMetricDefinition(metric.Reference)
 	if err != nil {
 		return nil, err
 	}
 
-	// Calculate score based on metric type
-	var score int
-	var status TrafficLightStatus
-
 	metricType, err := GetMetricType(metric.Reference)
 	if err != nil {
 		return nil, err
 	}
 
-	if metricType == "KPI" {
+	var bands []ScoringBand
+	var thresholds Thresholds
+	var confidence *float64
+
+	switch metricType {
+	case "KPI":
 		kpi, ok := metricDef.(KPI)
 		if !ok {
 			return nil, fmt.Errorf("failed to cast metric definition to KPI")
 		}
-		score = calculateKPIScore(metric.Value, kpi)
-		status = determineStatus(score, s.metricsProcessor.leversConfig.Global.KPIThresholds)
-	} else if metricType == "KRI" {
+		bands = kpi.ScoringBands
+		thresholds = s.metricsProcessor.leversConfig.Global.KPIThresholds
+		confidence = kpi.Confidence
+	case "KRI":
 		kri, ok := metricDef.(KRI)
 		if !ok {
 			return nil, fmt.Errorf("failed to cast metric definition to KRI")
 		}
-		score = calculateKRIScore(metric.Value, kri)
-		status = determineStatus(score, s.metricsProcessor.leversConfig.Global.KRIThresholds)
-	} else {
+		bands = kri.ScoringBands
+		thresholds = s.metricsProcessor.leversConfig.Global.KRIThresholds
+		confidence = kri.Confidence
+	default:
 		return nil, fmt.Errorf("unknown metric type: %s", metricType)
 	}
 
+	score, err := s.scoreForValue(metric.Reference, metric.Value)
+	if err != nil {
+		return nil, err
+	}
+	status := s.status(metric.Reference, score, thresholds)
+
+	trendScore, trend, err := s.calculateTrend(metric.Reference, metric.Timestamp, score)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvedConfidence := confidenceFor(confidence, metric.Timestamp, s.metricsProcessor.leversConfig.Global.MaxMetricAgeDays, time.Now())
+	configuredConfidence := 1.0
+	if confidence != nil {
+		configuredConfidence = *confidence
+	}
+
+	violation := violationMessage(metric.Value, score, bands)
+	forced := false
+	if rule, ok := s.metricsProcessor.leversConfig.Global.TrendRules[metric.Reference]; ok {
+		fired, err := evaluateTrendRule(s.metricsProcessor.History(), metric.Reference, metric.Timestamp, metric.Value, rule)
+		if err != nil {
+			return nil, fmt.Errorf("trend rule for %s: %w", metric.Reference, err)
+		}
+		if fired {
+			status = rule.Status
+			forced = true
+			violation = fmt.Sprintf("trend rule tripped: %s", trendRuleDescription(rule))
+		}
+	}
+
 	return &MetricScore{
-		Reference: metric.Reference,
-		Score:     score,
-		Status:    status,
+		Reference:       metric.Reference,
+		Score:           score,
+		Status:          status,
+		Violation:       violation,
+		TrendScore:      trendScore,
+		Trend:           trend,
+		Confidence:      resolvedConfidence,
+		Stale:           resolvedConfidence < configuredConfidence,
+		TrendRuleForced: forced,
 	}, nil
 }
 
+// metricWeight resolves reference's configured Weight (equal weighting, 1.0,
+// when unset), for use by WeightedScoring's category aggregation.
+func (s *ScoreCalculator) metricWeight(reference string) (float64, error) {
+	metricDef, err := s.metricsProcessor.GetMetricDefinition(reference)
+	if err != nil {
+		return 0, err
+	}
+
+	switch def := metricDef.(type) {
+	case KPI:
+		if def.Weight == nil {
+			return 1.0, nil
+		}
+		return *def.Weight, nil
+	case KRI:
+		if def.Weight == nil {
+			return 1.0, nil
+		}
+		return *def.Weight, nil
+	default:
+		return 0, fmt.Errorf("unknown metric definition type for %s", reference)
+	}
+}
+
+// confidenceFor resolves a metric's confidence for WeightedScoring: the
+// configured confidence (1.0 when unset), linearly decayed to zero as the
+// observation's age approaches maxAgeDays. A non-positive maxAgeDays
+// disables decay.
+func confidenceFor(confidence *float64, observedAt time.Time, maxAgeDays int, now time.Time) float64 {
+	c := 1.0
+	if confidence != nil {
+		c = *confidence
+	}
+	if maxAgeDays <= 0 {
+		return c
+	}
+
+	maxAge := time.Duration(maxAgeDays) * 24 * time.Hour
+	age := now.Sub(observedAt)
+	if age <= 0 {
+		return c
+	}
+	if age >= maxAge {
+		return 0
+	}
+
+	return c * (1 - float64(age)/float64(maxAge))
+}
+
+// scoreForValue computes the 0-100 score reference would receive for an
+// arbitrary value, honoring its Formula when one is defined. It is the
+// shared core behind both CalculateMetricScore's current-point score and
+// calculateTrend's replay of historical values.
+func (s *ScoreCalculator) scoreForValue(reference string, value float64) (int, error) {
+	metricDef, err := s.metricsProcessor.GetMetricDefinition(reference)
+	if err != nil {
+		return 0, err
+	}
+
+	metricType, err := GetMetricType(reference)
+	if err != nil {
+		return 0, err
+	}
+
+	switch metricType {
+	case "KPI":
+		kpi, ok := metricDef.(KPI)
+		if !ok {
+			return 0, fmt.Errorf("failed to cast metric definition to KPI")
+		}
+		if kpi.Formula != "" {
+			return s.evaluateFormulaScore(reference, kpi.Formula, value, kpi.Target, kpi.Min, kpi.Max)
+		}
+		return calculateKPIScore(value, kpi), nil
+	case "KRI":
+		kri, ok := metricDef.(KRI)
+		if !ok {
+			return 0, fmt.Errorf("failed to cast metric definition to KRI")
+		}
+		if kri.Formula != "" {
+			return s.evaluateFormulaScore(reference, kri.Formula, value, kri.Target, kri.Min, kri.Max)
+		}
+		return calculateKRIScore(value, kri), nil
+	default:
+		return 0, fmt.Errorf("unknown metric type: %s", metricType)
+	}
+}
+
+// trendSlopeWindow bounds how many of the most recent scored points feed the
+// Improving/Steady/Declining classification in calculateTrend.
+const trendSlopeWindow = 5
+
+// trendSlopeThreshold is the minimum score-per-point slope over
+// trendSlopeWindow points that counts as a real trend rather than noise.
+const trendSlopeThreshold = 1.0
+
+// calculateTrend computes an exponentially-weighted moving average score
+// for reference as of at, s = Σ w_i*score(v_i) / Σ w_i with
+// w_i = exp(-λ*Δt_i) and λ derived from s.trendHalfLife, plus a
+// TrendDirection based on the slope of the last trendSlopeWindow scored
+// points. It returns a zero TrendScore and empty TrendDirection, with no
+// error, when no HistoryStore is attached; when one is attached but holds
+// no points in the queried window, it returns currentScore and TrendSteady.
+func (s *ScoreCalculator) calculateTrend(reference string, at time.Time, currentScore int) (int, TrendDirection, error) {
+	history := s.metricsProcessor.History()
+	if history == nil {
+		return 0, "", nil
+	}
+
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	points, err := history.Query(reference, at.Add(-5*s.trendHalfLife), at)
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to query history for %s: %w", reference, err)
+	}
+	if len(points) == 0 {
+		return currentScore, TrendSteady, nil
+	}
+
+	lambda := math.Ln2 / s.trendHalfLife.Seconds()
+
+	var weightedSum, totalWeight float64
+	scores := make([]int, 0, len(points))
+	for _, p := range points {
+		pointScore, err := s.scoreForValue(reference, p.Value)
+		if err != nil {
+			return 0, "", err
+		}
+		scores = append(scores, pointScore)
+
+		weight := math.Exp(-lambda * at.Sub(p.Timestamp).Seconds())
+		weightedSum += weight * float64(pointScore)
+		totalWeight += weight
+	}
+
+	trendScore := int(math.Round(weightedSum / totalWeight))
+
+	tail := scores
+	if len(tail) > trendSlopeWindow {
+		tail = tail[len(tail)-trendSlopeWindow:]
+	}
+
+	direction := TrendSteady
+	if len(tail) >= 2 {
+		slope := float64(tail[len(tail)-1]-tail[0]) / float64(len(tail)-1)
+		switch {
+		case slope > trendSlopeThreshold:
+			direction = TrendImproving
+		case slope < -trendSlopeThreshold:
+			direction = TrendDeclining
+		}
+	}
+
+	return trendScore, direction, nil
+}
+
+// violationMessage describes why a metric fell short of the best available
+// score, honoring each band's declared Direction. It returns an empty string
+// when the metric already sits in its highest-scoring band.
+func violationMessage(value float64, score int, bands []ScoringBand) string {
+	if len(bands) == 0 {
+		return ""
+	}
+
+	best := bands[0]
+	for _, band := range bands {
+		if band.Score > best.Score {
+			best = band
+		}
+	}
+
+	if score >= best.Score {
+		return ""
+	}
+
+	direction := best.Direction
+	if direction == "" {
+		direction = HigherIsBetter
+	}
+
+	switch direction {
+	case LowerIsBetter:
+		if best.Max != nil {
+			return fmt.Sprintf("value %.2f exceeds the lower-is-better target of %.2f", value, *best.Max)
+		}
+	default:
+		if best.Min != nil {
+			return fmt.Sprintf("value %.2f is below the higher-is-better target of %.2f", value, *best.Min)
+		}
+	}
+
+	return fmt.Sprintf("value %.2f is outside the target band (score %d)", value, score)
+}
+
+// compiledFormula returns the Formula compiled from expr for reference,
+// compiling and caching it once per ScoreCalculator.
+func (s *ScoreCalculator) compiledFormula(reference, expr string) (*Formula, error) {
+	s.formulaMu.Lock()
+	defer s.formulaMu.Unlock()
+
+	if formula, ok := s.formulaCache[reference]; ok {
+		return formula, nil
+	}
+
+	formula, err := CompileFormula(expr, formulaVariables)
+	if err != nil {
+		return nil, err
+	}
+
+	s.formulaCache[reference] = formula
+	return formula, nil
+}
+
+// evaluateFormulaScore evaluates expr for reference against value, target,
+// min, max, the metric's previous recorded value, and a metric() resolver
+// backed by MetricsProcessor, clamping the result to the 0-100 score range.
+func (s *ScoreCalculator) evaluateFormulaScore(reference, expr string, value float64, target, min, max *float64) (int, error) {
+	formula, err := s.compiledFormula(reference, expr)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", reference, err)
+	}
+
+	vars := map[string]float64{
+		"value":    value,
+		"previous": s.previousValue(reference, value),
+	}
+	if target != nil {
+		vars["target"] = *target
+	}
+	if min != nil {
+		vars["min"] = *min
+	}
+	if max != nil {
+		vars["max"] = *max
+	}
+
+	result, err := formula.Eval(FormulaContext{
+		Variables: vars,
+		Metric: func(ref string) (float64, error) {
+			m, err := s.metricsProcessor.GetMetricByReference(ref)
+			if err != nil {
+				return 0, err
+			}
+			return m.Value, nil
+		},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("formula for %s failed to evaluate: %w", reference, err)
+	}
+
+	score := int(math.Round(result))
+	if score < 0 {
+		score = 0
+	}
+	if score > 100 {
+		score = 100
+	}
+
+	return score, nil
+}
+
+// previousValue returns the second-most-recent recorded value for
+// reference, or current if no history store is attached or fewer than two
+// points have been recorded.
+func (s *ScoreCalculator) previousValue(reference string, current float64) float64 {
+	history := s.metricsProcessor.History()
+	if history == nil {
+		return current
+	}
+
+	points, err := history.Query(reference, time.Time{}, time.Now())
+	if err != nil || len(points) < 2 {
+		return current
+	}
+
+	return points[len(points)-2].Value
+}
+
 // calculateKPIScore calculates the score for a KPI metric
 func calculateKPIScore(value float64, kpi KPI) int {
 	// For KPIs, check each scoring band to find the appropriate score
@@ -153,15 +697,30 @@ func calculateKRIScore(value float64, kri KRI) int {
 	return 0
 }
 
+// status maps score to a TrafficLightStatus under thresholds for target
+// (e.g. a metric reference, "categoryID:kpi", or "overall"), routing through
+// s.bandResolver when one is configured via WithBandResolver so
+// thresholds.Hysteresis can keep target from flapping bands, and falling
+// back to the plain determineStatus otherwise.
+func (s *ScoreCalculator) status(target string, score int, thresholds Thresholds) TrafficLightStatus {
+	if s.noThresholds {
+		return ""
+	}
+	if s.bandResolver == nil {
+		return determineStatus(score, thresholds)
+	}
+	return s.bandResolver.Resolve(target, score, thresholds)
+}
+
 // determineStatus determines the traffic light status based on the score
 func determineStatus(score int, thresholds Thresholds) TrafficLightStatus {
 	// Check if score is within the Green range
-	if score >= thresholds.Green.Min && score <= thresholds.Green.Max {
+	if thresholds.Green.Contains(score) {
 		return Green
 	}
 
 	// Check if score is within the Yellow range
-	if score >= thresholds.Yellow.Min && score <= thresholds.Yellow.Max {
+	if thresholds.Yellow.Contains(score) {
 		return Yellow
 	}
 
@@ -169,6 +728,134 @@ func determineStatus(score int, thresholds Thresholds) TrafficLightStatus {
 	return Red
 }
 
+// trendRuleLookback bounds how far back evaluateTrendRule queries history
+// before taking the tail of rule.Window most-recent samples, mirroring
+// calculateTrend's pattern of querying a fixed window and then slicing by
+// count rather than by time (samples aren't necessarily evenly spaced).
+const trendRuleLookback = 365 * 24 * time.Hour
+
+// evaluateTrendRule reports whether rule fires for reference given its
+// recorded history plus its current value at at. It returns false, with no
+// error, when history is nil (no HistoryStore attached) or fewer than
+// rule.Window samples (history plus the current value) are available.
+func evaluateTrendRule(history *HistoryStore, reference string, at time.Time, currentValue float64, rule TrendRule) (bool, error) {
+	if history == nil || rule.Window < 2 {
+		return false, nil
+	}
+
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	points, err := history.Query(reference, at.Add(-trendRuleLookback), at)
+	if err != nil {
+		return false, fmt.Errorf("failed to query history for %s: %w", reference, err)
+	}
+	points = append(points, HistoryPoint{Reference: reference, Value: currentValue, Timestamp: at})
+
+	if len(points) < rule.Window {
+		return false, nil
+	}
+	tail := points[len(points)-rule.Window:]
+
+	increasing, decreasing := true, true
+	for i := 1; i < len(tail); i++ {
+		switch {
+		case tail[i].Value < tail[i-1].Value:
+			increasing = false
+		case tail[i].Value > tail[i-1].Value:
+			decreasing = false
+		}
+	}
+
+	if rule.TrendUp && !increasing {
+		return false, nil
+	}
+	if rule.TrendDown && !decreasing {
+		return false, nil
+	}
+	if rule.ThresholdMin != nil && currentValue < *rule.ThresholdMin {
+		return false, nil
+	}
+	if rule.ThresholdMax != nil && currentValue > *rule.ThresholdMax {
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// trendRuleDescription renders rule as a short human-readable condition, for
+// use in MetricScore.Violation when the rule trips.
+func trendRuleDescription(rule TrendRule) string {
+	var direction string
+	switch {
+	case rule.TrendUp:
+		direction = fmt.Sprintf("%d consecutive samples trending up", rule.Window)
+	case rule.TrendDown:
+		direction = fmt.Sprintf("%d consecutive samples trending down", rule.Window)
+	default:
+		direction = fmt.Sprintf("%d consecutive samples", rule.Window)
+	}
+
+	switch {
+	case rule.ThresholdMin != nil && rule.ThresholdMax != nil:
+		return fmt.Sprintf("%s while value is within [%.2f, %.2f]", direction, *rule.ThresholdMin, *rule.ThresholdMax)
+	case rule.ThresholdMin != nil:
+		return fmt.Sprintf("%s while value >= %.2f", direction, *rule.ThresholdMin)
+	case rule.ThresholdMax != nil:
+		return fmt.Sprintf("%s while value <= %.2f", direction, *rule.ThresholdMax)
+	default:
+		return direction
+	}
+}
+
+// evaluateCriticalRule reports whether value trips rule's veto condition.
+func evaluateCriticalRule(value float64, rule CriticalRule) (bool, error) {
+	switch rule.Op {
+	case ">":
+		return value > rule.Value, nil
+	case ">=":
+		return value >= rule.Value, nil
+	case "<":
+		return value < rule.Value, nil
+	case "<=":
+		return value <= rule.Value, nil
+	case "==":
+		return value == rule.Value, nil
+	case "!=":
+		return value != rule.Value, nil
+	default:
+		return false, fmt.Errorf("unknown critical rule operator %q", rule.Op)
+	}
+}
+
+// vetoedCriticalKRIs returns the reference of every metric in metrics whose
+// Global.CriticalKRIs rule trips, so a category/overall Status can be forced
+// to Red independent of the numeric aggregate.
+func vetoedCriticalKRIs(metrics []Metric, rules map[string]CriticalRule) ([]string, error) {
+	if len(rules) == 0 {
+		return nil, nil
+	}
+
+	var vetoed []string
+	for _, metric := range metrics {
+		rule, ok := rules[metric.Reference]
+		if !ok {
+			continue
+		}
+
+		tripped, err := evaluateCriticalRule(metric.Value, rule)
+		if err != nil {
+			return nil, fmt.Errorf("critical rule for %s: %w", metric.Reference, err)
+		}
+		if tripped {
+			vetoed = append(vetoed, metric.Reference)
+		}
+	}
+
+	return vetoed, nil
+}
+
 // calculateAverage calculates the average value from a slice of integers
 func calculateAverage(values []int) int {
 	if len(values) == 0 {
@@ -222,9 +909,8 @@ func (s *ScoreCalculator) CalculateCategoryScore(categoryID string) (*CategorySc
 
 	// Calculate scores for each metric
 	var metricScores []MetricScore
-	var scores []int
-	var kpiScores []int
-	var kriScores []int
+	var scores, kpiScores, kriScores []int
+	var scoreWeights, kpiWeights, kriWeights []float64
 
 	for _, metric := range categoryMetrics {
 		metricScore, err := s.CalculateMetricScore(metric)
@@ -240,37 +926,36 @@ func (s *ScoreCalculator) CalculateCategoryScore(categoryID string) (*CategorySc
 			return nil, err
 		}
 
+		var weight float64
+		if s.scoringMethod == WeightedScoring {
+			w, err := s.metricWeight(metric.Reference)
+			if err != nil {
+				return nil, err
+			}
+			weight = w * metricScore.Confidence
+		}
+		scoreWeights = append(scoreWeights, weight)
+
 		if metricType == "KPI" {
 			kpiScores = append(kpiScores, metricScore.Score)
+			kpiWeights = append(kpiWeights, weight)
 		} else if metricType == "KRI" {
 			kriScores = append(kriScores, metricScore.Score)
+			kriWeights = append(kriWeights, weight)
 		}
 	}
 
 	// Calculate overall category score based on scoring method
-	var categoryScore int
-	if s.scoringMethod == MedianScoring {
-		categoryScore = calculateMedian(scores)
-	} else {
-		categoryScore = calculateAverage(scores)
-	}
+	categoryScore := s.categoryAggregate(scores, scoreWeights)
 
 	// Calculate separate KPI and KRI scores
 	var kpiScore, kriScore int
 	if len(kpiScores) > 0 {
-		if s.scoringMethod == MedianScoring {
-			kpiScore = calculateMedian(kpiScores)
-		} else {
-			kpiScore = calculateAverage(kpiScores)
-		}
+		kpiScore = s.categoryAggregate(kpiScores, kpiWeights)
 	}
 
 	if len(kriScores) > 0 {
-		if s.scoringMethod == MedianScoring {
-			kriScore = calculateMedian(kriScores)
-		} else {
-			kriScore = calculateAverage(kriScores)
-		}
+		kriScore = s.categoryAggregate(kriScores, kriWeights)
 	}
 
 	// Determine overall status
@@ -278,18 +963,18 @@ func (s *ScoreCalculator) CalculateCategoryScore(categoryID string) (*CategorySc
 
 	// Check if there are category-specific thresholds
 	if categoryThresholds, exists := s.metricsProcessor.leversConfig.Weights.CategoryThresholds[categoryID]; exists {
-		status = determineStatus(categoryScore, categoryThresholds)
+		status = s.status(categoryID, categoryScore, categoryThresholds)
 	} else {
-		status = determineStatus(categoryScore, s.metricsProcessor.leversConfig.Global.Thresholds)
+		status = s.status(categoryID, categoryScore, s.metricsProcessor.leversConfig.Global.Thresholds)
 	}
 
 	// Determine KPI status
 	var kpiStatus TrafficLightStatus
 	if len(kpiScores) > 0 {
 		if categoryKPIThresholds, exists := s.metricsProcessor.leversConfig.Weights.CategoryKPIThresholds[categoryID]; exists {
-			kpiStatus = determineStatus(kpiScore, categoryKPIThresholds)
+			kpiStatus = s.status(categoryID+":kpi", kpiScore, categoryKPIThresholds)
 		} else {
-			kpiStatus = determineStatus(kpiScore, s.metricsProcessor.leversConfig.Global.KPIThresholds)
+			kpiStatus = s.status(categoryID+":kpi", kpiScore, s.metricsProcessor.leversConfig.Global.KPIThresholds)
 		}
 	} else {
 		kpiStatus = Yellow // Default if no KPIs
@@ -299,24 +984,157 @@ func (s *ScoreCalculator) CalculateCategoryScore(categoryID string) (*CategorySc
 	var kriStatus TrafficLightStatus
 	if len(kriScores) > 0 {
 		if categoryKRIThresholds, exists := s.metricsProcessor.leversConfig.Weights.CategoryKRIThresholds[categoryID]; exists {
-			kriStatus = determineStatus(kriScore, categoryKRIThresholds)
+			kriStatus = s.status(categoryID+":kri", kriScore, categoryKRIThresholds)
 		} else {
-			kriStatus = determineStatus(kriScore, s.metricsProcessor.leversConfig.Global.KRIThresholds)
+			kriStatus = s.status(categoryID+":kri", kriScore, s.metricsProcessor.leversConfig.Global.KRIThresholds)
 		}
 	} else {
 		kriStatus = Yellow // Default if no KRIs
 	}
 
+	// A tripped critical KRI overrides the numeric aggregate: the category
+	// is Red regardless of what averaging/median produced.
+	vetoedBy, err := vetoedCriticalKRIs(categoryMetrics, s.metricsProcessor.leversConfig.Global.CriticalKRIs)
+	if err != nil {
+		return nil, err
+	}
+	if len(vetoedBy) > 0 {
+		status = Red
+	}
+
+	return &CategoryScore{
+		ID:           categoryID,
+		Name:         category.Name,
+		Score:        categoryScore,
+		KPIScore:     kpiScore,
+		KRIScore:     kriScore,
+		Status:       status,
+		KPIStatus:    kpiStatus,
+		KRIStatus:    kriStatus,
+		Metrics:      metricScores,
+		VetoedBy:     vetoedBy,
+		Distribution: computeDistribution(scores, s.metricsProcessor.leversConfig.Global.DistributionBuckets),
+	}, nil
+}
+
+// CalculateScopeScore aggregates every metric tagged with (scope, key) -
+// e.g. scope=ScopeTeam, key="payments" - into a CategoryScore-shaped result,
+// using the same per-metric scoring and aggregation (median/average/
+// percentile) as CalculateCategoryScore. This lets a scope instance be
+// scored the same way a Category is, independent of which Category its
+// underlying metrics belong to.
+func (s *ScoreCalculator) CalculateScopeScore(scope MetricScope, key string) (*CategoryScore, error) {
+	scopedMetrics := s.metricsProcessor.GetMetricsByScope(scope, key)
+	if len(scopedMetrics) == 0 {
+		return nil, fmt.Errorf("no metrics found for scope %s=%s", scope, key)
+	}
+
+	var metricScores []MetricScore
+	var scores, kpiScores, kriScores []int
+
+	for _, metric := range scopedMetrics {
+		metricScore, err := s.CalculateMetricScore(metric)
+		if err != nil {
+			return nil, err
+		}
+		metricScores = append(metricScores, *metricScore)
+		scores = append(scores, metricScore.Score)
+
+		metricType, err := GetMetricType(metric.Reference)
+		if err != nil {
+			return nil, err
+		}
+		if metricType == "KPI" {
+			kpiScores = append(kpiScores, metricScore.Score)
+		} else {
+			kriScores = append(kriScores, metricScore.Score)
+		}
+	}
+
+	score := s.aggregateScores(scores)
+
+	var kpiScore, kriScore int
+	if len(kpiScores) > 0 {
+		kpiScore = s.aggregateScores(kpiScores)
+	}
+	if len(kriScores) > 0 {
+		kriScore = s.aggregateScores(kriScores)
+	}
+
+	target := string(scope) + "=" + key
+	status := s.status(target, score, s.metricsProcessor.leversConfig.Global.Thresholds)
+
+	kpiStatus := Yellow
+	if len(kpiScores) > 0 {
+		kpiStatus = s.status(target+":kpi", kpiScore, s.metricsProcessor.leversConfig.Global.KPIThresholds)
+	}
+
+	kriStatus := Yellow
+	if len(kriScores) > 0 {
+		kriStatus = s.status(target+":kri", kriScore, s.metricsProcessor.leversConfig.Global.KRIThresholds)
+	}
+
+	vetoedBy, err := vetoedCriticalKRIs(scopedMetrics, s.metricsProcessor.leversConfig.Global.CriticalKRIs)
+	if err != nil {
+		return nil, err
+	}
+	if len(vetoedBy) > 0 {
+		status = Red
+	}
+
 	return &CategoryScore{
-		ID:        categoryID,
-		Name:      category.Name,
-		Score:     categoryScore,
-		KPIScore:  kpiScore,
-		KRIScore:  kriScore,
-		Status:    status,
-		KPIStatus: kpiStatus,
-		KRIStatus: kriStatus,
-		Metrics:   metricScores,
+		ID:           string(scope) + "=" + key,
+		Name:         key,
+		Score:        score,
+		KPIScore:     kpiScore,
+		KRIScore:     kriScore,
+		Status:       status,
+		KPIStatus:    kpiStatus,
+		KRIStatus:    kriStatus,
+		Metrics:      metricScores,
+		VetoedBy:     vetoedBy,
+		Distribution: computeDistribution(scores, s.metricsProcessor.leversConfig.Global.DistributionBuckets),
+	}, nil
+}
+
+// CalculateRollup aggregates every scope-instance at fromScope (e.g. every
+// team) into a single toScope score (e.g. the org as a whole), honoring the
+// asset < team < service < category < org partial order MetricScope.Granularity
+// defines. The returned CategoryScore's Children map holds each fromScope
+// instance's own CalculateScopeScore result, keyed by its scope key, so
+// callers can read the rolled-up posture and still drill back down into any
+// contributing instance without a second query.
+func (s *ScoreCalculator) CalculateRollup(fromScope, toScope MetricScope) (*CategoryScore, error) {
+	if toScope.Granularity() <= fromScope.Granularity() {
+		return nil, fmt.Errorf("toScope (%s) must be broader than fromScope (%s)", toScope, fromScope)
+	}
+
+	childKeys := s.metricsProcessor.ScopeKeys(fromScope)
+	if len(childKeys) == 0 {
+		return nil, fmt.Errorf("no metrics found for scope %s", fromScope)
+	}
+
+	children := make(map[string]*CategoryScore, len(childKeys))
+	var scores []int
+
+	for _, childKey := range childKeys {
+		childScore, err := s.CalculateScopeScore(fromScope, childKey)
+		if err != nil {
+			return nil, err
+		}
+		children[childKey] = childScore
+		scores = append(scores, childScore.Score)
+	}
+
+	score := s.aggregateScores(scores)
+
+	return &CategoryScore{
+		ID:           string(toScope),
+		Name:         string(toScope),
+		Score:        score,
+		Status:       s.status(string(toScope), score, s.metricsProcessor.leversConfig.Global.Thresholds),
+		Children:     children,
+		Distribution: computeDistribution(scores, s.metricsProcessor.leversConfig.Global.DistributionBuckets),
 	}, nil
 }
 
@@ -473,12 +1291,12 @@ func (s *ScoreCalculator) CalculateOverallScore() (*OverallScore, error) {
 	}
 
 	// Determine overall status
-	status := determineStatus(overallScore, s.metricsProcessor.leversConfig.Global.Thresholds)
+	status := s.status("overall", overallScore, s.metricsProcessor.leversConfig.Global.Thresholds)
 
 	// Determine KPI status
 	var kpiStatus TrafficLightStatus
 	if len(kpiScores) > 0 {
-		kpiStatus = determineStatus(kpiScore, s.metricsProcessor.leversConfig.Global.KPIThresholds)
+		kpiStatus = s.status("overall:kpi", kpiScore, s.metricsProcessor.leversConfig.Global.KPIThresholds)
 	} else {
 		kpiStatus = Yellow // Default if no KPIs
 	}
@@ -486,18 +1304,108 @@ func (s *ScoreCalculator) CalculateOverallScore() (*OverallScore, error) {
 	// Determine KRI status
 	var kriStatus TrafficLightStatus
 	if len(kriScores) > 0 {
-		kriStatus = determineStatus(kriScore, s.metricsProcessor.leversConfig.Global.KRIThresholds)
+		kriStatus = s.status("overall:kri", kriScore, s.metricsProcessor.leversConfig.Global.KRIThresholds)
 	} else {
 		kriStatus = Yellow // Default if no KRIs
 	}
 
+	// Roll up every category's vetoes; once at least MinCriticalVetoesForRed
+	// have tripped (default 1 when any CriticalKRIs are configured at all),
+	// the overall status is forced to Red too.
+	var vetoedBy []string
+	for _, categoryScore := range categoryScores {
+		vetoedBy = append(vetoedBy, categoryScore.VetoedBy...)
+	}
+
+	if len(vetoedBy) > 0 {
+		minVetoes := s.metricsProcessor.leversConfig.Global.MinCriticalVetoesForRed
+		if minVetoes <= 0 {
+			minVetoes = 1
+		}
+		if len(vetoedBy) >= minVetoes {
+			status = Red
+		}
+	}
+
+	var allMetricScores []int
+	for _, categoryScore := range categoryScores {
+		for _, metricScore := range categoryScore.Metrics {
+			allMetricScores = append(allMetricScores, metricScore.Score)
+		}
+	}
+
 	return &OverallScore{
-		Score:      overallScore,
-		KPIScore:   kpiScore,
-		KRIScore:   kriScore,
-		Status:     status,
-		KPIStatus:  kpiStatus,
-		KRIStatus:  kriStatus,
-		Categories: categoryScores,
+		Score:        overallScore,
+		KPIScore:     kpiScore,
+		KRIScore:     kriScore,
+		Status:       status,
+		KPIStatus:    kpiStatus,
+		KRIStatus:    kriStatus,
+		Categories:   categoryScores,
+		VetoedBy:     vetoedBy,
+		Distribution: computeDistribution(allMetricScores, s.metricsProcessor.leversConfig.Global.DistributionBuckets),
 	}, nil
 }
+
+// CalculateMetricScoreAt replays a metric's recorded history and returns the
+// score it would have had at time t, using the current scoring bands. It
+// requires a HistoryStore to have been attached via
+// MetricsProcessor.SetHistoryStore.
+func (s *ScoreCalculator) CalculateMetricScoreAt(reference string, t time.Time) (*MetricScore, error) {
+	history := s.metricsProcessor.History()
+	if history == nil {
+		return nil, fmt.Errorf("no history store configured for %s", reference)
+	}
+
+	value, found, err := history.ValueAt(reference, t)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query history for %s: %w", reference, err)
+	}
+	if !found {
+		return nil, fmt.Errorf("no history recorded for %s at or before %s", reference, t.Format("2006-01-02T15:04:05Z07:00"))
+	}
+
+	return s.CalculateMetricScore(Metric{Reference: reference, Value: value, Timestamp: t})
+}
+
+// CalculateScoreSeries replays a metric's history between from and to at the
+// given step, returning one ScorePoint per step so callers can see how the
+// metric's score and status moved over time.
+func (s *ScoreCalculator) CalculateScoreSeries(reference string, from, to time.Time, step time.Duration) ([]ScorePoint, error) {
+	if step <= 0 {
+		return nil, fmt.Errorf("step must be positive")
+	}
+
+	history := s.metricsProcessor.History()
+	if history == nil {
+		return nil, fmt.Errorf("no history store configured for %s", reference)
+	}
+
+	var series []ScorePoint
+
+	for t := from; !t.After(to); t = t.Add(step) {
+		value, found, err := history.ValueAt(reference, t)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query history for %s: %w", reference, err)
+		}
+		if !found {
+			// No data yet at this point in the series; skip it rather than
+			// failing the whole range.
+			continue
+		}
+
+		metricScore, err := s.CalculateMetricScore(Metric{Reference: reference, Value: value, Timestamp: t})
+		if err != nil {
+			return nil, err
+		}
+
+		series = append(series, ScorePoint{
+			Timestamp: t,
+			Value:     value,
+			Score:     metricScore.Score,
+			Status:    metricScore.Status,
+		})
+	}
+
+	return series, nil
+}
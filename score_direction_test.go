@@ -0,0 +1,37 @@
+package pulse
+
+import "testing"
+
+func TestViolationMessageHigherIsBetter(t *testing.T) {
+	bands := []ScoringBand{
+		{Max: FloatPtr(80), Score: 50},
+		{Min: FloatPtr(80), Score: 100},
+	}
+
+	msg := violationMessage(65, 50, bands)
+	if msg == "" {
+		t.Fatal("expected a violation message for a below-target value")
+	}
+
+	msg = violationMessage(90, 100, bands)
+	if msg != "" {
+		t.Fatalf("expected no violation message at the best band, got %q", msg)
+	}
+}
+
+func TestViolationMessageLowerIsBetter(t *testing.T) {
+	bands := []ScoringBand{
+		{Max: FloatPtr(5), Score: 100, Direction: LowerIsBetter},
+		{Min: FloatPtr(5), Score: 50, Direction: LowerIsBetter},
+	}
+
+	msg := violationMessage(12, 50, bands)
+	if msg == "" {
+		t.Fatal("expected a violation message for an above-target value")
+	}
+
+	msg = violationMessage(2, 100, bands)
+	if msg != "" {
+		t.Fatalf("expected no violation message at the best band, got %q", msg)
+	}
+}
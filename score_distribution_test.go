@@ -0,0 +1,57 @@
+package pulse
+
+import "testing"
+
+func TestWeightedPercentileUnweighted(t *testing.T) {
+	values := []int{10, 20, 30, 40, 50}
+
+	if got := weightedPercentile(values, nil, 50); got != 30 {
+		t.Fatalf("expected median 30, got %d", got)
+	}
+	if got := weightedPercentile(values, nil, 0); got != 10 {
+		t.Fatalf("expected p0 of 10, got %d", got)
+	}
+	if got := weightedPercentile(values, nil, 100); got != 50 {
+		t.Fatalf("expected p100 of 50, got %d", got)
+	}
+}
+
+func TestWeightedPercentileWeighted(t *testing.T) {
+	values := []int{10, 90}
+	weights := []float64{9, 1}
+
+	if got := weightedPercentile(values, weights, 50); got != 10 {
+		t.Fatalf("expected the heavily-weighted low value to dominate the median, got %d", got)
+	}
+}
+
+func TestComputeDistributionDefaultBuckets(t *testing.T) {
+	scores := []int{5, 15, 55, 95, 100}
+
+	dist := computeDistribution(scores, nil)
+
+	if len(dist.Buckets) != len(defaultDistributionBuckets) {
+		t.Fatalf("expected the default buckets to be used, got %v", dist.Buckets)
+	}
+	if dist.Min != 5 || dist.Max != 100 {
+		t.Fatalf("expected min=5 max=100, got min=%d max=%d", dist.Min, dist.Max)
+	}
+	if dist.Counts[0] != 1 || dist.Counts[1] != 1 || dist.Counts[5] != 1 {
+		t.Fatalf("unexpected bucket counts: %v", dist.Counts)
+	}
+	if dist.Counts[len(dist.Counts)-1] != 2 {
+		t.Fatalf("expected the final bucket's upper bound to be inclusive, got %v", dist.Counts)
+	}
+}
+
+func TestComputeDistributionEmptyScores(t *testing.T) {
+	dist := computeDistribution(nil, nil)
+	if dist.Min != 0 || dist.Max != 0 {
+		t.Fatalf("expected zero-valued stats for no scores, got %+v", dist)
+	}
+	for _, count := range dist.Counts {
+		if count != 0 {
+			t.Fatalf("expected all bucket counts to be zero, got %v", dist.Counts)
+		}
+	}
+}
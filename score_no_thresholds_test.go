@@ -0,0 +1,40 @@
+package pulse
+
+import "testing"
+
+func TestCalculateMetricScoreWithNoThresholdsSkipsStatus(t *testing.T) {
+	metricsConfig := &MetricsConfig{
+		Categories: []Category{
+			{
+				ID: "app_sec",
+				KPIs: []KPI{
+					{
+						ID:           "coverage",
+						ScoringBands: []ScoringBand{{Min: FloatPtr(0), Score: 90}},
+					},
+				},
+			},
+		},
+	}
+	leversConfig := &LeversConfig{Global: Global{
+		KPIThresholds: Thresholds{
+			Green:  ThresholdRange{Min: 80, Max: 100},
+			Yellow: ThresholdRange{Min: 50, Max: 79},
+			Red:    ThresholdRange{Min: 0, Max: 49},
+		},
+	}}
+	processor := NewMetricsProcessor(metricsConfig, leversConfig, &MetricsData{})
+
+	calculator := NewScoreCalculator(processor, MedianScoring, WithNoThresholds())
+	metricScore, err := calculator.CalculateMetricScore(Metric{Reference: "app_sec.KPI.coverage", Value: 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if metricScore.Score != 90 {
+		t.Fatalf("expected the score to still be computed, got %d", metricScore.Score)
+	}
+	if metricScore.Status != "" {
+		t.Fatalf("expected Status to be skipped (empty) with WithNoThresholds, got %q", metricScore.Status)
+	}
+}
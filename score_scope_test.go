@@ -0,0 +1,87 @@
+package pulse
+
+import "testing"
+
+func newScopeTestCalculator(t *testing.T) *ScoreCalculator {
+	t.Helper()
+
+	metricsConfig := &MetricsConfig{
+		Categories: []Category{
+			{
+				ID: "app_sec",
+				KPIs: []KPI{
+					{ID: "coverage", ScoringBands: []ScoringBand{
+						{Min: FloatPtr(90), Score: 100},
+						{Max: FloatPtr(89.999), Score: 40},
+					}},
+				},
+			},
+		},
+	}
+	leversConfig := &LeversConfig{Global: Global{
+		Thresholds:    Thresholds{Green: ThresholdRange{Min: 80, Max: 100}, Yellow: ThresholdRange{Min: 50, Max: 79}, Red: ThresholdRange{Min: 0, Max: 49}},
+		KPIThresholds: Thresholds{Green: ThresholdRange{Min: 80, Max: 100}, Yellow: ThresholdRange{Min: 50, Max: 79}, Red: ThresholdRange{Min: 0, Max: 49}},
+	}}
+	metricsData := &MetricsData{Metrics: []Metric{
+		{Reference: "app_sec.KPI.coverage", Value: 95, Scope: ScopeTeam, ScopeKey: "payments"},
+		{Reference: "app_sec.KPI.coverage", Value: 50, Scope: ScopeTeam, ScopeKey: "platform"},
+	}}
+	processor := NewMetricsProcessor(metricsConfig, leversConfig, metricsData)
+
+	return NewScoreCalculator(processor, MedianScoring)
+}
+
+func TestCalculateScopeScore(t *testing.T) {
+	calculator := newScopeTestCalculator(t)
+
+	scoreForPayments, err := calculator.CalculateScopeScore(ScopeTeam, "payments")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if scoreForPayments.Score != 100 {
+		t.Fatalf("expected payments team score of 100, got %d", scoreForPayments.Score)
+	}
+
+	if _, err := calculator.CalculateScopeScore(ScopeTeam, "nonexistent"); err == nil {
+		t.Fatal("expected an error for a scope key with no metrics")
+	}
+}
+
+func TestCalculateRollup(t *testing.T) {
+	calculator := newScopeTestCalculator(t)
+
+	rollup, err := calculator.CalculateRollup(ScopeTeam, ScopeOrg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(rollup.Children) != 2 {
+		t.Fatalf("expected 2 child scope instances, got %d", len(rollup.Children))
+	}
+	if rollup.Children["payments"].Score != 100 || rollup.Children["platform"].Score != 40 {
+		t.Fatalf("unexpected child scores: %+v", rollup.Children)
+	}
+	if rollup.Score != 70 {
+		t.Fatalf("expected the median of [100, 40] to be 70, got %d", rollup.Score)
+	}
+}
+
+func TestCalculateRollupRejectsNarrowerTarget(t *testing.T) {
+	calculator := newScopeTestCalculator(t)
+
+	if _, err := calculator.CalculateRollup(ScopeOrg, ScopeTeam); err == nil {
+		t.Fatal("expected an error when toScope is not broader than fromScope")
+	}
+}
+
+func TestMetricScopeGranularity(t *testing.T) {
+	if ScopeAsset.Granularity() >= ScopeTeam.Granularity() {
+		t.Fatal("expected asset to be more granular than team")
+	}
+	if ScopeOrg.Granularity() <= ScopeCategory.Granularity() {
+		t.Fatal("expected org to be broader than category")
+	}
+	if MetricScope("bogus").Granularity() != -1 {
+		t.Fatal("expected an unrecognized scope to report -1")
+	}
+}
@@ -19,7 +19,7 @@ func TestScoreCalculator(t *testing.T) {
 						Name:        "Test KPI",
 						Description: "Test KPI description",
 						Unit:        "count",
-						Target:      10,
+						Target:      FloatPtr(10),
 						ScoringBands: []ScoringBand{
 							{Max: FloatPtr(5), Score: 95},
 							{Min: FloatPtr(5), Max: FloatPtr(10), Score: 85},
@@ -35,7 +35,7 @@ func TestScoreCalculator(t *testing.T) {
 						Name:        "Test KRI",
 						Description: "Test KRI description",
 						Unit:        "count",
-						Threshold:   5,
+						Target:      FloatPtr(5),
 						ScoringBands: []ScoringBand{
 							{Max: FloatPtr(0), Score: 95},
 							{Min: FloatPtr(0), Max: FloatPtr(2), Score: 85},
@@ -56,7 +56,7 @@ func TestScoreCalculator(t *testing.T) {
 						Name:        "Test KPI 2",
 						Description: "Test KPI 2 description",
 						Unit:        "percent",
-						Target:      95,
+						Target:      FloatPtr(95),
 						ScoringBands: []ScoringBand{
 							{Min: FloatPtr(95), Score: 95},
 							{Min: FloatPtr(90), Max: FloatPtr(95), Score: 85},
@@ -0,0 +1,147 @@
+package pulse
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEvaluateTrendRuleFiresOnConsecutiveIncrease(t *testing.T) {
+	history := NewHistoryStore(t.TempDir())
+	now := time.Date(2026, 7, 26, 12, 0, 0, 0, time.UTC)
+	points := []HistoryPoint{
+		{Reference: "app_sec.KRI.open_ports", Value: 10, Timestamp: now.Add(-2 * 24 * time.Hour)},
+		{Reference: "app_sec.KRI.open_ports", Value: 15, Timestamp: now.Add(-1 * 24 * time.Hour)},
+	}
+	for _, p := range points {
+		if err := history.Append(p); err != nil {
+			t.Fatalf("failed to append history point: %v", err)
+		}
+	}
+
+	min := 20.0
+	rule := TrendRule{TrendUp: true, ThresholdMin: &min, Window: 3, Status: Red}
+
+	fired, err := evaluateTrendRule(history, "app_sec.KRI.open_ports", now, 20, rule)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !fired {
+		t.Fatal("expected the rule to fire for a 3-sample rising streak at threshold")
+	}
+}
+
+func TestEvaluateTrendRuleDoesNotFireWhenDirectionBreaks(t *testing.T) {
+	history := NewHistoryStore(t.TempDir())
+	now := time.Date(2026, 7, 26, 12, 0, 0, 0, time.UTC)
+	points := []HistoryPoint{
+		{Reference: "app_sec.KRI.open_ports", Value: 20, Timestamp: now.Add(-2 * 24 * time.Hour)},
+		{Reference: "app_sec.KRI.open_ports", Value: 15, Timestamp: now.Add(-1 * 24 * time.Hour)},
+	}
+	for _, p := range points {
+		if err := history.Append(p); err != nil {
+			t.Fatalf("failed to append history point: %v", err)
+		}
+	}
+
+	rule := TrendRule{TrendUp: true, Window: 3, Status: Red}
+
+	fired, err := evaluateTrendRule(history, "app_sec.KRI.open_ports", now, 20, rule)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fired {
+		t.Fatal("expected the rule not to fire when the streak dips before recovering")
+	}
+}
+
+func TestEvaluateTrendRuleDoesNotFireBelowWindowSampleCount(t *testing.T) {
+	history := NewHistoryStore(t.TempDir())
+	now := time.Date(2026, 7, 26, 12, 0, 0, 0, time.UTC)
+	if err := history.Append(HistoryPoint{Reference: "app_sec.KRI.open_ports", Value: 10, Timestamp: now.Add(-1 * 24 * time.Hour)}); err != nil {
+		t.Fatalf("failed to append history point: %v", err)
+	}
+
+	rule := TrendRule{TrendUp: true, Window: 5, Status: Red}
+
+	fired, err := evaluateTrendRule(history, "app_sec.KRI.open_ports", now, 20, rule)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fired {
+		t.Fatal("expected the rule not to fire with fewer samples than Window")
+	}
+}
+
+func TestEvaluateTrendRuleNoHistoryStoreNeverFires(t *testing.T) {
+	fired, err := evaluateTrendRule(nil, "app_sec.KRI.open_ports", time.Now(), 20, TrendRule{TrendUp: true, Window: 2, Status: Red})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fired {
+		t.Fatal("expected no HistoryStore to mean the rule never fires")
+	}
+}
+
+func TestCalculateMetricScoreAppliesTrendRuleOverride(t *testing.T) {
+	metricsConfig := &MetricsConfig{
+		Categories: []Category{
+			{
+				ID: "app_sec",
+				KRIs: []KRI{
+					{
+						ID: "open_ports",
+						ScoringBands: []ScoringBand{
+							{Max: FloatPtr(19.999), Score: 100},
+							{Min: FloatPtr(20), Score: 80},
+						},
+					},
+				},
+			},
+		},
+	}
+	min := 20.0
+	leversConfig := &LeversConfig{Global: Global{
+		KRIThresholds: Thresholds{
+			Green:  ThresholdRange{Min: 80, Max: 100},
+			Yellow: ThresholdRange{Min: 50, Max: 79},
+			Red:    ThresholdRange{Min: 0, Max: 49},
+		},
+		TrendRules: map[string]TrendRule{
+			"app_sec.KRI.open_ports": {TrendUp: true, ThresholdMin: &min, Window: 3, Status: Red},
+		},
+	}}
+	processor := NewMetricsProcessor(metricsConfig, leversConfig, &MetricsData{})
+
+	history := NewHistoryStore(t.TempDir())
+	processor.SetHistoryStore(history)
+
+	now := time.Date(2026, 7, 26, 12, 0, 0, 0, time.UTC)
+	points := []HistoryPoint{
+		{Reference: "app_sec.KRI.open_ports", Value: 10, Timestamp: now.Add(-2 * 24 * time.Hour)},
+		{Reference: "app_sec.KRI.open_ports", Value: 15, Timestamp: now.Add(-1 * 24 * time.Hour)},
+	}
+	for _, p := range points {
+		if err := history.Append(p); err != nil {
+			t.Fatalf("failed to append history point: %v", err)
+		}
+	}
+
+	calculator := NewScoreCalculator(processor, MedianScoring)
+	metricScore, err := calculator.CalculateMetricScore(Metric{Reference: "app_sec.KRI.open_ports", Value: 20, Timestamp: now})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if metricScore.Score != 80 {
+		t.Fatalf("expected the raw score to stay 80 (still Green by band), got %d", metricScore.Score)
+	}
+	if metricScore.Status != Red {
+		t.Fatalf("expected the trend rule to force Status to Red, got %v", metricScore.Status)
+	}
+	if !metricScore.TrendRuleForced {
+		t.Fatal("expected TrendRuleForced to be true")
+	}
+	if metricScore.Violation == "" {
+		t.Fatal("expected a Violation message describing the tripped trend rule")
+	}
+}
@@ -0,0 +1,89 @@
+package pulse
+
+import (
+	"testing"
+	"time"
+)
+
+func newTrendTestCalculator(t *testing.T) (*ScoreCalculator, *HistoryStore) {
+	t.Helper()
+
+	metricsConfig := &MetricsConfig{
+		Categories: []Category{
+			{
+				ID: "app_sec",
+				KPIs: []KPI{
+					{
+						ID: "coverage",
+						ScoringBands: []ScoringBand{
+							{Min: FloatPtr(90), Score: 100},
+							{Min: FloatPtr(70), Max: FloatPtr(89.999), Score: 60},
+							{Max: FloatPtr(69.999), Score: 20},
+						},
+					},
+				},
+			},
+		},
+	}
+	leversConfig := &LeversConfig{Global: Global{KPIThresholds: Thresholds{
+		Green:  ThresholdRange{Min: 80, Max: 100},
+		Yellow: ThresholdRange{Min: 50, Max: 79},
+		Red:    ThresholdRange{Min: 0, Max: 49},
+	}}}
+	processor := NewMetricsProcessor(metricsConfig, leversConfig, &MetricsData{})
+
+	history := NewHistoryStore(t.TempDir())
+	processor.SetHistoryStore(history)
+
+	return NewScoreCalculator(processor, MedianScoring, WithTrendHalfLife(24*time.Hour)), history
+}
+
+func TestCalculateMetricScoreTrendImproving(t *testing.T) {
+	calculator, history := newTrendTestCalculator(t)
+
+	now := time.Date(2026, 7, 26, 12, 0, 0, 0, time.UTC)
+	points := []HistoryPoint{
+		{Reference: "app_sec.KPI.coverage", Value: 50, Timestamp: now.Add(-4 * 24 * time.Hour)},
+		{Reference: "app_sec.KPI.coverage", Value: 65, Timestamp: now.Add(-3 * 24 * time.Hour)},
+		{Reference: "app_sec.KPI.coverage", Value: 80, Timestamp: now.Add(-2 * 24 * time.Hour)},
+		{Reference: "app_sec.KPI.coverage", Value: 92, Timestamp: now.Add(-1 * 24 * time.Hour)},
+	}
+	for _, p := range points {
+		if err := history.Append(p); err != nil {
+			t.Fatalf("failed to append history point: %v", err)
+		}
+	}
+
+	metricScore, err := calculator.CalculateMetricScore(Metric{Reference: "app_sec.KPI.coverage", Value: 95, Timestamp: now})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if metricScore.Score != 100 {
+		t.Fatalf("expected current-point score of 100, got %d", metricScore.Score)
+	}
+	if metricScore.Trend != TrendImproving {
+		t.Fatalf("expected an improving trend, got %v", metricScore.Trend)
+	}
+	if metricScore.TrendScore <= 0 || metricScore.TrendScore >= metricScore.Score {
+		t.Fatalf("expected a trend score weighted below the current score, got %d", metricScore.TrendScore)
+	}
+}
+
+func TestCalculateMetricScoreTrendWithoutHistoryStore(t *testing.T) {
+	metricsConfig := &MetricsConfig{
+		Categories: []Category{
+			{ID: "app_sec", KPIs: []KPI{{ID: "coverage", ScoringBands: []ScoringBand{{Min: FloatPtr(0), Score: 100}}}}},
+		},
+	}
+	processor := NewMetricsProcessor(metricsConfig, &LeversConfig{}, &MetricsData{})
+	calculator := NewScoreCalculator(processor, MedianScoring)
+
+	metricScore, err := calculator.CalculateMetricScore(Metric{Reference: "app_sec.KPI.coverage", Value: 95})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if metricScore.TrendScore != 0 || metricScore.Trend != "" {
+		t.Fatalf("expected no trend data without a HistoryStore, got score=%d trend=%v", metricScore.TrendScore, metricScore.Trend)
+	}
+}
@@ -0,0 +1,57 @@
+package pulse
+
+import "testing"
+
+func TestEvaluateCriticalRule(t *testing.T) {
+	tripped, err := evaluateCriticalRule(0.95, CriticalRule{Op: ">", Value: 0.9})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !tripped {
+		t.Fatal("expected the rule to trip")
+	}
+
+	tripped, err = evaluateCriticalRule(0.5, CriticalRule{Op: ">", Value: 0.9})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tripped {
+		t.Fatal("expected the rule not to trip")
+	}
+}
+
+func TestEvaluateCriticalRuleUnknownOp(t *testing.T) {
+	if _, err := evaluateCriticalRule(1, CriticalRule{Op: "~="}); err == nil {
+		t.Fatal("expected an error for an unknown operator")
+	}
+}
+
+func TestVetoedCriticalKRIs(t *testing.T) {
+	metrics := []Metric{
+		{Reference: "app_sec.KRI.breach_count", Value: 3},
+		{Reference: "app_sec.KPI.coverage", Value: 50},
+	}
+	rules := map[string]CriticalRule{
+		"app_sec.KRI.breach_count": {Op: ">", Value: 0},
+	}
+
+	vetoed, err := vetoedCriticalKRIs(metrics, rules)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(vetoed) != 1 || vetoed[0] != "app_sec.KRI.breach_count" {
+		t.Fatalf("expected the tripped KRI to be vetoed, got %v", vetoed)
+	}
+}
+
+func TestVetoedCriticalKRIsNoRulesConfigured(t *testing.T) {
+	metrics := []Metric{{Reference: "app_sec.KRI.breach_count", Value: 3}}
+
+	vetoed, err := vetoedCriticalKRIs(metrics, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(vetoed) != 0 {
+		t.Fatalf("expected no vetoes when no critical rules are configured, got %v", vetoed)
+	}
+}
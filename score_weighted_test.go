@@ -0,0 +1,114 @@
+package pulse
+
+import (
+	"testing"
+	"time"
+)
+
+func weightedTestConfig() (*MetricsConfig, *LeversConfig) {
+	metricsConfig := &MetricsConfig{
+		Categories: []Category{
+			{
+				ID: "test_cat",
+				KPIs: []KPI{
+					{
+						ID:     "fast",
+						Weight: FloatPtr(0.75),
+						ScoringBands: []ScoringBand{
+							{Min: FloatPtr(0), Score: 100},
+						},
+					},
+					{
+						ID:     "slow",
+						Weight: FloatPtr(0.25),
+						ScoringBands: []ScoringBand{
+							{Min: FloatPtr(0), Score: 0},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	leversConfig := &LeversConfig{
+		Global: Global{
+			Thresholds:    Thresholds{Green: ThresholdRange{Min: 80, Max: 100}, Yellow: ThresholdRange{Min: 50, Max: 79}, Red: ThresholdRange{Min: 0, Max: 49}},
+			KPIThresholds: Thresholds{Green: ThresholdRange{Min: 80, Max: 100}, Yellow: ThresholdRange{Min: 50, Max: 79}, Red: ThresholdRange{Min: 0, Max: 49}},
+		},
+		Weights: Weights{Categories: CategoryWeights{"test_cat": 1.0}},
+	}
+
+	return metricsConfig, leversConfig
+}
+
+func TestCalculateCategoryScoreWeightedScoringHonorsMetricWeights(t *testing.T) {
+	metricsConfig, leversConfig := weightedTestConfig()
+	metricsData := &MetricsData{
+		Metrics: []Metric{
+			{Reference: "test_cat.KPI.fast", Value: 1, Timestamp: time.Now()},
+			{Reference: "test_cat.KPI.slow", Value: 1, Timestamp: time.Now()},
+		},
+	}
+
+	processor := NewMetricsProcessor(metricsConfig, leversConfig, metricsData)
+	calculator := NewScoreCalculator(processor, WeightedScoring)
+
+	score, err := calculator.CalculateCategoryScore("test_cat")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// 100*0.75 + 0*0.25 = 75, vs. an unweighted median/average of 50.
+	if score.Score != 75 {
+		t.Fatalf("expected weighted score 75, got %d", score.Score)
+	}
+}
+
+func TestConfidenceForDecaysLinearlyWithAge(t *testing.T) {
+	now := time.Now()
+
+	fresh := confidenceFor(nil, now, 10, now)
+	if fresh != 1.0 {
+		t.Fatalf("expected full confidence for a fresh observation, got %v", fresh)
+	}
+
+	halfway := confidenceFor(nil, now.AddDate(0, 0, -5), 10, now)
+	if halfway < 0.45 || halfway > 0.55 {
+		t.Fatalf("expected confidence near 0.5 halfway through max_age, got %v", halfway)
+	}
+
+	stale := confidenceFor(nil, now.AddDate(0, 0, -20), 10, now)
+	if stale != 0 {
+		t.Fatalf("expected zero confidence past max_age, got %v", stale)
+	}
+
+	noDecay := confidenceFor(nil, now.AddDate(0, 0, -20), 0, now)
+	if noDecay != 1.0 {
+		t.Fatalf("expected max_age_days=0 to disable decay, got %v", noDecay)
+	}
+}
+
+func TestCalculateMetricScoreFlagsStaleMetrics(t *testing.T) {
+	metricsConfig, leversConfig := weightedTestConfig()
+	leversConfig.Global.MaxMetricAgeDays = 10
+
+	metricsData := &MetricsData{
+		Metrics: []Metric{
+			{Reference: "test_cat.KPI.fast", Value: 1, Timestamp: time.Now().AddDate(0, 0, -20)},
+		},
+	}
+
+	processor := NewMetricsProcessor(metricsConfig, leversConfig, metricsData)
+	calculator := NewScoreCalculator(processor, WeightedScoring)
+
+	metricScore, err := calculator.CalculateMetricScore(metricsData.Metrics[0])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !metricScore.Stale {
+		t.Fatal("expected a metric older than max_age_days to be flagged stale")
+	}
+	if metricScore.Confidence != 0 {
+		t.Fatalf("expected confidence to decay to 0 past max_age_days, got %v", metricScore.Confidence)
+	}
+}
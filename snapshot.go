@@ -0,0 +1,267 @@
+package pulse
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// ScoreSnapshot is a single OverallScore captured at a point in time by
+// SnapshotStore.Save, the building block for GenerateScoreTrendReport and
+// AggregateSnapshots.
+type ScoreSnapshot struct {
+	Timestamp time.Time    `json:"timestamp"`
+	Score     OverallScore `json:"score"`
+}
+
+// SnapshotStore is an append-only, NDJSON-backed log of OverallScore
+// snapshots, partitioned into one file per UTC day under Dir. It mirrors
+// HistoryStore's layout (see history.go), but records whole-report scores
+// rather than individual metric values.
+type SnapshotStore struct {
+	Dir string
+}
+
+// NewSnapshotStore creates a SnapshotStore rooted at dir (typically
+// <data-dir>/snapshots).
+func NewSnapshotStore(dir string) *SnapshotStore {
+	return &SnapshotStore{Dir: dir}
+}
+
+func (s *SnapshotStore) pathForDay(t time.Time) string {
+	return filepath.Join(s.Dir, t.UTC().Format("2006-01-02")+".jsonl")
+}
+
+// Save appends score as a ScoreSnapshot timestamped at, creating Dir and the
+// day's file as needed.
+func (s *SnapshotStore) Save(score OverallScore, at time.Time) error {
+	if err := os.MkdirAll(s.Dir, 0750); err != nil {
+		return fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+
+	data, err := json.Marshal(ScoreSnapshot{Timestamp: at, Score: score})
+	if err != nil {
+		return fmt.Errorf("failed to marshal snapshot: %w", err)
+	}
+
+	path := s.pathForDay(at)
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open snapshot file %s: %w", path, err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+
+	return nil
+}
+
+// Query returns every ScoreSnapshot recorded within [from, to], ordered by
+// timestamp ascending.
+func (s *SnapshotStore) Query(from, to time.Time) ([]ScoreSnapshot, error) {
+	var snapshots []ScoreSnapshot
+
+	for d := from.UTC().Truncate(24 * time.Hour); !d.After(to); d = d.AddDate(0, 0, 1) {
+		dayShots, err := s.readDay(d)
+		if err != nil {
+			return nil, err
+		}
+		for _, snap := range dayShots {
+			if snap.Timestamp.Before(from) || snap.Timestamp.After(to) {
+				continue
+			}
+			snapshots = append(snapshots, snap)
+		}
+	}
+
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].Timestamp.Before(snapshots[j].Timestamp)
+	})
+
+	return snapshots, nil
+}
+
+func (s *SnapshotStore) readDay(day time.Time) ([]ScoreSnapshot, error) {
+	path := s.pathForDay(day)
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to open snapshot file %s: %w", path, err)
+	}
+	defer f.Close()
+
+	var snapshots []ScoreSnapshot
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var snap ScoreSnapshot
+		if err := json.Unmarshal(scanner.Bytes(), &snap); err != nil {
+			return nil, fmt.Errorf("failed to parse snapshot in %s: %w", path, err)
+		}
+		snapshots = append(snapshots, snap)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read snapshot file %s: %w", path, err)
+	}
+
+	return snapshots, nil
+}
+
+// Period is the rollup granularity AggregateSnapshots collapses raw
+// snapshots into.
+type Period string
+
+const (
+	DailyPeriod  Period = "daily"
+	WeeklyPeriod Period = "weekly"
+)
+
+// CategorySummary is the min/max/mean KPI/KRI score for one category across
+// a rollup period.
+type CategorySummary struct {
+	ID           string  `json:"id"`
+	KPIScoreMin  int     `json:"kpi_score_min"`
+	KPIScoreMax  int     `json:"kpi_score_max"`
+	KPIScoreMean float64 `json:"kpi_score_mean"`
+	KRIScoreMin  int     `json:"kri_score_min"`
+	KRIScoreMax  int     `json:"kri_score_max"`
+	KRIScoreMean float64 `json:"kri_score_mean"`
+}
+
+// SnapshotSummary is one rolled-up period produced by AggregateSnapshots:
+// the bucket's start, the number of raw snapshots it summarizes, and a
+// CategorySummary per category observed in that bucket.
+type SnapshotSummary struct {
+	PeriodStart time.Time         `json:"period_start"`
+	SampleCount int               `json:"sample_count"`
+	Categories  []CategorySummary `json:"categories"`
+}
+
+// AggregateSnapshots rolls every raw snapshot in [from, to] into one
+// SnapshotSummary per period bucket (day or week, per granularity), writing
+// each to <Dir>/<granularity>/<bucket date>.json, and returns the summaries
+// it wrote. Raw day files are left untouched. Nothing in this package reads
+// the summary files back: Query and GenerateScoreTrendReport always replay
+// the raw snapshots.
+func (s *SnapshotStore) AggregateSnapshots(granularity Period, from, to time.Time) ([]SnapshotSummary, error) {
+	snapshots, err := s.Query(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	buckets := make(map[time.Time][]ScoreSnapshot)
+	for _, snap := range snapshots {
+		bucketStart := bucketStartFor(granularity, snap.Timestamp)
+		buckets[bucketStart] = append(buckets[bucketStart], snap)
+	}
+
+	var bucketStarts []time.Time
+	for bucketStart := range buckets {
+		bucketStarts = append(bucketStarts, bucketStart)
+	}
+	sort.Slice(bucketStarts, func(i, j int) bool { return bucketStarts[i].Before(bucketStarts[j]) })
+
+	var summaries []SnapshotSummary
+	for _, bucketStart := range bucketStarts {
+		summary := summarizeBucket(bucketStart, buckets[bucketStart])
+		if err := s.writeSummary(granularity, summary); err != nil {
+			return nil, err
+		}
+		summaries = append(summaries, summary)
+	}
+
+	return summaries, nil
+}
+
+// bucketStartFor floors t to the start of its containing day or ISO week
+// (Monday), in UTC.
+func bucketStartFor(granularity Period, t time.Time) time.Time {
+	t = t.UTC()
+	dayStart := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	if granularity != WeeklyPeriod {
+		return dayStart
+	}
+
+	offset := (int(t.Weekday()) + 6) % 7 // Monday == 0
+	return dayStart.AddDate(0, 0, -offset)
+}
+
+func summarizeBucket(bucketStart time.Time, snapshots []ScoreSnapshot) SnapshotSummary {
+	categoryScores := make(map[string][]CategoryScore)
+	for _, snap := range snapshots {
+		for _, category := range snap.Score.Categories {
+			categoryScores[category.ID] = append(categoryScores[category.ID], category)
+		}
+	}
+
+	var categoryIDs []string
+	for id := range categoryScores {
+		categoryIDs = append(categoryIDs, id)
+	}
+	sort.Strings(categoryIDs)
+
+	var categories []CategorySummary
+	for _, id := range categoryIDs {
+		categories = append(categories, summarizeCategory(id, categoryScores[id]))
+	}
+
+	return SnapshotSummary{PeriodStart: bucketStart, SampleCount: len(snapshots), Categories: categories}
+}
+
+func summarizeCategory(id string, scores []CategoryScore) CategorySummary {
+	summary := CategorySummary{
+		ID:          id,
+		KPIScoreMin: scores[0].KPIScore,
+		KPIScoreMax: scores[0].KPIScore,
+		KRIScoreMin: scores[0].KRIScore,
+		KRIScoreMax: scores[0].KRIScore,
+	}
+
+	var kpiSum, kriSum int
+	for _, score := range scores {
+		if score.KPIScore < summary.KPIScoreMin {
+			summary.KPIScoreMin = score.KPIScore
+		}
+		if score.KPIScore > summary.KPIScoreMax {
+			summary.KPIScoreMax = score.KPIScore
+		}
+		if score.KRIScore < summary.KRIScoreMin {
+			summary.KRIScoreMin = score.KRIScore
+		}
+		if score.KRIScore > summary.KRIScoreMax {
+			summary.KRIScoreMax = score.KRIScore
+		}
+		kpiSum += score.KPIScore
+		kriSum += score.KRIScore
+	}
+	summary.KPIScoreMean = float64(kpiSum) / float64(len(scores))
+	summary.KRIScoreMean = float64(kriSum) / float64(len(scores))
+
+	return summary
+}
+
+func (s *SnapshotStore) writeSummary(granularity Period, summary SnapshotSummary) error {
+	dir := filepath.Join(s.Dir, string(granularity))
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return fmt.Errorf("failed to create summary directory: %w", err)
+	}
+
+	path := filepath.Join(dir, summary.PeriodStart.Format("2006-01-02")+".json")
+	data, err := json.MarshalIndent(summary, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal summary: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write summary file %s: %w", path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,111 @@
+package pulse
+
+import (
+	"testing"
+	"time"
+)
+
+func scoreSnapshotFixture(kpiScore, kriScore int) OverallScore {
+	return OverallScore{
+		Categories: []CategoryScore{
+			{
+				ID:       "app_sec",
+				KPIScore: kpiScore,
+				KRIScore: kriScore,
+				Metrics: []MetricScore{
+					{Reference: "app_sec.KPI.coverage", Score: kpiScore},
+					{Reference: "app_sec.KRI.incidents", Score: kriScore},
+				},
+			},
+		},
+	}
+}
+
+func TestSnapshotStoreSaveAndQuery(t *testing.T) {
+	store := NewSnapshotStore(t.TempDir())
+
+	base := time.Date(2026, 7, 20, 12, 0, 0, 0, time.UTC)
+	if err := store.Save(scoreSnapshotFixture(80, 40), base); err != nil {
+		t.Fatalf("failed to save snapshot: %v", err)
+	}
+	if err := store.Save(scoreSnapshotFixture(85, 55), base.Add(24*time.Hour)); err != nil {
+		t.Fatalf("failed to save snapshot: %v", err)
+	}
+	if err := store.Save(scoreSnapshotFixture(90, 70), base.Add(72*time.Hour)); err != nil {
+		t.Fatalf("failed to save snapshot: %v", err)
+	}
+
+	results, err := store.Query(base, base.Add(48*time.Hour))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 snapshots in range, got %d", len(results))
+	}
+	if results[0].Score.Categories[0].KPIScore != 80 || results[1].Score.Categories[0].KPIScore != 85 {
+		t.Fatalf("unexpected ordering: %+v", results)
+	}
+}
+
+func TestSnapshotStoreQueryEmptyStore(t *testing.T) {
+	store := NewSnapshotStore(t.TempDir())
+
+	results, err := store.Query(time.Now().Add(-24*time.Hour), time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != 0 {
+		t.Fatalf("expected no snapshots, got %d", len(results))
+	}
+}
+
+func TestAggregateSnapshotsRollsUpDailyBuckets(t *testing.T) {
+	store := NewSnapshotStore(t.TempDir())
+
+	base := time.Date(2026, 7, 20, 6, 0, 0, 0, time.UTC)
+	if err := store.Save(scoreSnapshotFixture(80, 40), base); err != nil {
+		t.Fatalf("failed to save snapshot: %v", err)
+	}
+	if err := store.Save(scoreSnapshotFixture(90, 60), base.Add(6*time.Hour)); err != nil {
+		t.Fatalf("failed to save snapshot: %v", err)
+	}
+	if err := store.Save(scoreSnapshotFixture(70, 50), base.Add(24*time.Hour)); err != nil {
+		t.Fatalf("failed to save snapshot: %v", err)
+	}
+
+	summaries, err := store.AggregateSnapshots(DailyPeriod, base, base.Add(48*time.Hour))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(summaries) != 2 {
+		t.Fatalf("expected 2 daily buckets, got %d", len(summaries))
+	}
+
+	first := summaries[0]
+	if first.SampleCount != 2 {
+		t.Fatalf("expected the first bucket to have 2 samples, got %d", first.SampleCount)
+	}
+	if len(first.Categories) != 1 {
+		t.Fatalf("expected 1 category summary, got %d", len(first.Categories))
+	}
+	category := first.Categories[0]
+	if category.KPIScoreMin != 80 || category.KPIScoreMax != 90 || category.KPIScoreMean != 85 {
+		t.Fatalf("unexpected KPI summary: %+v", category)
+	}
+	if category.KRIScoreMin != 40 || category.KRIScoreMax != 60 || category.KRIScoreMean != 50 {
+		t.Fatalf("unexpected KRI summary: %+v", category)
+	}
+}
+
+func TestBucketStartForWeeklyFloorsToMonday(t *testing.T) {
+	// 2026-07-23 is a Thursday.
+	thursday := time.Date(2026, 7, 23, 15, 30, 0, 0, time.UTC)
+	weekStart := bucketStartFor(WeeklyPeriod, thursday)
+
+	if weekStart.Weekday() != time.Monday {
+		t.Fatalf("expected the week to start on Monday, got %s", weekStart.Weekday())
+	}
+	if weekStart.Format("2006-01-02") != "2026-07-20" {
+		t.Fatalf("expected 2026-07-20, got %s", weekStart.Format("2006-01-02"))
+	}
+}
@@ -0,0 +1,164 @@
+package pulse
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// MetricsSource resolves a live value for a metric reference, bypassing the
+// static value stored in MetricsData.
+type MetricsSource interface {
+	// FetchValue returns the current value for the given PromQL-style query
+	// evaluated against endpoint.
+	FetchValue(endpoint, query string) (float64, error)
+}
+
+// promQLResponse models the subset of the Prometheus HTTP API instant query
+// response that pulse cares about.
+type promQLResponse struct {
+	Status string `json:"status"`
+	Data   struct {
+		ResultType string `json:"resultType"`
+		Result     []struct {
+			Value [2]interface{} `json:"value"`
+		} `json:"result"`
+	} `json:"data"`
+	Error string `json:"error"`
+}
+
+// EmptyVectorError is returned by PromQLSource.FetchValue when query
+// evaluates to an empty instant vector. It is a distinct type (rather than
+// a plain error) so refreshLiveMetric can tell "no data yet" apart from a
+// hard request/parse failure and decide, based on KPI/KRI.RequireSamples,
+// whether to fall back to the metric's last known value or fail the run.
+type EmptyVectorError struct {
+	Query string
+}
+
+func (e *EmptyVectorError) Error() string {
+	return fmt.Sprintf("promql source: query %q returned an empty vector", e.Query)
+}
+
+// defaultPromQLCacheTTL is how long PromQLSource.FetchValue reuses a prior
+// result for the same endpoint/query pair when a PromQLSource is built
+// without WithCacheTTL, so a report and a list-metrics run moments apart
+// don't each hit the Prometheus server.
+const defaultPromQLCacheTTL = 30 * time.Second
+
+// promQLCacheEntry is one cached FetchValue result.
+type promQLCacheEntry struct {
+	value     float64
+	fetchedAt time.Time
+}
+
+// PromQLSource evaluates PromQL queries against a Prometheus HTTP API
+// endpoint and returns the resulting instant vector as a single float64,
+// caching successful results for cacheTTL so repeated calls for the same
+// endpoint/query don't re-hit the server.
+type PromQLSource struct {
+	HTTPClient *http.Client
+	cacheTTL   time.Duration
+
+	mu    sync.Mutex
+	cache map[string]promQLCacheEntry
+}
+
+// PromQLSourceOption configures optional PromQLSource behavior.
+type PromQLSourceOption func(*PromQLSource)
+
+// WithCacheTTL overrides how long a successful FetchValue result is reused
+// for the same endpoint/query pair. A zero TTL disables caching entirely,
+// querying Prometheus on every call.
+func WithCacheTTL(ttl time.Duration) PromQLSourceOption {
+	return func(p *PromQLSource) {
+		p.cacheTTL = ttl
+	}
+}
+
+// NewPromQLSource creates a PromQLSource with a sane request timeout and
+// defaultPromQLCacheTTL caching.
+func NewPromQLSource(opts ...PromQLSourceOption) *PromQLSource {
+	p := &PromQLSource{
+		HTTPClient: &http.Client{Timeout: 10 * time.Second},
+		cacheTTL:   defaultPromQLCacheTTL,
+		cache:      make(map[string]promQLCacheEntry),
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+// FetchValue executes query against the Prometheus /api/v1/query endpoint
+// rooted at endpoint and returns the scalar value of the first series in the
+// instant vector result, serving a cached value when one was fetched within
+// cacheTTL. Returns an *EmptyVectorError when the query succeeds but
+// resolves to no series.
+func (p *PromQLSource) FetchValue(endpoint, query string) (float64, error) {
+	if endpoint == "" {
+		return 0, fmt.Errorf("promql source: endpoint is required")
+	}
+	if query == "" {
+		return 0, fmt.Errorf("promql source: query is required")
+	}
+
+	cacheKey := endpoint + "|" + query
+	if p.cacheTTL > 0 {
+		p.mu.Lock()
+		entry, ok := p.cache[cacheKey]
+		p.mu.Unlock()
+		if ok && time.Since(entry.fetchedAt) < p.cacheTTL {
+			return entry.value, nil
+		}
+	}
+
+	reqURL := fmt.Sprintf("%s/api/v1/query?query=%s", endpoint, url.QueryEscape(query))
+
+	resp, err := p.HTTPClient.Get(reqURL)
+	if err != nil {
+		return 0, fmt.Errorf("promql source: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("promql source: unexpected status %d", resp.StatusCode)
+	}
+
+	var parsed promQLResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return 0, fmt.Errorf("promql source: failed to decode response: %w", err)
+	}
+
+	if parsed.Status != "success" {
+		return 0, fmt.Errorf("promql source: query failed: %s", parsed.Error)
+	}
+
+	if len(parsed.Data.Result) == 0 {
+		return 0, &EmptyVectorError{Query: query}
+	}
+
+	raw, ok := parsed.Data.Result[0].Value[1].(string)
+	if !ok {
+		return 0, fmt.Errorf("promql source: unexpected sample value type")
+	}
+
+	value, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return 0, fmt.Errorf("promql source: failed to parse sample value %q: %w", raw, err)
+	}
+
+	if p.cacheTTL > 0 {
+		p.mu.Lock()
+		p.cache[cacheKey] = promQLCacheEntry{value: value, fetchedAt: time.Now()}
+		p.mu.Unlock()
+	}
+
+	return value, nil
+}
@@ -0,0 +1,95 @@
+package pulse
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func promQLTestServer(t *testing.T, value string) (*httptest.Server, *int32) {
+	t.Helper()
+
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		fmt.Fprintf(w, `{"status":"success","data":{"resultType":"vector","result":[{"value":[0,%q]}]}}`, value)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &hits
+}
+
+func TestPromQLSourceFetchValueParsesResult(t *testing.T) {
+	server, _ := promQLTestServer(t, "42.5")
+	source := NewPromQLSource()
+
+	value, err := source.FetchValue(server.URL, "up")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if value != 42.5 {
+		t.Fatalf("expected 42.5, got %v", value)
+	}
+}
+
+func TestPromQLSourceFetchValueCachesWithinTTL(t *testing.T) {
+	server, hits := promQLTestServer(t, "1")
+	source := NewPromQLSource(WithCacheTTL(time.Minute))
+
+	if _, err := source.FetchValue(server.URL, "up"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := source.FetchValue(server.URL, "up"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := atomic.LoadInt32(hits); got != 1 {
+		t.Fatalf("expected a single request to be cached, got %d requests", got)
+	}
+}
+
+func TestPromQLSourceFetchValueDisabledCacheRefetches(t *testing.T) {
+	server, hits := promQLTestServer(t, "1")
+	source := NewPromQLSource(WithCacheTTL(0))
+
+	if _, err := source.FetchValue(server.URL, "up"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := source.FetchValue(server.URL, "up"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := atomic.LoadInt32(hits); got != 2 {
+		t.Fatalf("expected every call to re-fetch with caching disabled, got %d requests", got)
+	}
+}
+
+func TestPromQLSourceFetchValueEmptyVector(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"status":"success","data":{"resultType":"vector","result":[]}}`)
+	}))
+	t.Cleanup(server.Close)
+
+	source := NewPromQLSource()
+	_, err := source.FetchValue(server.URL, "up")
+
+	var emptyVector *EmptyVectorError
+	if !errors.As(err, &emptyVector) {
+		t.Fatalf("expected an *EmptyVectorError, got %v (%T)", err, err)
+	}
+}
+
+func TestPromQLSourceFetchValueRequiresEndpointAndQuery(t *testing.T) {
+	source := NewPromQLSource()
+
+	if _, err := source.FetchValue("", "up"); err == nil {
+		t.Fatal("expected an error for a missing endpoint")
+	}
+	if _, err := source.FetchValue("http://example.invalid", ""); err == nil {
+		t.Fatal("expected an error for a missing query")
+	}
+}
@@ -0,0 +1,72 @@
+package pulse
+
+import "testing"
+
+func lowerIsBetterThresholds() Thresholds {
+	return Thresholds{
+		Green:  ThresholdRange{Min: 0, Max: 19, Direction: LowerIsBetter},
+		Yellow: ThresholdRange{Min: 20, Max: 59, Direction: LowerIsBetter},
+		Red:    ThresholdRange{Min: 60, Max: 100, Direction: LowerIsBetter},
+	}
+}
+
+func TestDetermineStatusHonorsLowerIsBetterThresholds(t *testing.T) {
+	thresholds := lowerIsBetterThresholds()
+
+	if got := determineStatus(5, thresholds); got != Green {
+		t.Fatalf("expected a low score to be Green, got %s", got)
+	}
+	if got := determineStatus(40, thresholds); got != Yellow {
+		t.Fatalf("expected a mid score to be Yellow, got %s", got)
+	}
+	if got := determineStatus(90, thresholds); got != Red {
+		t.Fatalf("expected a high score to be Red, got %s", got)
+	}
+}
+
+func TestValidateThresholdsAcceptsInvertedLowerIsBetterStack(t *testing.T) {
+	leversConfig := &LeversConfig{
+		Global: Global{Thresholds: lowerIsBetterThresholds()},
+	}
+
+	checks := ValidateThresholds(leversConfig)
+	for _, check := range checks {
+		if !check.OK {
+			t.Fatalf("expected %s to pass for a valid lower-is-better stack, got: %s", check.Name, check.Details)
+		}
+	}
+}
+
+func TestValidateThresholdsCatchesInvertedOverlap(t *testing.T) {
+	thresholds := lowerIsBetterThresholds()
+	thresholds.Yellow.Max = 65 // now overlaps Red's lower bound of 60
+	leversConfig := &LeversConfig{Global: Global{Thresholds: thresholds}}
+
+	checks := ValidateThresholds(leversConfig)
+	for _, check := range checks {
+		if check.Name == "threshold_overlap" && check.OK {
+			t.Fatal("expected threshold_overlap to fail for an overlapping inverted stack")
+		}
+	}
+}
+
+func TestBandResolverHoldsBandUnderLowerIsBetterExitMargin(t *testing.T) {
+	thresholds := lowerIsBetterThresholds()
+	thresholds.Hysteresis = &Hysteresis{ExitMargin: FloatPtr(5)}
+	resolver := NewBandResolver("")
+
+	if got := resolver.Resolve("incident_count", 10, thresholds); got != Green {
+		t.Fatalf("expected Green, got %s", got)
+	}
+
+	// A rise to 22 is past the plain Green boundary (19) but still below the
+	// exit cutoff (19+5=24), so the resolver should stick to Green.
+	if got := resolver.Resolve("incident_count", 22, thresholds); got != Green {
+		t.Fatalf("expected the resolver to hold Green within the exit margin, got %s", got)
+	}
+
+	// A rise to 30 clears the exit cutoff, so the transition now takes effect.
+	if got := resolver.Resolve("incident_count", 30, thresholds); got != Yellow {
+		t.Fatalf("expected the resolver to transition to Yellow once the exit margin clears, got %s", got)
+	}
+}
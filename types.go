@@ -19,29 +19,59 @@ type Category struct {
 	KRIs        []KRI  `yaml:"kris"`
 }
 
+// Direction indicates which side of a threshold or scoring band represents
+// the desirable side of a metric's value.
+type Direction string
+
+const (
+	// HigherIsBetter means larger values are preferable (e.g. availability %)
+	HigherIsBetter Direction = "higher_is_better"
+	// LowerIsBetter means smaller values are preferable (e.g. incident count)
+	LowerIsBetter Direction = "lower_is_better"
+)
+
 // ScoringBand represents a single scoring band with min/max values and the resulting score
 type ScoringBand struct {
-	Min   *float64 `yaml:"min,omitempty"`
-	Max   *float64 `yaml:"max,omitempty"`
-	Score int      `yaml:"score"`
+	Min       *float64  `yaml:"min,omitempty"`
+	Max       *float64  `yaml:"max,omitempty"`
+	Score     int       `yaml:"score"`
+	Direction Direction `yaml:"direction,omitempty"` // defaults to HigherIsBetter when empty
 }
 
 // KPI represents a Key Performance Indicator
 type KPI struct {
-	ID           string        `yaml:"id"`
-	Name         string        `yaml:"name"`
-	Description  string        `yaml:"description"`
-	Unit         string        `yaml:"unit"`
-	ScoringBands []ScoringBand `yaml:"scoring_bands"`
+	ID             string        `yaml:"id"`
+	Name           string        `yaml:"name"`
+	Description    string        `yaml:"description"`
+	Unit           string        `yaml:"unit"`
+	ScoringBands   []ScoringBand `yaml:"scoring_bands"`
+	Query          string        `yaml:"query,omitempty"`           // PromQL query used to resolve the live value
+	Endpoint       string        `yaml:"endpoint,omitempty"`        // Prometheus HTTP API endpoint for Query
+	RequireSamples bool          `yaml:"require_samples,omitempty"` // when true, RefreshLiveMetrics fails the run if Query returns an empty vector instead of falling back to the last known value
+	Formula        string        `yaml:"formula,omitempty"`         // expression producing a 0-100 score directly; takes priority over ScoringBands when set
+	Target         *float64      `yaml:"target,omitempty"`          // exposed to Formula as the "target" variable
+	Min            *float64      `yaml:"min,omitempty"`             // exposed to Formula as the "min" variable
+	Max            *float64      `yaml:"max,omitempty"`             // exposed to Formula as the "max" variable
+	Weight         *float64      `yaml:"weight,omitempty"`          // this metric's share of its category's score under WeightedScoring; nil means equal weighting
+	Confidence     *float64      `yaml:"confidence,omitempty"`      // 0.0-1.0 trust in this metric's data quality under WeightedScoring; nil means full confidence
 }
 
 // KRI represents a Key Risk Indicator
 type KRI struct {
-	ID           string        `yaml:"id"`
-	Name         string        `yaml:"name"`
-	Description  string        `yaml:"description"`
-	Unit         string        `yaml:"unit"`
-	ScoringBands []ScoringBand `yaml:"scoring_bands"`
+	ID             string        `yaml:"id"`
+	Name           string        `yaml:"name"`
+	Description    string        `yaml:"description"`
+	Unit           string        `yaml:"unit"`
+	ScoringBands   []ScoringBand `yaml:"scoring_bands"`
+	Query          string        `yaml:"query,omitempty"`           // PromQL query used to resolve the live value
+	Endpoint       string        `yaml:"endpoint,omitempty"`        // Prometheus HTTP API endpoint for Query
+	RequireSamples bool          `yaml:"require_samples,omitempty"` // when true, RefreshLiveMetrics fails the run if Query returns an empty vector instead of falling back to the last known value
+	Formula        string        `yaml:"formula,omitempty"`         // expression producing a 0-100 score directly; takes priority over ScoringBands when set
+	Target         *float64      `yaml:"target,omitempty"`          // exposed to Formula as the "target" variable
+	Min            *float64      `yaml:"min,omitempty"`             // exposed to Formula as the "min" variable
+	Max            *float64      `yaml:"max,omitempty"`             // exposed to Formula as the "max" variable
+	Weight         *float64      `yaml:"weight,omitempty"`          // this metric's share of its category's score under WeightedScoring; nil means equal weighting
+	Confidence     *float64      `yaml:"confidence,omitempty"`      // 0.0-1.0 trust in this metric's data quality under WeightedScoring; nil means full confidence
 }
 
 // MetricsConfig represents the structure of the metrics configuration file
@@ -51,10 +81,45 @@ type MetricsConfig struct {
 
 // Metric represents a single metric measurement
 type Metric struct {
-	Reference  string    `yaml:"reference"`
-	Value      float64   `yaml:"value"`
-	Timestamp  time.Time `yaml:"timestamp"`
-	SourceFile string    `yaml:"-"` // Source file for the metric (not stored in YAML)
+	Reference  string      `yaml:"reference"`
+	Value      float64     `yaml:"value"`
+	Timestamp  time.Time   `yaml:"timestamp"`
+	Scope      MetricScope `yaml:"scope,omitempty"`     // organizational level this observation describes; empty means unscoped
+	ScopeKey   string      `yaml:"scope_key,omitempty"` // scope-instance identifier, e.g. "payments" when Scope is ScopeTeam
+	SourceFile string      `yaml:"-"`                   // Source file for the metric (not stored in YAML)
+}
+
+// MetricScope classifies which level of an organization a metric
+// observation describes, from the most granular (asset) to the broadest
+// (org). ScoreCalculator.CalculateRollup aggregates narrower scopes up into
+// broader ones following the partial order Granularity defines.
+type MetricScope string
+
+const (
+	ScopeAsset    MetricScope = "asset"
+	ScopeTeam     MetricScope = "team"
+	ScopeService  MetricScope = "service"
+	ScopeCategory MetricScope = "category"
+	ScopeOrg      MetricScope = "org"
+)
+
+// scopeGranularity orders MetricScope from most granular (lowest) to
+// broadest (highest).
+var scopeGranularity = map[MetricScope]int{
+	ScopeAsset:    0,
+	ScopeTeam:     1,
+	ScopeService:  2,
+	ScopeCategory: 3,
+	ScopeOrg:      4,
+}
+
+// Granularity returns s's position in the asset < team < service <
+// category < org hierarchy, or -1 if s is not a recognized scope.
+func (s MetricScope) Granularity() int {
+	if g, ok := scopeGranularity[s]; ok {
+		return g
+	}
+	return -1
 }
 
 // MetricsData represents the structure of the metrics data file
@@ -62,10 +127,97 @@ type MetricsData struct {
 	Metrics []Metric `yaml:"metrics"`
 }
 
+// Bound is one inequality edge of a ThresholdRange, e.g. {Op: ">=", Value:
+// 80}, generalizing the legacy inclusive Min/Max form so a band can be
+// open or closed at either edge.
+type Bound struct {
+	Op    string  `yaml:"op"` // one of >, >=, <, <=
+	Value float64 `yaml:"value"`
+}
+
+// Satisfies reports whether score satisfies the bound's inequality.
+func (b Bound) Satisfies(score float64) bool {
+	switch b.Op {
+	case ">":
+		return score > b.Value
+	case ">=":
+		return score >= b.Value
+	case "<":
+		return score < b.Value
+	case "<=":
+		return score <= b.Value
+	default:
+		return false
+	}
+}
+
 // ThresholdRange represents a range with min and max values
 type ThresholdRange struct {
-	Min int `yaml:"min"` // Minimum value (inclusive)
-	Max int `yaml:"max"` // Maximum value (inclusive)
+	Min       int       `yaml:"min"`                 // Minimum value (inclusive)
+	Max       int       `yaml:"max"`                 // Maximum value (inclusive)
+	Direction Direction `yaml:"direction,omitempty"` // defaults to HigherIsBetter when empty
+
+	// Lower and Upper express the same range as a pair of inequalities
+	// instead of an inclusive [Min,Max], so a band can be open-ended (nil
+	// Lower or Upper, e.g. a Red band with only a ceiling) or exclusive at
+	// either edge (Op ">" or "<" instead of ">=" or "<="). When both are
+	// nil, Contains falls back to the legacy inclusive [Min,Max] form.
+	Lower *Bound `yaml:"lower,omitempty"`
+	Upper *Bound `yaml:"upper,omitempty"`
+
+	// Range, when set, is a Nagios-plugin style range spec (e.g. "60:79",
+	// "80:", "@0:59") parsed by ParseNagiosRange, taking priority over
+	// Lower/Upper and the legacy Min/Max form. It's the only form that can
+	// express an inverted ("@") band, but neither Contains nor
+	// normalizedBounds (used by ValidateThresholds and BandResolver's
+	// hysteresis boundary math, both of which assume a single contiguous
+	// [lower,upper] region per band) honors the inversion: both read just
+	// the Range's numeric edges.
+	Range string `yaml:"range,omitempty"`
+}
+
+// normalizedBounds returns t's Lower and Upper bounds: Lower/Upper when set
+// explicitly, else a parsed Range's numeric edges, else the legacy
+// inclusive Min/Max fields, so levers.yaml files using only {min,max} keep
+// behaving exactly as before.
+func (t ThresholdRange) normalizedBounds() (lower, upper *Bound) {
+	if t.Lower != nil || t.Upper != nil {
+		return t.Lower, t.Upper
+	}
+	if t.Range != "" {
+		if r, err := ParseNagiosRange(t.Range); err == nil {
+			if r.Lower != nil {
+				lower = &Bound{Op: ">=", Value: *r.Lower}
+			}
+			if r.Upper != nil {
+				upper = &Bound{Op: "<=", Value: *r.Upper}
+			}
+			return lower, upper
+		}
+	}
+	return &Bound{Op: ">=", Value: float64(t.Min)}, &Bound{Op: "<=", Value: float64(t.Max)}
+}
+
+// Contains reports whether score falls within the range. A Range spec's
+// [lower,upper] bounds are tested directly, regardless of a leading "@":
+// Invert only changes what Alerts considers "bad", not which scores the
+// band itself covers. Lower/Upper and the legacy Min/Max form are always
+// a plain contiguous [lower,upper] test too.
+func (t ThresholdRange) Contains(score int) bool {
+	if t.Range != "" {
+		if r, err := ParseNagiosRange(t.Range); err == nil {
+			return r.Inside(float64(score))
+		}
+	}
+
+	lower, upper := t.normalizedBounds()
+	if lower != nil && !lower.Satisfies(float64(score)) {
+		return false
+	}
+	if upper != nil && !upper.Satisfies(float64(score)) {
+		return false
+	}
+	return true
 }
 
 // Thresholds represents the traffic light thresholds with ranges
@@ -73,6 +225,39 @@ type Thresholds struct {
 	Green  ThresholdRange `yaml:"green"`  // Green threshold range
 	Yellow ThresholdRange `yaml:"yellow"` // Yellow threshold range
 	Red    ThresholdRange `yaml:"red"`    // Red threshold range
+
+	// Hysteresis, when set, makes BandResolver stick to a target's
+	// previously resolved band until the score clears the far side of an
+	// enter/exit margin, instead of flipping bands on every small score
+	// movement across a boundary.
+	Hysteresis *Hysteresis `yaml:"hysteresis,omitempty"`
+}
+
+// effectiveDirection returns t's orientation for a lower-is-better metric:
+// Green low, Yellow mid, Red high instead of the default Red low, Green
+// high. It reads whichever of Green/Yellow/Red declares a Direction first
+// (they're expected to agree when set), defaulting to HigherIsBetter when
+// none of them do.
+func (t Thresholds) effectiveDirection() Direction {
+	for _, d := range []Direction{t.Green.Direction, t.Yellow.Direction, t.Red.Direction} {
+		if d != "" {
+			return d
+		}
+	}
+	return HigherIsBetter
+}
+
+// Hysteresis configures how much margin a band transition needs before
+// BandResolver honors it. EnterMargin/ExitMargin are added to or subtracted
+// from the plain Red/Yellow and Yellow/Green boundaries to derive the
+// enter/exit cutoffs; EnterMin/ExitMin, when set, are used as the cutoffs
+// directly instead of being derived from a margin. All four fields are
+// optional; an unset one leaves that cutoff at the plain boundary.
+type Hysteresis struct {
+	EnterMargin *float64 `yaml:"enter_margin,omitempty"`
+	ExitMargin  *float64 `yaml:"exit_margin,omitempty"`
+	EnterMin    *float64 `yaml:"enter_min,omitempty"`
+	ExitMin     *float64 `yaml:"exit_min,omitempty"`
 }
 
 // CategoryWeights represents the weights for each category
@@ -83,9 +268,54 @@ type CategoryThresholds map[string]Thresholds
 
 // Global represents global configuration settings
 type Global struct {
-	Thresholds    Thresholds `yaml:"thresholds"`
-	KPIThresholds Thresholds `yaml:"kpi_thresholds"`
-	KRIThresholds Thresholds `yaml:"kri_thresholds"`
+	Thresholds              Thresholds              `yaml:"thresholds"`
+	KPIThresholds           Thresholds              `yaml:"kpi_thresholds"`
+	KRIThresholds           Thresholds              `yaml:"kri_thresholds"`
+	Retention               RetentionPolicy         `yaml:"retention"`
+	CriticalKRIs            map[string]CriticalRule `yaml:"critical_kris"`                  // metric reference -> veto rule
+	MinCriticalVetoesForRed int                     `yaml:"min_critical_vetoes_for_red"`    // tripped critical KRIs needed to force the overall Status to Red; 0 or less means the default of 1
+	DistributionBuckets     []float64               `yaml:"distribution_buckets,omitempty"` // histogram boundaries for CategoryScore/OverallScore.Distribution; defaults to 0,10,20,...,100 when empty
+	MaxMetricAgeDays        int                     `yaml:"max_age_days,omitempty"`         // under WeightedScoring, a metric's Confidence decays linearly to zero as its observation age approaches this many days; zero disables decay
+	TrendRules              map[string]TrendRule    `yaml:"trend_rules,omitempty"`          // metric reference -> trend-based status override
+}
+
+// CriticalRule is a veto condition for a KRI: when a metric's value
+// satisfies Op against Value, its enclosing category's Status (and,
+// depending on MinCriticalVetoesForRed, the overall Status) is forced to
+// Red regardless of the numeric score, so a single catastrophic KRI can't
+// be averaged or medianed away.
+type CriticalRule struct {
+	Op    string  `yaml:"op"` // one of >, >=, <, <=, ==, !=
+	Value float64 `yaml:"value"`
+}
+
+// TrendRule forces a metric's Status based on the direction of its recent
+// raw values rather than its current score alone, so a regression shows up
+// before enough individual observations have pushed the score itself out
+// of Green. TrendUp and TrendDown are mutually exclusive: when TrendUp is
+// set, the rule only considers firing if the last Window samples (the
+// current value included) are non-decreasing; TrendDown requires them to be
+// non-increasing. ThresholdMin/ThresholdMax, when set, additionally gate
+// the rule on the current value itself (e.g. "3 consecutive samples
+// trending upward while value >= 80"); either or both may be nil to gate on
+// direction alone. Window is the number of samples (current value
+// included) the direction check spans, and must be at least 2.
+type TrendRule struct {
+	TrendUp      bool               `yaml:"trend_up,omitempty"`
+	TrendDown    bool               `yaml:"trend_down,omitempty"`
+	ThresholdMin *float64           `yaml:"threshold_min,omitempty"`
+	ThresholdMax *float64           `yaml:"threshold_max,omitempty"`
+	Window       int                `yaml:"window"`
+	Status       TrafficLightStatus `yaml:"status"` // status to force when the rule fires
+}
+
+// RetentionPolicy controls how long a HistoryStore keeps raw observations
+// before they are eligible for downsampling to daily aggregates, and how
+// long those daily aggregates are kept in turn. A zero value means "keep
+// forever" for that tier.
+type RetentionPolicy struct {
+	RawDays   int `yaml:"raw_days"`
+	DailyDays int `yaml:"daily_days"`
 }
 
 // Weights represents the weights configuration
@@ -98,8 +328,66 @@ type Weights struct {
 
 // LeversConfig represents the structure of the executive levers configuration file
 type LeversConfig struct {
-	Global  Global  `yaml:"global"`
-	Weights Weights `yaml:"weights"`
+	Global          Global                `yaml:"global"`
+	Weights         Weights               `yaml:"weights"`
+	Rules           []CustomRule          `yaml:"rules,omitempty"`
+	MetricRules     []MetricThresholdRule `yaml:"metric_rules,omitempty"`
+	ReferenceSchema *ReferenceSchema      `yaml:"reference_schema,omitempty"`
+}
+
+// ReferenceSchema configures what MetricsProcessor considers a
+// well-formed metric reference. A nil ReferenceSchema (the default,
+// when levers.yaml has no reference_schema block) falls back to the
+// built-in scheme: a 3-part dot-separated reference whose middle part is
+// "KPI" or "KRI", at most 100 characters, using only letters, digits,
+// '.', '_', and '-'.
+type ReferenceSchema struct {
+	// MiddleTokens are the allowed values for the reference's type
+	// segment, e.g. ["KPI", "KRI"] or ["KPI", "KRI", "OKR", "SLO", "SLI"].
+	// Defaults to ["KPI", "KRI"] when empty.
+	MiddleTokens []string `yaml:"middle_tokens,omitempty"`
+	// MaxLength caps the overall reference length. Defaults to 100 when zero.
+	MaxLength int `yaml:"max_length,omitempty"`
+	// SegmentPattern is a regular expression each segment must match
+	// (applied to category, type, and metric ID segments alike, and to
+	// the team segment when TeamSegment is set). Defaults to
+	// "^[A-Za-z0-9_-]+$" when empty.
+	SegmentPattern string `yaml:"segment_pattern,omitempty"`
+	// TeamSegment, when true, accepts a leading "$team_name" segment
+	// before the category (e.g. "$payments.SEC.KPI.coverage"), making
+	// well-formed references 3 or 4 dot-separated parts instead of
+	// exactly 3.
+	TeamSegment bool `yaml:"team_segment,omitempty"`
+}
+
+// CustomRule is an organization-specific invariant over a LeversConfig,
+// checked by ValidateCustomRules in addition to the built-in
+// order/overlap/coverage/hysteresis checks. Expression is evaluated by the
+// small expression language in rules.go against the loaded config; the
+// rule fails validation when Expression evaluates to false.
+type CustomRule struct {
+	ID         string `yaml:"id"`
+	Message    string `yaml:"message"`
+	Severity   string `yaml:"severity,omitempty"` // "error" or "warning"; defaults to "error"
+	Expression string `yaml:"expression"`
+}
+
+// MetricThresholdRule is an alert-style threshold check over metric data
+// itself, checked by MetricsProcessor.EvaluateRules in addition to the
+// color-band thresholds a metric is already scored against. Selector is
+// either a bare reference ("SEC.KPI.coverage") or an aggregate over a
+// glob-matched set of references ("avg(SEC.KPI.*)", "max(OPS.KRI.*)",
+// "count(*.KRI.*)", "sum", "min"); LowerBound chooses whether Threshold is
+// a floor (the rule fails when the resolved value drops below it) or a
+// ceiling (fails when it rises above), so a single rule shape covers both
+// "alert if availability drops below 99.9" and "alert if open
+// vulnerabilities exceeds 50".
+type MetricThresholdRule struct {
+	Name       string  `yaml:"name"`
+	Selector   string  `yaml:"selector"`
+	Threshold  float64 `yaml:"threshold"`
+	LowerBound bool    `yaml:"lower_bound"`
+	Message    string  `yaml:"message,omitempty"`
 }
 
 // TrafficLightStatus represents the status in the traffic light model
@@ -111,33 +399,146 @@ const (
 	Red    TrafficLightStatus = "red"
 )
 
+// TrendDirection classifies how a metric's trend score is moving relative
+// to its recent history.
+type TrendDirection string
+
+const (
+	// TrendImproving means the metric's score has been rising over its
+	// recent scored points.
+	TrendImproving TrendDirection = "improving"
+	// TrendSteady means the metric's score has held roughly constant.
+	TrendSteady TrendDirection = "steady"
+	// TrendDeclining means the metric's score has been falling.
+	TrendDeclining TrendDirection = "declining"
+)
+
 // MetricScore represents a calculated score for a metric
 type MetricScore struct {
 	Reference string
 	Score     int
 	Status    TrafficLightStatus
+	Violation string // human-readable explanation when the metric is not in its best band, empty otherwise
+
+	// TrendScore and Trend summarize sustained performance rather than this
+	// single observation: TrendScore is an exponentially-weighted moving
+	// average of the metric's scored history (recent points weighted more
+	// heavily), and Trend classifies its recent slope. Both are zero-valued
+	// when no HistoryStore is attached via MetricsProcessor.SetHistoryStore.
+	TrendScore int
+	Trend      TrendDirection
+
+	// Confidence is the metric's configured Confidence (1.0 when unset),
+	// linearly decayed toward zero as its observation age approaches
+	// Global.MaxMetricAgeDays; it feeds category aggregation under
+	// WeightedScoring but is reported for every scoring method. Stale reports
+	// whether that decay actually reduced it below its configured value.
+	Confidence float64
+	Stale      bool
+
+	// TrendRuleForced is true when a Global.TrendRules entry for this metric
+	// tripped, overriding Status regardless of Score.
+	TrendRuleForced bool
 }
 
 // CategoryScore represents a calculated score for a category
 type CategoryScore struct {
-	ID        string
-	Name      string
+	ID           string
+	Name         string
+	Score        int
+	KPIScore     int
+	KRIScore     int
+	Status       TrafficLightStatus
+	KPIStatus    TrafficLightStatus
+	KRIStatus    TrafficLightStatus
+	Metrics      []MetricScore
+	VetoedBy     []string          // critical KRI references whose Critical rule tripped, forcing Status to Red
+	Distribution ScoreDistribution // histogram of this category's metric scores
+
+	// Children holds the per-scope-instance score (e.g. one entry per team)
+	// that rolled up into this score, keyed by scope key. Populated only by
+	// ScoreCalculator.CalculateRollup; nil for ordinary category scores.
+	Children map[string]*CategoryScore
+}
+
+// ScoreDistribution is a bucketed histogram of the metric scores behind a
+// CategoryScore or OverallScore, modeled after runtime/metrics.Float64Histogram:
+// Counts[i] counts scores falling in [Buckets[i], Buckets[i+1]), with the
+// final bucket's upper bound inclusive. Min/Max/P25/P50/P75/P90 summarize
+// the same scores so dashboards can show spread without re-deriving it from
+// the raw buckets.
+type ScoreDistribution struct {
+	Buckets []float64
+	Counts  []uint64
+	Min     int
+	Max     int
+	P25     int
+	P50     int
+	P75     int
+	P90     int
+}
+
+// CollectorsConfig represents the structure of the file collector
+// configuration file, which maps metric references to extraction rules.
+type CollectorsConfig struct {
+	Rules []CollectionRule `yaml:"rules"`
+}
+
+// NotifierConfig configures a single named notification channel. Type
+// selects which Notifier implementation NotifierFromConfig builds; the
+// remaining fields are interpreted according to Type and left empty
+// otherwise (e.g. WebhookURL for "slack"/"teams", SMTPHost/From/To for
+// "email").
+type NotifierConfig struct {
+	Type       string   `yaml:"type"`
+	WebhookURL string   `yaml:"webhook_url,omitempty"`
+	RoutingKey string   `yaml:"routing_key,omitempty"`
+	URL        string   `yaml:"url,omitempty"`
+	SMTPHost   string   `yaml:"smtp_host,omitempty"`
+	SMTPPort   int      `yaml:"smtp_port,omitempty"`
+	Username   string   `yaml:"username,omitempty"`
+	Password   string   `yaml:"password,omitempty"`
+	From       string   `yaml:"from,omitempty"`
+	To         []string `yaml:"to,omitempty"`
+}
+
+// NotificationRule routes a status transition to one or more channels.
+// Scope is either "overall" or a category ID, or "*" to match any
+// category; Status is the TrafficLightStatus the transition must land on
+// (e.g. "red") for the rule to fire. Channels names a key into
+// NotificationsConfig.Notifiers for each channel the rule dispatches to.
+type NotificationRule struct {
+	Scope    string   `yaml:"scope"`
+	Status   string   `yaml:"status"`
+	Channels []string `yaml:"channels"`
+}
+
+// NotificationsConfig represents the structure of the notifications
+// configuration file: the named channels available to dispatch to, and the
+// rules routing status transitions to them.
+type NotificationsConfig struct {
+	Notifiers map[string]NotifierConfig `yaml:"notifiers"`
+	Rules     []NotificationRule        `yaml:"rules"`
+}
+
+// ScorePoint is a single point on a metric's score trend, replaying a
+// historical value through the current scoring bands.
+type ScorePoint struct {
+	Timestamp time.Time
+	Value     float64
 	Score     int
-	KPIScore  int
-	KRIScore  int
 	Status    TrafficLightStatus
-	KPIStatus TrafficLightStatus
-	KRIStatus TrafficLightStatus
-	Metrics   []MetricScore
 }
 
 // OverallScore represents the overall security posture score
 type OverallScore struct {
-	Score      int
-	KPIScore   int
-	KRIScore   int
-	Status     TrafficLightStatus
-	KPIStatus  TrafficLightStatus
-	KRIStatus  TrafficLightStatus
-	Categories []CategoryScore
+	Score        int
+	KPIScore     int
+	KRIScore     int
+	Status       TrafficLightStatus
+	KPIStatus    TrafficLightStatus
+	KRIStatus    TrafficLightStatus
+	Categories   []CategoryScore
+	VetoedBy     []string          // critical KRI references, across all categories, whose Critical rule tripped
+	Distribution ScoreDistribution // histogram of every metric score across every category
 }
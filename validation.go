@@ -0,0 +1,641 @@
+package pulse
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+)
+
+// ValidationCode identifies a specific kind of levers-configuration
+// validation failure, so callers (e.g. the CLI's validate commands) can
+// report a stable, machine-readable reason rather than just free-form
+// text, and map each to a distinct process exit code.
+type ValidationCode string
+
+const (
+	// ErrConfigMissing means the levers configuration could not be loaded
+	// at all (missing file, unreadable, invalid YAML).
+	ErrConfigMissing ValidationCode = "config_missing"
+	// ErrWeightsSum means the category weights do not add up to 100%.
+	ErrWeightsSum ValidationCode = "weights_sum"
+	// ErrThresholdOrder means a threshold range's min is greater than its max.
+	ErrThresholdOrder ValidationCode = "threshold_order"
+	// ErrThresholdOverlap means two threshold ranges (Red/Yellow/Green) overlap.
+	ErrThresholdOverlap ValidationCode = "threshold_overlap"
+	// ErrThresholdCoverage means the threshold ranges don't span the full 0-100 scale.
+	ErrThresholdCoverage ValidationCode = "threshold_coverage"
+	// ErrThresholdRangeSyntax means a ThresholdRange.Range spec failed to
+	// parse as a Nagios-style range.
+	ErrThresholdRangeSyntax ValidationCode = "threshold_range_syntax"
+	// ErrThresholdHysteresis means a Hysteresis block's enter/exit margins
+	// are inconsistent (negative, or an exit cutoff above its enter cutoff).
+	ErrThresholdHysteresis ValidationCode = "threshold_hysteresis"
+	// ErrMetricWeightsSum means a category's explicitly-weighted KPIs or
+	// KRIs don't add up to 100%.
+	ErrMetricWeightsSum ValidationCode = "metric_weights_sum"
+	// ErrMetricReferences means a data point's Reference doesn't resolve
+	// to any KPI or KRI defined in metrics.yaml.
+	ErrMetricReferences ValidationCode = "metric_references"
+	// ErrMetricCoverage means a KPI or KRI defined in metrics.yaml has no
+	// data points in the loaded metrics data.
+	ErrMetricCoverage ValidationCode = "metric_coverage"
+	// ErrDataTimestamps means a data point's timestamp is later than now.
+	ErrDataTimestamps ValidationCode = "data_timestamps"
+	// ErrScoringMethod means a --scoring-method value isn't one of
+	// SupportedScoringMethods.
+	ErrScoringMethod ValidationCode = "scoring_method"
+	// ErrTrendRuleConsistency means a Global.TrendRules entry sets
+	// contradictory or out-of-range fields.
+	ErrTrendRuleConsistency ValidationCode = "trend_rule_consistency"
+	// ErrReferenceSchema means a data point's Reference fails the
+	// configured (or default) ReferenceSchema, e.g. a bad character, an
+	// unknown type segment, or the wrong number of dot-separated parts.
+	ErrReferenceSchema ValidationCode = "reference_schema"
+)
+
+// ExitCode returns the process exit code a CLI should use when a
+// validation check fails with this code, so scripts and CI pipelines can
+// distinguish failure reasons without parsing text output.
+func (c ValidationCode) ExitCode() int {
+	switch c {
+	case ErrConfigMissing:
+		return 2
+	case ErrWeightsSum:
+		return 3
+	case ErrThresholdOrder:
+		return 4
+	case ErrThresholdOverlap:
+		return 5
+	case ErrThresholdCoverage:
+		return 6
+	case ErrThresholdHysteresis:
+		return 7
+	case ErrMetricWeightsSum:
+		return 8
+	case ErrThresholdRangeSyntax:
+		return 9
+	case ErrMetricReferences:
+		return 10
+	case ErrMetricCoverage:
+		return 11
+	case ErrDataTimestamps:
+		return 12
+	case ErrScoringMethod:
+		return 13
+	case ErrTrendRuleConsistency:
+		return 14
+	case ErrReferenceSchema:
+		return 15
+	default:
+		return 1
+	}
+}
+
+// ValidationError reports a single failed ValidationCheck as an error,
+// so a validate command can both print it and exit with Code.ExitCode().
+type ValidationError struct {
+	Code    ValidationCode
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return e.Message
+}
+
+// ValidationCheck is one named pass/fail result produced by ValidateWeights
+// or ValidateThresholds, suitable for rendering as text or marshaling into
+// the `{"status":"fail","checks":[...]}` JSON shape emitted by the CLI's
+// validate commands.
+type ValidationCheck struct {
+	Name    string `json:"name"`
+	OK      bool   `json:"ok"`
+	Details string `json:"details,omitempty"`
+}
+
+// ValidateWeights checks that leversConfig.Weights.Categories has no
+// negative entries and sums to 100% within a small epsilon, returning a
+// single "weights_sum" ValidationCheck.
+func ValidateWeights(leversConfig *LeversConfig) ValidationCheck {
+	const epsilon = 0.0001
+
+	if len(leversConfig.Weights.Categories) == 0 {
+		return ValidationCheck{Name: "weights_sum", OK: false, Details: "no category weights defined"}
+	}
+
+	var total float64
+	var negativeErrors []string
+	for category, weight := range leversConfig.Weights.Categories {
+		total += weight
+		if weight < 0 {
+			negativeErrors = append(negativeErrors, fmt.Sprintf("%s: weight %.2f must not be negative", category, weight))
+		}
+	}
+
+	if len(negativeErrors) > 0 {
+		return checkFromErrors("weights_sum", negativeErrors)
+	}
+
+	if total < 1.0-epsilon || total > 1.0+epsilon {
+		return ValidationCheck{
+			Name:    "weights_sum",
+			OK:      false,
+			Details: fmt.Sprintf("category weights add up to %.0f%%, expected 100%%", total*100),
+		}
+	}
+
+	return ValidationCheck{Name: "weights_sum", OK: true}
+}
+
+// ValidateMetricWeights checks, for every category, that if any of its KPIs
+// carry an explicit Weight then all of its KPIs do and they sum to 100%
+// (within a small epsilon) - and the same independently for KRIs. A
+// category with no explicit weights on its KPIs (or KRIs) is left to equal
+// weighting and isn't checked. Returns one "metric_weights_sum"
+// ValidationCheck per category that fails either check.
+func ValidateMetricWeights(metricsConfig *MetricsConfig) []ValidationCheck {
+	const epsilon = 0.0001
+
+	var checks []ValidationCheck
+	for _, category := range metricsConfig.Categories {
+		if detail, bad := intraCategoryWeightError(category.ID, "KPI", len(category.KPIs), func(i int) *float64 { return category.KPIs[i].Weight }, epsilon); bad {
+			checks = append(checks, ValidationCheck{Name: "metric_weights_sum", OK: false, Details: detail})
+		}
+		if detail, bad := intraCategoryWeightError(category.ID, "KRI", len(category.KRIs), func(i int) *float64 { return category.KRIs[i].Weight }, epsilon); bad {
+			checks = append(checks, ValidationCheck{Name: "metric_weights_sum", OK: false, Details: detail})
+		}
+	}
+
+	if len(checks) == 0 {
+		checks = append(checks, ValidationCheck{Name: "metric_weights_sum", OK: true})
+	}
+
+	return checks
+}
+
+// intraCategoryWeightError implements the single-metric-kind half of
+// ValidateMetricWeights: it reports an error detail (and bad=true) when some
+// but not all of a category's n metrics of the given kind have an explicit
+// weight, or when their weights don't sum to 100%.
+func intraCategoryWeightError(categoryID, kind string, n int, weightAt func(int) *float64, epsilon float64) (string, bool) {
+	var explicit, total int
+	var sum float64
+	for i := 0; i < n; i++ {
+		if w := weightAt(i); w != nil {
+			explicit++
+			sum += *w
+		}
+		total++
+	}
+
+	if explicit == 0 {
+		return "", false
+	}
+	if explicit != total {
+		return fmt.Sprintf("category %q: %d of %d %ss have an explicit weight; set one on all or none", categoryID, explicit, total, kind), true
+	}
+	if sum < 1.0-epsilon || sum > 1.0+epsilon {
+		return fmt.Sprintf("category %q: %s weights add up to %.0f%%, expected 100%%", categoryID, kind, sum*100), true
+	}
+
+	return "", false
+}
+
+// boundsOverlap reports whether upper (the upper edge of one band) and
+// lower (the lower edge of the next band up) describe any score that
+// would satisfy both, so bands that merely touch at a shared boundary
+// aren't flagged as overlapping just because one side is inclusive. Two
+// bounds at the same Value only overlap if both edges are inclusive
+// (<=/>=); an exclusive edge (</>) on either side makes them adjacent
+// instead.
+func boundsOverlap(upper, lower *Bound) bool {
+	if upper.Value != lower.Value {
+		return upper.Value >= lower.Value
+	}
+	return upper.Op == "<=" && lower.Op == ">="
+}
+
+// ValidateThresholds checks every Thresholds set (global plus any
+// category-specific overrides) for internally-consistent bounds, non-overlap
+// between Red/Yellow/Green, and full 0-100 coverage, returning one
+// ValidationCheck per category of failure: "threshold_order",
+// "threshold_overlap", "threshold_coverage", and "threshold_range_syntax",
+// followed by ValidateTrendRules's "trend_rule_consistency" check.
+// Each band's bounds come from ThresholdRange.normalizedBounds, so the
+// legacy inclusive {min,max} form, the explicit Lower/Upper Bound fields,
+// and a Nagios-style Range spec are all checked the same way (Range's
+// numeric edges only; a malformed spec is instead reported by
+// threshold_range_syntax, and an inverted "@" spec's overlap/coverage
+// follows its plain edges); a band left
+// open-ended on one side (e.g. a Red band with only a ceiling) is simply
+// skipped for the checks that don't apply to that side. A Thresholds set's
+// effectiveDirection decides which end Green sits on: HigherIsBetter (the
+// default) expects Red low and Green high, while LowerIsBetter expects the
+// stack inverted, Green low and Red high, for metrics like incident count
+// where small values are good.
+func ValidateThresholds(leversConfig *LeversConfig) []ValidationCheck {
+	sets := map[string]Thresholds{"global": leversConfig.Global.Thresholds}
+	for category, thresholds := range leversConfig.Weights.CategoryThresholds {
+		sets[fmt.Sprintf("category:%s", category)] = thresholds
+	}
+
+	var orderErrors, overlapErrors, coverageErrors, rangeSyntaxErrors []string
+
+	for name, t := range sets {
+		for _, band := range []struct {
+			label string
+			spec  string
+		}{
+			{"Green", t.Green.Range},
+			{"Yellow", t.Yellow.Range},
+			{"Red", t.Red.Range},
+		} {
+			if band.spec == "" {
+				continue
+			}
+			if _, err := ParseNagiosRange(band.spec); err != nil {
+				rangeSyntaxErrors = append(rangeSyntaxErrors, fmt.Sprintf("%s: %s range %q: %v", name, band.label, band.spec, err))
+			}
+		}
+
+		greenLower, greenUpper := t.Green.normalizedBounds()
+		yellowLower, yellowUpper := t.Yellow.normalizedBounds()
+		redLower, redUpper := t.Red.normalizedBounds()
+
+		for _, band := range []struct {
+			label        string
+			lower, upper *Bound
+		}{
+			{"Green", greenLower, greenUpper},
+			{"Yellow", yellowLower, yellowUpper},
+			{"Red", redLower, redUpper},
+		} {
+			if band.lower != nil && band.upper != nil && band.lower.Value > band.upper.Value {
+				orderErrors = append(orderErrors, fmt.Sprintf("%s: %s lower bound (%g) must be <= upper bound (%g)", name, band.label, band.lower.Value, band.upper.Value))
+			}
+		}
+
+		if t.effectiveDirection() == LowerIsBetter {
+			if greenUpper != nil && yellowLower != nil && boundsOverlap(greenUpper, yellowLower) {
+				overlapErrors = append(overlapErrors, fmt.Sprintf("%s: Green upper bound (%g) must be < Yellow lower bound (%g)", name, greenUpper.Value, yellowLower.Value))
+			}
+			if yellowUpper != nil && redLower != nil && boundsOverlap(yellowUpper, redLower) {
+				overlapErrors = append(overlapErrors, fmt.Sprintf("%s: Yellow upper bound (%g) must be < Red lower bound (%g)", name, yellowUpper.Value, redLower.Value))
+			}
+
+			if greenLower != nil && greenLower.Value > 0 {
+				coverageErrors = append(coverageErrors, fmt.Sprintf("%s: Green lower bound (%g) should reach down to 0", name, greenLower.Value))
+			}
+			if redUpper != nil && redUpper.Value < 100 {
+				coverageErrors = append(coverageErrors, fmt.Sprintf("%s: Red upper bound (%g) should reach up to 100", name, redUpper.Value))
+			}
+			continue
+		}
+
+		if yellowUpper != nil && greenLower != nil && boundsOverlap(yellowUpper, greenLower) {
+			overlapErrors = append(overlapErrors, fmt.Sprintf("%s: Yellow upper bound (%g) must be < Green lower bound (%g)", name, yellowUpper.Value, greenLower.Value))
+		}
+		if redUpper != nil && yellowLower != nil && boundsOverlap(redUpper, yellowLower) {
+			overlapErrors = append(overlapErrors, fmt.Sprintf("%s: Red upper bound (%g) must be < Yellow lower bound (%g)", name, redUpper.Value, yellowLower.Value))
+		}
+
+		if redLower != nil && redLower.Value > 0 {
+			coverageErrors = append(coverageErrors, fmt.Sprintf("%s: Red lower bound (%g) should reach down to 0", name, redLower.Value))
+		}
+		if greenUpper != nil && greenUpper.Value < 100 {
+			coverageErrors = append(coverageErrors, fmt.Sprintf("%s: Green upper bound (%g) should reach up to 100", name, greenUpper.Value))
+		}
+	}
+
+	return []ValidationCheck{
+		checkFromErrors("threshold_order", orderErrors),
+		checkFromErrors("threshold_overlap", overlapErrors),
+		checkFromErrors("threshold_coverage", coverageErrors),
+		checkFromErrors(string(ErrThresholdRangeSyntax), rangeSyntaxErrors),
+		ValidateTrendRules(leversConfig),
+	}
+}
+
+// ValidateTrendRules checks that every Global.TrendRules entry is
+// internally consistent: trend_up and trend_down aren't both set (a rule
+// can't require the metric to be simultaneously rising and falling),
+// threshold_min isn't greater than threshold_max when both are set, window
+// is at least 2 (a trend needs at least two samples to compare), and status
+// is one of Green/Yellow/Red.
+func ValidateTrendRules(leversConfig *LeversConfig) ValidationCheck {
+	var errs []string
+	for reference, rule := range leversConfig.Global.TrendRules {
+		if rule.TrendUp && rule.TrendDown {
+			errs = append(errs, fmt.Sprintf("%s: trend_up and trend_down cannot both be set", reference))
+		}
+		if rule.ThresholdMin != nil && rule.ThresholdMax != nil && *rule.ThresholdMin > *rule.ThresholdMax {
+			errs = append(errs, fmt.Sprintf("%s: threshold_min (%g) must be <= threshold_max (%g)", reference, *rule.ThresholdMin, *rule.ThresholdMax))
+		}
+		if rule.Window < 2 {
+			errs = append(errs, fmt.Sprintf("%s: window (%d) must be at least 2", reference, rule.Window))
+		}
+		switch rule.Status {
+		case Green, Yellow, Red:
+		default:
+			errs = append(errs, fmt.Sprintf("%s: status %q must be one of green, yellow, red", reference, rule.Status))
+		}
+	}
+	return checkFromErrors(string(ErrTrendRuleConsistency), errs)
+}
+
+// ValidateHysteresis checks every Thresholds set (global plus any
+// category-specific overrides) that declares a Hysteresis block: its
+// margins must be non-negative, exit_min (when both are set) must not
+// exceed enter_min, and the resulting enter cutoff at each boundary must not
+// fall below its exit cutoff (both comparisons are reversed for a
+// LowerIsBetter set), returning a single "threshold_hysteresis"
+// ValidationCheck. Thresholds without a Hysteresis block are skipped.
+func ValidateHysteresis(leversConfig *LeversConfig) ValidationCheck {
+	sets := map[string]Thresholds{"global": leversConfig.Global.Thresholds}
+	for category, thresholds := range leversConfig.Weights.CategoryThresholds {
+		sets[fmt.Sprintf("category:%s", category)] = thresholds
+	}
+
+	var errs []string
+	for name, t := range sets {
+		h := t.Hysteresis
+		if h == nil {
+			continue
+		}
+
+		if h.EnterMargin != nil && *h.EnterMargin < 0 {
+			errs = append(errs, fmt.Sprintf("%s: enter_margin (%g) must be >= 0", name, *h.EnterMargin))
+		}
+		if h.ExitMargin != nil && *h.ExitMargin < 0 {
+			errs = append(errs, fmt.Sprintf("%s: exit_margin (%g) must be >= 0", name, *h.ExitMargin))
+		}
+
+		direction := t.effectiveDirection()
+		lowerIsBetter := direction == LowerIsBetter
+
+		if h.EnterMin != nil && h.ExitMin != nil {
+			if lowerIsBetter && *h.ExitMin < *h.EnterMin {
+				errs = append(errs, fmt.Sprintf("%s: exit_min (%g) must be >= enter_min (%g)", name, *h.ExitMin, *h.EnterMin))
+			} else if !lowerIsBetter && *h.ExitMin > *h.EnterMin {
+				errs = append(errs, fmt.Sprintf("%s: exit_min (%g) must be <= enter_min (%g)", name, *h.ExitMin, *h.EnterMin))
+			}
+		}
+
+		for _, boundary := range []struct {
+			label string
+			at    int
+		}{{"Red/Yellow", 0}, {"Yellow/Green", 1}} {
+			raw := boundaryFor(t, boundary.at, direction)
+			enter, exit := enterCutoff(raw, h, direction), exitCutoff(raw, h, direction)
+			if lowerIsBetter {
+				if enter > exit {
+					errs = append(errs, fmt.Sprintf("%s: %s enter cutoff (%g) must be <= exit cutoff (%g)", name, boundary.label, enter, exit))
+				}
+				continue
+			}
+			if enter < exit {
+				errs = append(errs, fmt.Sprintf("%s: %s enter cutoff (%g) must be >= exit cutoff (%g)", name, boundary.label, enter, exit))
+			}
+		}
+	}
+
+	return checkFromErrors("threshold_hysteresis", errs)
+}
+
+// ValidationSeverity classifies how seriously a CI pipeline should treat a
+// ValidationIssue.
+type ValidationSeverity string
+
+const (
+	SeverityError   ValidationSeverity = "error"
+	SeverityWarning ValidationSeverity = "warning"
+	SeverityInfo    ValidationSeverity = "info"
+)
+
+// ValidationIssue is a single ValidationCheck failure rendered for
+// machine consumption: a stable Code, the config Path it came from (e.g.
+// "global" or "category:app_sec", when the check tracks one), a
+// human-readable Message, and a Severity.
+type ValidationIssue struct {
+	Code     ValidationCode     `json:"code"`
+	Path     string             `json:"path,omitempty"`
+	Message  string             `json:"message"`
+	Severity ValidationSeverity `json:"severity"`
+}
+
+// ValidationReport is the result of ValidateLevers: every ValidationCheck
+// that ran, plus its failures flattened into ValidationIssues.
+type ValidationReport struct {
+	Checks []ValidationCheck `json:"checks"`
+	Issues []ValidationIssue `json:"issues"`
+}
+
+// OK reports whether every check in the report passed.
+func (r ValidationReport) OK() bool {
+	for _, check := range r.Checks {
+		if !check.OK {
+			return false
+		}
+	}
+	return true
+}
+
+// ExitCode returns the process exit code a CLI should use for the report:
+// 0 if every check passed, otherwise the ExitCode of the first failing
+// check's ValidationCode. This preserves the existing per-check exit code
+// contract (see ValidationCode.ExitCode) rather than collapsing failures
+// into a coarser class, since CI pipelines already gate on the specific
+// codes ValidateWeights/ValidateThresholds/ValidateHysteresis produce.
+func (r ValidationReport) ExitCode() int {
+	for _, check := range r.Checks {
+		if !check.OK {
+			return ValidationCode(check.Name).ExitCode()
+		}
+	}
+	return 0
+}
+
+// ValidateLevers runs every lever-configuration check — weights,
+// thresholds, hysteresis, and any user-defined CustomRules — against
+// leversConfig and returns them as a single ValidationReport, the basis
+// for a validate command's --format text/json/sarif output.
+func ValidateLevers(leversConfig *LeversConfig) ValidationReport {
+	checks := append([]ValidationCheck{ValidateWeights(leversConfig)}, ValidateThresholds(leversConfig)...)
+	checks = append(checks, ValidateHysteresis(leversConfig))
+	checks = append(checks, ValidateCustomRules(leversConfig)...)
+
+	severityByRuleID := make(map[string]ValidationSeverity, len(leversConfig.Rules))
+	for _, rule := range leversConfig.Rules {
+		if rule.Severity == string(SeverityWarning) {
+			severityByRuleID[rule.ID] = SeverityWarning
+		} else {
+			severityByRuleID[rule.ID] = SeverityError
+		}
+	}
+
+	var issues []ValidationIssue
+	for _, check := range checks {
+		if check.OK {
+			continue
+		}
+		severity, isCustomRule := severityByRuleID[check.Name]
+		if !isCustomRule {
+			severity = SeverityError
+		}
+		issues = append(issues, ValidationIssue{
+			Code:     ValidationCode(check.Name),
+			Message:  check.Details,
+			Severity: severity,
+		})
+	}
+
+	return ValidationReport{Checks: checks, Issues: issues}
+}
+
+// sarifLog is the minimal subset of the SARIF 2.1.0 schema
+// (https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html) needed
+// to report ValidationIssues as a single run with one result per issue, so
+// pulse's validation output can be consumed by tools that already ingest
+// SARIF (e.g. GitHub code scanning).
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name  string      `json:"name"`
+	Rules []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID string `json:"id"`
+}
+
+type sarifResult struct {
+	RuleID  string             `json:"ruleId"`
+	Level   string             `json:"level"`
+	Message sarifResultMessage `json:"message"`
+}
+
+type sarifResultMessage struct {
+	Text string `json:"text"`
+}
+
+// sarifLevel maps a ValidationSeverity to the SARIF result.level vocabulary
+// ("error", "warning", "note").
+func sarifLevel(severity ValidationSeverity) string {
+	if severity == SeverityWarning {
+		return "warning"
+	}
+	return "error"
+}
+
+// FormatValidationSARIF renders report as a SARIF 2.1.0 log, one result per
+// ValidationIssue, so CI tooling that already ingests SARIF (e.g. GitHub
+// code scanning) can consume pulse's lever validation output directly.
+func FormatValidationSARIF(report ValidationReport) ([]byte, error) {
+	rulesSeen := make(map[string]bool)
+	var rules []sarifRule
+	var results []sarifResult
+
+	for _, issue := range report.Issues {
+		ruleID := string(issue.Code)
+		if !rulesSeen[ruleID] {
+			rulesSeen[ruleID] = true
+			rules = append(rules, sarifRule{ID: ruleID})
+		}
+		results = append(results, sarifResult{
+			RuleID:  ruleID,
+			Level:   sarifLevel(issue.Severity),
+			Message: sarifResultMessage{Text: issue.Message},
+		})
+	}
+
+	log := sarifLog{
+		Schema:  "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []sarifRun{
+			{
+				Tool:    sarifTool{Driver: sarifDriver{Name: "pulse", Rules: rules}},
+				Results: results,
+			},
+		},
+	}
+
+	return json.MarshalIndent(log, "", "  ")
+}
+
+// junitTestSuites is the minimal subset of the JUnit XML schema needed to
+// report ValidationChecks as a single suite with one testcase per check, so
+// a CI pipeline that already renders JUnit results (GitLab, Jenkins,
+// GitHub Actions via a reporting action) can surface pulse's validation
+// output without a SARIF-aware plugin.
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// FormatValidationJUnit renders report as a JUnit XML document, one
+// testcase per ValidationCheck (not just its failures, so a passing suite
+// still lists every check that ran), with an XML declaration prepended the
+// way CI tooling expects a standalone JUnit report to start.
+func FormatValidationJUnit(report ValidationReport) ([]byte, error) {
+	suite := junitTestSuite{Name: "pulse.validate", Tests: len(report.Checks)}
+
+	for _, check := range report.Checks {
+		testCase := junitTestCase{Name: check.Name, ClassName: "pulse.validate"}
+		if !check.OK {
+			suite.Failures++
+			testCase.Failure = &junitFailure{Message: check.Details, Text: check.Details}
+		}
+		suite.Cases = append(suite.Cases, testCase)
+	}
+
+	body, err := xml.MarshalIndent(junitTestSuites{Suites: []junitTestSuite{suite}}, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode JUnit validation result: %w", err)
+	}
+
+	return append([]byte(xml.Header), body...), nil
+}
+
+// checkFromErrors builds a ValidationCheck that's OK when errs is empty,
+// joining errs into Details otherwise.
+func checkFromErrors(name string, errs []string) ValidationCheck {
+	if len(errs) == 0 {
+		return ValidationCheck{Name: name, OK: true}
+	}
+
+	details := errs[0]
+	for _, e := range errs[1:] {
+		details += "; " + e
+	}
+	return ValidationCheck{Name: name, OK: false, Details: details}
+}
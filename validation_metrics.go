@@ -0,0 +1,162 @@
+package pulse
+
+import (
+	"fmt"
+	"time"
+)
+
+// SupportedScoringMethods lists every ScoringMethod name pulse understands,
+// for validating a --scoring-method flag or config value before it's used.
+var SupportedScoringMethods = []ScoringMethod{MedianScoring, AverageScoring, PercentileScoring, WeightedScoring}
+
+// ValidateScoringMethod checks that name is one of SupportedScoringMethods,
+// returning a single "scoring_method" ValidationCheck.
+func ValidateScoringMethod(name string) ValidationCheck {
+	for _, method := range SupportedScoringMethods {
+		if ScoringMethod(name) == method {
+			return ValidationCheck{Name: string(ErrScoringMethod), OK: true}
+		}
+	}
+	return ValidationCheck{
+		Name:    string(ErrScoringMethod),
+		OK:      false,
+		Details: fmt.Sprintf("unsupported scoring method %q (expected one of median, average, percentile, weighted)", name),
+	}
+}
+
+// ValidateMetricReferences cross-checks metricsConfig against metricsData in
+// both directions: every data point's Reference must resolve to a KPI or
+// KRI defined in metricsConfig ("metric_references"), and every KPI/KRI
+// metricsConfig defines should have at least one data point in metricsData
+// ("metric_coverage"). References use the "categoryID.KPI|KRI.metricID"
+// form GetMetricType and MetricsProcessor.GetMetricDefinition expect.
+func ValidateMetricReferences(metricsConfig *MetricsConfig, metricsData *MetricsData) []ValidationCheck {
+	defined := make(map[string]bool)
+	for _, category := range metricsConfig.Categories {
+		for _, kpi := range category.KPIs {
+			defined[fmt.Sprintf("%s.KPI.%s", category.ID, kpi.ID)] = true
+		}
+		for _, kri := range category.KRIs {
+			defined[fmt.Sprintf("%s.KRI.%s", category.ID, kri.ID)] = true
+		}
+	}
+
+	observed := make(map[string]bool, len(metricsData.Metrics))
+	var unresolvedErrors []string
+	for _, metric := range metricsData.Metrics {
+		observed[metric.Reference] = true
+		if !defined[metric.Reference] {
+			unresolvedErrors = append(unresolvedErrors, fmt.Sprintf("%s: no matching KPI/KRI definition", metric.Reference))
+		}
+	}
+
+	var missingErrors []string
+	for reference := range defined {
+		if !observed[reference] {
+			missingErrors = append(missingErrors, fmt.Sprintf("%s: no data points", reference))
+		}
+	}
+
+	return []ValidationCheck{
+		checkFromErrors(string(ErrMetricReferences), unresolvedErrors),
+		checkFromErrors(string(ErrMetricCoverage), missingErrors),
+	}
+}
+
+// ValidateDataTimestamps checks that none of metricsData's observations are
+// timestamped later than now, returning a single "data_timestamps"
+// ValidationCheck. A Metric with a zero Timestamp (omitted, or failed to
+// parse at load time) is skipped, the same way MetricsProcessor treats a
+// zero Timestamp as "no observation time" rather than a specific point.
+func ValidateDataTimestamps(metricsData *MetricsData, now time.Time) ValidationCheck {
+	var errs []string
+	for _, metric := range metricsData.Metrics {
+		if metric.Timestamp.IsZero() {
+			continue
+		}
+		if metric.Timestamp.After(now) {
+			errs = append(errs, fmt.Sprintf("%s: timestamp %s is in the future", metric.Reference, metric.Timestamp.Format(time.RFC3339)))
+		}
+	}
+	return checkFromErrors(string(ErrDataTimestamps), errs)
+}
+
+// ValidateMetricReferenceSchema checks every data point's Reference against
+// the ReferenceSchema configured on leversConfig (or the built-in KPI/KRI
+// scheme if none is set), returning a single "reference_schema"
+// ValidationCheck that lists every violation found.
+func ValidateMetricReferenceSchema(metricsData *MetricsData, leversConfig *LeversConfig) (ValidationCheck, error) {
+	validator, err := NewReferenceValidator(leversConfig.ReferenceSchema)
+	if err != nil {
+		return ValidationCheck{}, err
+	}
+
+	var errs []string
+	for _, metric := range metricsData.Metrics {
+		for _, refErr := range validator.Validate(metric.Reference) {
+			errs = append(errs, fmt.Sprintf("%s: %s", metric.Reference, refErr.Message))
+		}
+	}
+	return checkFromErrors(string(ErrReferenceSchema), errs), nil
+}
+
+// Validator runs the full pulse validation suite against a loaded
+// configuration: everything ValidateLevers covers (weights, thresholds,
+// hysteresis, custom rules), plus metrics-configuration checks ValidateLevers
+// doesn't reach (reference resolution, data coverage, timestamp sanity) and
+// the scoring method the caller intends to run with. It's the basis for a
+// broader "validate-config" CLI check than validate-thresholds/validate-weights
+// alone, bundled the way a monitoring plugin bundles several named checks
+// behind one aggregated exit code.
+type Validator struct {
+	LeversConfig  *LeversConfig
+	MetricsConfig *MetricsConfig
+	MetricsData   *MetricsData
+	ScoringMethod string
+	Now           time.Time
+}
+
+// NewValidator creates a Validator for the given configs. now is the
+// reference time ValidateDataTimestamps checks future timestamps against;
+// pass time.Now() in production and a fixed time in tests.
+func NewValidator(leversConfig *LeversConfig, metricsConfig *MetricsConfig, metricsData *MetricsData, scoringMethod string, now time.Time) *Validator {
+	return &Validator{
+		LeversConfig:  leversConfig,
+		MetricsConfig: metricsConfig,
+		MetricsData:   metricsData,
+		ScoringMethod: scoringMethod,
+		Now:           now,
+	}
+}
+
+// Validate runs every check and returns one aggregated ValidationReport. It
+// starts from ValidateLevers's report (which already assigns CustomRule
+// severities) and appends the metrics-configuration checks at SeverityError,
+// consistent with how ValidateLevers treats its own built-in checks.
+func (v *Validator) Validate() ValidationReport {
+	report := ValidateLevers(v.LeversConfig)
+
+	checks := ValidateMetricReferences(v.MetricsConfig, v.MetricsData)
+	checks = append(checks, ValidateMetricWeights(v.MetricsConfig)...)
+	checks = append(checks, ValidateDataTimestamps(v.MetricsData, v.Now))
+	checks = append(checks, ValidateScoringMethod(v.ScoringMethod))
+	if schemaCheck, err := ValidateMetricReferenceSchema(v.MetricsData, v.LeversConfig); err == nil {
+		checks = append(checks, schemaCheck)
+	} else {
+		checks = append(checks, ValidationCheck{Name: string(ErrReferenceSchema), OK: false, Details: err.Error()})
+	}
+
+	for _, check := range checks {
+		report.Checks = append(report.Checks, check)
+		if check.OK {
+			continue
+		}
+		report.Issues = append(report.Issues, ValidationIssue{
+			Code:     ValidationCode(check.Name),
+			Message:  check.Details,
+			Severity: SeverityError,
+		})
+	}
+
+	return report
+}
@@ -0,0 +1,135 @@
+package pulse
+
+import (
+	"testing"
+	"time"
+)
+
+func TestValidateScoringMethodAcceptsSupportedNames(t *testing.T) {
+	for _, name := range []string{"median", "average", "percentile", "weighted"} {
+		if check := ValidateScoringMethod(name); !check.OK {
+			t.Fatalf("expected %q to be a supported scoring method, got %+v", name, check)
+		}
+	}
+}
+
+func TestValidateScoringMethodRejectsUnknownName(t *testing.T) {
+	if check := ValidateScoringMethod("bogus"); check.OK {
+		t.Fatal("expected an unsupported scoring method name to fail")
+	}
+}
+
+func validateReferencesFixture() (*MetricsConfig, *MetricsData) {
+	metricsConfig := &MetricsConfig{
+		Categories: []Category{
+			{ID: "app_sec", KPIs: []KPI{{ID: "coverage"}}, KRIs: []KRI{{ID: "incidents"}}},
+		},
+	}
+	metricsData := &MetricsData{
+		Metrics: []Metric{
+			{Reference: "app_sec.KPI.coverage", Value: 90},
+			{Reference: "app_sec.KRI.incidents", Value: 2},
+		},
+	}
+	return metricsConfig, metricsData
+}
+
+func TestValidateMetricReferencesPassesWhenFullyResolvedAndCovered(t *testing.T) {
+	metricsConfig, metricsData := validateReferencesFixture()
+
+	for _, check := range ValidateMetricReferences(metricsConfig, metricsData) {
+		if !check.OK {
+			t.Fatalf("expected %s to pass, got %+v", check.Name, check)
+		}
+	}
+}
+
+func TestValidateMetricReferencesCatchesUnresolvedReference(t *testing.T) {
+	metricsConfig, metricsData := validateReferencesFixture()
+	metricsData.Metrics = append(metricsData.Metrics, Metric{Reference: "app_sec.KPI.unknown", Value: 1})
+
+	checks := ValidateMetricReferences(metricsConfig, metricsData)
+	if checks[0].Name != string(ErrMetricReferences) || checks[0].OK {
+		t.Fatalf("expected metric_references to fail for an unresolved reference, got %+v", checks[0])
+	}
+}
+
+func TestValidateMetricReferencesCatchesMissingCoverage(t *testing.T) {
+	metricsConfig, metricsData := validateReferencesFixture()
+	metricsData.Metrics = metricsData.Metrics[:1] // drop the KRI's only data point
+
+	checks := ValidateMetricReferences(metricsConfig, metricsData)
+	if checks[1].Name != string(ErrMetricCoverage) || checks[1].OK {
+		t.Fatalf("expected metric_coverage to fail for an uncollected KRI, got %+v", checks[1])
+	}
+}
+
+func TestValidateDataTimestampsCatchesFutureTimestamp(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	metricsData := &MetricsData{
+		Metrics: []Metric{
+			{Reference: "app_sec.KPI.coverage", Timestamp: now.Add(24 * time.Hour)},
+		},
+	}
+
+	check := ValidateDataTimestamps(metricsData, now)
+	if check.OK {
+		t.Fatal("expected a future timestamp to fail")
+	}
+}
+
+func TestValidateDataTimestampsPassesForPastAndZeroTimestamps(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	metricsData := &MetricsData{
+		Metrics: []Metric{
+			{Reference: "app_sec.KPI.coverage", Timestamp: now.Add(-24 * time.Hour)},
+			{Reference: "app_sec.KRI.incidents"}, // zero Timestamp, skipped
+		},
+	}
+
+	check := ValidateDataTimestamps(metricsData, now)
+	if !check.OK {
+		t.Fatalf("expected past and zero timestamps to pass, got %+v", check)
+	}
+}
+
+func TestValidatorBundlesAllChecks(t *testing.T) {
+	metricsConfig, metricsData := validateReferencesFixture()
+	leversConfig := &LeversConfig{
+		Weights: Weights{Categories: CategoryWeights{"app_sec": 1.0}},
+		Global: Global{Thresholds: Thresholds{
+			Green:  ThresholdRange{Min: 80, Max: 100},
+			Yellow: ThresholdRange{Min: 50, Max: 79},
+			Red:    ThresholdRange{Min: 0, Max: 49},
+		}},
+	}
+
+	validator := NewValidator(leversConfig, metricsConfig, metricsData, "median", time.Now())
+	report := validator.Validate()
+
+	if !report.OK() {
+		t.Fatalf("expected a clean configuration to pass every check, got: %+v", report.Issues)
+	}
+
+	names := make(map[string]bool, len(report.Checks))
+	for _, check := range report.Checks {
+		names[check.Name] = true
+	}
+	for _, want := range []string{"weights_sum", "threshold_order", string(ErrMetricReferences), string(ErrMetricCoverage), string(ErrDataTimestamps), string(ErrScoringMethod)} {
+		if !names[want] {
+			t.Fatalf("expected the bundled report to include a %q check, got %+v", want, names)
+		}
+	}
+}
+
+func TestValidatorCatchesBadScoringMethod(t *testing.T) {
+	metricsConfig, metricsData := validateReferencesFixture()
+	leversConfig := &LeversConfig{Weights: Weights{Categories: CategoryWeights{"app_sec": 1.0}}}
+
+	validator := NewValidator(leversConfig, metricsConfig, metricsData, "bogus", time.Now())
+	report := validator.Validate()
+
+	if report.OK() {
+		t.Fatal("expected an unsupported scoring method to fail the bundled report")
+	}
+}
@@ -0,0 +1,327 @@
+package pulse
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestValidateWeightsPasses(t *testing.T) {
+	leversConfig := &LeversConfig{Weights: Weights{Categories: CategoryWeights{"app_sec": 0.6, "data_sec": 0.4}}}
+
+	check := ValidateWeights(leversConfig)
+	if !check.OK {
+		t.Fatalf("expected weights summing to 100%% to pass, got %+v", check)
+	}
+}
+
+func TestValidateWeightsFailsWhenNotOneHundredPercent(t *testing.T) {
+	leversConfig := &LeversConfig{Weights: Weights{Categories: CategoryWeights{"app_sec": 0.5, "data_sec": 0.2}}}
+
+	check := ValidateWeights(leversConfig)
+	if check.OK {
+		t.Fatal("expected weights summing to 70% to fail")
+	}
+	if check.Name != string(ErrWeightsSum) {
+		t.Fatalf("expected check name %q, got %q", ErrWeightsSum, check.Name)
+	}
+}
+
+func TestValidateWeightsFailsOnNegativeWeight(t *testing.T) {
+	leversConfig := &LeversConfig{Weights: Weights{Categories: CategoryWeights{"app_sec": 1.2, "data_sec": -0.2}}}
+
+	check := ValidateWeights(leversConfig)
+	if check.OK {
+		t.Fatal("expected a negative category weight to fail")
+	}
+}
+
+func TestValidateMetricWeightsPassesWithEqualWeighting(t *testing.T) {
+	metricsConfig := &MetricsConfig{
+		Categories: []Category{
+			{ID: "app_sec", KPIs: []KPI{{ID: "a"}, {ID: "b"}}},
+		},
+	}
+
+	checks := ValidateMetricWeights(metricsConfig)
+	for _, check := range checks {
+		if !check.OK {
+			t.Fatalf("expected unweighted KPIs to pass, got %+v", check)
+		}
+	}
+}
+
+func TestValidateMetricWeightsFailsWhenPartiallyWeighted(t *testing.T) {
+	metricsConfig := &MetricsConfig{
+		Categories: []Category{
+			{ID: "app_sec", KPIs: []KPI{{ID: "a", Weight: FloatPtr(0.6)}, {ID: "b"}}},
+		},
+	}
+
+	checks := ValidateMetricWeights(metricsConfig)
+	var sawFailure bool
+	for _, check := range checks {
+		if !check.OK {
+			sawFailure = true
+			if check.Name != string(ErrMetricWeightsSum) {
+				t.Fatalf("expected check name %q, got %q", ErrMetricWeightsSum, check.Name)
+			}
+		}
+	}
+	if !sawFailure {
+		t.Fatal("expected a failure when only some KPIs in a category have a weight")
+	}
+}
+
+func TestValidateMetricWeightsFailsWhenNotOneHundredPercent(t *testing.T) {
+	metricsConfig := &MetricsConfig{
+		Categories: []Category{
+			{ID: "app_sec", KRIs: []KRI{{ID: "a", Weight: FloatPtr(0.5)}, {ID: "b", Weight: FloatPtr(0.2)}}},
+		},
+	}
+
+	checks := ValidateMetricWeights(metricsConfig)
+	var sawFailure bool
+	for _, check := range checks {
+		if !check.OK {
+			sawFailure = true
+		}
+	}
+	if !sawFailure {
+		t.Fatal("expected KRI weights summing to 70% to fail")
+	}
+}
+
+func TestValidateTrendRulesPassesForConsistentRule(t *testing.T) {
+	min := 80.0
+	leversConfig := &LeversConfig{Global: Global{TrendRules: map[string]TrendRule{
+		"app_sec.KRI.open_ports": {TrendUp: true, ThresholdMin: &min, Window: 3, Status: Red},
+	}}}
+
+	check := ValidateTrendRules(leversConfig)
+	if !check.OK {
+		t.Fatalf("expected a consistent trend rule to pass, got %+v", check)
+	}
+}
+
+func TestValidateTrendRulesCatchesContradictoryDirection(t *testing.T) {
+	leversConfig := &LeversConfig{Global: Global{TrendRules: map[string]TrendRule{
+		"app_sec.KRI.open_ports": {TrendUp: true, TrendDown: true, Window: 3, Status: Red},
+	}}}
+
+	check := ValidateTrendRules(leversConfig)
+	if check.OK {
+		t.Fatal("expected a rule setting both trend_up and trend_down to fail")
+	}
+}
+
+func TestValidateTrendRulesCatchesInvertedThresholds(t *testing.T) {
+	min, max := 80.0, 50.0
+	leversConfig := &LeversConfig{Global: Global{TrendRules: map[string]TrendRule{
+		"app_sec.KRI.open_ports": {TrendUp: true, ThresholdMin: &min, ThresholdMax: &max, Window: 3, Status: Red},
+	}}}
+
+	check := ValidateTrendRules(leversConfig)
+	if check.OK {
+		t.Fatal("expected threshold_min > threshold_max to fail")
+	}
+}
+
+func TestValidateTrendRulesCatchesShortWindowAndBadStatus(t *testing.T) {
+	leversConfig := &LeversConfig{Global: Global{TrendRules: map[string]TrendRule{
+		"app_sec.KRI.open_ports": {TrendUp: true, Window: 1, Status: "critical"},
+	}}}
+
+	check := ValidateTrendRules(leversConfig)
+	if check.OK {
+		t.Fatal("expected a sub-2 window and an invalid status to fail")
+	}
+}
+
+func TestValidateThresholdsDetectsOverlapAndCoverage(t *testing.T) {
+	leversConfig := &LeversConfig{Global: Global{
+		Thresholds: Thresholds{
+			Green:  ThresholdRange{Min: 70, Max: 90},
+			Yellow: ThresholdRange{Min: 50, Max: 79},
+			Red:    ThresholdRange{Min: 1, Max: 49},
+		},
+	}}
+
+	checks := ValidateThresholds(leversConfig)
+
+	byName := make(map[string]ValidationCheck, len(checks))
+	for _, check := range checks {
+		byName[check.Name] = check
+	}
+
+	if byName["threshold_overlap"].OK {
+		t.Fatal("expected overlapping Yellow/Green ranges to fail threshold_overlap")
+	}
+	if byName["threshold_coverage"].OK {
+		t.Fatal("expected Red.Min > 0 and Green.Max < 100 to fail threshold_coverage")
+	}
+	if !byName["threshold_order"].OK {
+		t.Fatalf("expected min<=max ordering to pass, got %+v", byName["threshold_order"])
+	}
+}
+
+func TestValidateThresholdsAcceptsOpenEndedBounds(t *testing.T) {
+	// A Red band with only a ceiling (no explicit floor) should pass
+	// order/overlap/coverage checks: its exclusive ceiling only touches
+	// Yellow's inclusive floor, and with no lower bound it reaches down to 0
+	// as required.
+	leversConfig := &LeversConfig{Global: Global{
+		Thresholds: Thresholds{
+			Green:  ThresholdRange{Lower: &Bound{Op: ">=", Value: 80}, Upper: &Bound{Op: "<=", Value: 100}},
+			Yellow: ThresholdRange{Lower: &Bound{Op: ">=", Value: 50}, Upper: &Bound{Op: "<", Value: 80}},
+			Red:    ThresholdRange{Upper: &Bound{Op: "<", Value: 50}},
+		},
+	}}
+
+	checks := ValidateThresholds(leversConfig)
+
+	for _, check := range checks {
+		if !check.OK {
+			t.Errorf("expected %s to pass for a Red band with no floor, got %+v", check.Name, check)
+		}
+	}
+}
+
+func TestThresholdRangeContainsHonorsExclusiveBounds(t *testing.T) {
+	yellow := ThresholdRange{Lower: &Bound{Op: ">=", Value: 50}, Upper: &Bound{Op: "<", Value: 80}}
+
+	if !yellow.Contains(50) {
+		t.Error("expected 50 to satisfy an inclusive lower bound")
+	}
+	if yellow.Contains(80) {
+		t.Error("expected 80 to fail an exclusive upper bound")
+	}
+
+	red := ThresholdRange{Upper: &Bound{Op: "<", Value: 50}}
+	if !red.Contains(0) {
+		t.Error("expected an open-ended lower bound to accept any low score")
+	}
+	if red.Contains(50) {
+		t.Error("expected 50 to fail Red's exclusive upper bound")
+	}
+}
+
+func TestValidationCodeExitCode(t *testing.T) {
+	if ErrConfigMissing.ExitCode() == 0 {
+		t.Fatal("expected a non-zero exit code for config_missing")
+	}
+	if ErrWeightsSum.ExitCode() == ErrThresholdOrder.ExitCode() {
+		t.Fatal("expected distinct exit codes per validation code")
+	}
+	if ValidationCode("bogus").ExitCode() == 0 {
+		t.Fatal("expected a non-zero fallback exit code for an unrecognized code")
+	}
+}
+
+func TestValidateLeversAggregatesAllChecks(t *testing.T) {
+	leversConfig := &LeversConfig{
+		Weights: Weights{Categories: CategoryWeights{"app_sec": 0.6, "data_sec": 0.4}},
+		Global: Global{Thresholds: Thresholds{
+			Green:  ThresholdRange{Min: 80, Max: 100},
+			Yellow: ThresholdRange{Min: 50, Max: 79},
+			Red:    ThresholdRange{Min: 0, Max: 49},
+		}},
+	}
+
+	report := ValidateLevers(leversConfig)
+	if !report.OK() {
+		t.Fatalf("expected a clean lever config to pass, got %+v", report)
+	}
+	if report.ExitCode() != 0 {
+		t.Fatalf("expected exit code 0 for a passing report, got %d", report.ExitCode())
+	}
+	if len(report.Issues) != 0 {
+		t.Fatalf("expected no issues for a passing report, got %+v", report.Issues)
+	}
+	if len(report.Checks) == 0 {
+		t.Fatal("expected ValidateLevers to return its constituent checks")
+	}
+}
+
+func TestValidateLeversReportsIssuesAndExitCode(t *testing.T) {
+	leversConfig := &LeversConfig{Weights: Weights{Categories: CategoryWeights{"app_sec": 0.5}}}
+
+	report := ValidateLevers(leversConfig)
+	if report.OK() {
+		t.Fatal("expected weights summing to 50% to fail")
+	}
+	if report.ExitCode() != ErrWeightsSum.ExitCode() {
+		t.Fatalf("expected exit code %d for a weights failure, got %d", ErrWeightsSum.ExitCode(), report.ExitCode())
+	}
+
+	found := false
+	for _, issue := range report.Issues {
+		if issue.Code == ErrWeightsSum {
+			found = true
+			if issue.Severity != SeverityError {
+				t.Fatalf("expected weights_sum issue to be SeverityError, got %q", issue.Severity)
+			}
+		}
+	}
+	if !found {
+		t.Fatal("expected a weights_sum issue in the report")
+	}
+}
+
+func TestFormatValidationSARIFRendersOneResultPerIssue(t *testing.T) {
+	leversConfig := &LeversConfig{Weights: Weights{Categories: CategoryWeights{"app_sec": 0.5}}}
+	report := ValidateLevers(leversConfig)
+
+	sarif, err := FormatValidationSARIF(report)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var decoded struct {
+		Version string `json:"version"`
+		Runs    []struct {
+			Results []struct {
+				RuleID string `json:"ruleId"`
+				Level  string `json:"level"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	if err := json.Unmarshal(sarif, &decoded); err != nil {
+		t.Fatalf("expected valid SARIF JSON, got error: %v", err)
+	}
+
+	if decoded.Version != "2.1.0" {
+		t.Fatalf("expected SARIF version 2.1.0, got %q", decoded.Version)
+	}
+	if len(decoded.Runs) != 1 || len(decoded.Runs[0].Results) != len(report.Issues) {
+		t.Fatalf("expected one SARIF result per issue, got %+v", decoded)
+	}
+}
+
+func TestFormatValidationSARIFEmptyForPassingReport(t *testing.T) {
+	leversConfig := &LeversConfig{
+		Weights: Weights{Categories: CategoryWeights{"app_sec": 1.0}},
+		Global: Global{Thresholds: Thresholds{
+			Green:  ThresholdRange{Min: 80, Max: 100},
+			Yellow: ThresholdRange{Min: 50, Max: 79},
+			Red:    ThresholdRange{Min: 0, Max: 49},
+		}},
+	}
+	report := ValidateLevers(leversConfig)
+
+	sarif, err := FormatValidationSARIF(report)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var decoded struct {
+		Runs []struct {
+			Results []json.RawMessage `json:"results"`
+		} `json:"runs"`
+	}
+	if err := json.Unmarshal(sarif, &decoded); err != nil {
+		t.Fatalf("expected valid SARIF JSON, got error: %v", err)
+	}
+	if len(decoded.Runs[0].Results) != 0 {
+		t.Fatalf("expected no SARIF results for a passing report, got %d", len(decoded.Runs[0].Results))
+	}
+}
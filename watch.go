@@ -0,0 +1,299 @@
+package pulse
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigEventKind identifies which kind of configuration changed.
+type ConfigEventKind string
+
+const (
+	MetricsConfigChanged ConfigEventKind = "metrics_config_changed"
+	LeversConfigChanged  ConfigEventKind = "levers_config_changed"
+	MetricsDataChanged   ConfigEventKind = "metrics_data_changed"
+)
+
+// ConfigEvent reports that a file under ConfigDir or DataDir changed and
+// re-validated successfully.
+type ConfigEvent struct {
+	Kind       ConfigEventKind
+	SourceFile string
+}
+
+// Watch polls ConfigDir and DataDir for changes to *.yaml/*.yml files and
+// emits a ConfigEvent once a change has settled for watchDebounce with no
+// further writes. Pulse has no inotify dependency, so this polls rather than
+// using fsnotify directly; the debounce window absorbs editor/atomic-rename
+// bursts just the same. Only changes that pass validateYAML and unmarshal
+// cleanly are emitted - an in-progress or invalid save is skipped until it
+// settles into something parseable. Writes performed by this ConfigLoader's
+// own SaveMetricsData/CreateMetricFile are suppressed so Watch never reacts
+// to its own output. The returned channel is closed once ctx is done.
+func (c *ConfigLoader) Watch(ctx context.Context) (<-chan ConfigEvent, error) {
+	events := make(chan ConfigEvent)
+	mtimes := make(map[string]time.Time)
+	pending := make(map[string]time.Time)
+
+	c.pollConfigFiles(mtimes, pending)
+
+	go func() {
+		defer close(events)
+
+		ticker := time.NewTicker(watchPollInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				c.pollConfigFiles(mtimes, pending)
+
+				for path, seenAt := range pending {
+					if time.Since(seenAt) < watchDebounce {
+						continue
+					}
+					delete(pending, path)
+
+					event, ok := c.validateAndBuildEvent(path)
+					if !ok {
+						continue
+					}
+
+					select {
+					case events <- event:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+		}
+	}()
+
+	return events, nil
+}
+
+const (
+	watchPollInterval = 250 * time.Millisecond
+	watchDebounce     = 200 * time.Millisecond
+)
+
+// ownWriteWindow is how long after writeFile() a matching mtime change is
+// assumed to be that write landing on disk, rather than an external edit.
+const ownWriteWindow = 2 * time.Second
+
+// pollConfigFiles updates mtimes with the current modification time of every
+// tracked YAML file, adding newly-changed (and not self-written) paths to
+// pending with the time they were first observed as changed.
+func (c *ConfigLoader) pollConfigFiles(mtimes, pending map[string]time.Time) {
+	for _, dir := range []string{c.ConfigDir, c.DataDir} {
+		entries, err := c.fs.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+
+		for _, entry := range entries {
+			if entry.IsDir() || !isYAMLFileName(entry.Name()) {
+				continue
+			}
+
+			path := filepath.Join(dir, entry.Name())
+			info, err := c.fs.Stat(path)
+			if err != nil {
+				continue
+			}
+
+			last, tracked := mtimes[path]
+			if tracked && !info.ModTime().After(last) {
+				continue
+			}
+			mtimes[path] = info.ModTime()
+
+			if c.isOwnWrite(path) {
+				delete(pending, path)
+				continue
+			}
+
+			pending[path] = time.Now()
+		}
+	}
+}
+
+// isOwnWrite reports whether path was written by this ConfigLoader's own
+// writeFile within ownWriteWindow.
+func (c *ConfigLoader) isOwnWrite(path string) bool {
+	c.recentWritesMu.Lock()
+	defer c.recentWritesMu.Unlock()
+
+	writtenAt, ok := c.recentWrites[path]
+	return ok && time.Since(writtenAt) < ownWriteWindow
+}
+
+// noteOwnWrite records that writeFile just wrote path, so Watch can
+// distinguish it from an externally-made edit.
+func (c *ConfigLoader) noteOwnWrite(path string) {
+	c.recentWritesMu.Lock()
+	defer c.recentWritesMu.Unlock()
+
+	if c.recentWrites == nil {
+		c.recentWrites = make(map[string]time.Time)
+	}
+	c.recentWrites[path] = time.Now()
+}
+
+func isYAMLFileName(name string) bool {
+	return strings.HasSuffix(name, ".yaml") || strings.HasSuffix(name, ".yml")
+}
+
+// validateAndBuildEvent re-reads, validates, and unmarshals path, returning
+// the ConfigEvent to emit and true on success, or false if the file is not
+// currently parseable.
+func (c *ConfigLoader) validateAndBuildEvent(path string) (ConfigEvent, bool) {
+	data, err := c.readFile(path)
+	if err != nil {
+		return ConfigEvent{}, false
+	}
+	if err := validateYAML(data); err != nil {
+		return ConfigEvent{}, false
+	}
+
+	dir := filepath.Dir(path)
+	base := filepath.Base(path)
+
+	switch {
+	case dir == c.ConfigDir && base == "metrics.yaml":
+		var cfg MetricsConfig
+		if err := yaml.Unmarshal(data, &cfg); err != nil {
+			return ConfigEvent{}, false
+		}
+		return ConfigEvent{Kind: MetricsConfigChanged, SourceFile: base}, true
+	case dir == c.ConfigDir && base == "levers.yaml":
+		var cfg LeversConfig
+		if err := yaml.Unmarshal(data, &cfg); err != nil {
+			return ConfigEvent{}, false
+		}
+		return ConfigEvent{Kind: LeversConfigChanged, SourceFile: base}, true
+	case dir == c.DataDir:
+		var data2 MetricsData
+		if err := yaml.Unmarshal(data, &data2); err != nil {
+			return ConfigEvent{}, false
+		}
+		return ConfigEvent{Kind: MetricsDataChanged, SourceFile: base}, true
+	default:
+		return ConfigEvent{}, false
+	}
+}
+
+// Registry caches the last successfully parsed MetricsConfig, LeversConfig,
+// and MetricsData for a ConfigLoader, atomically swapping them in as Watch
+// reports changes so long-running consumers (the Prometheus exporter, a
+// future TUI, pulse serve) can read a consistent snapshot without
+// restarting.
+type Registry struct {
+	loader *ConfigLoader
+
+	mu            sync.RWMutex
+	metricsConfig *MetricsConfig
+	leversConfig  *LeversConfig
+	metricsData   *MetricsData
+}
+
+// NewRegistry creates a Registry backed by loader, performing an initial
+// load of all three configs.
+func NewRegistry(loader *ConfigLoader) (*Registry, error) {
+	r := &Registry{loader: loader}
+
+	if err := r.reloadAll(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// MetricsConfig returns the most recently loaded MetricsConfig.
+func (r *Registry) MetricsConfig() *MetricsConfig {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.metricsConfig
+}
+
+// LeversConfig returns the most recently loaded LeversConfig.
+func (r *Registry) LeversConfig() *LeversConfig {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.leversConfig
+}
+
+// MetricsData returns the most recently loaded MetricsData.
+func (r *Registry) MetricsData() *MetricsData {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.metricsData
+}
+
+// Run watches r's ConfigLoader and reloads the affected config on every
+// ConfigEvent until ctx is done. A reload failure is swallowed - the
+// Registry just keeps serving its last-good snapshot - since Watch only
+// emits events for files that already parsed cleanly once.
+func (r *Registry) Run(ctx context.Context) error {
+	events, err := r.loader.Watch(ctx)
+	if err != nil {
+		return err
+	}
+
+	for event := range events {
+		switch event.Kind {
+		case MetricsConfigChanged:
+			if cfg, err := r.loader.LoadMetricsConfig(); err == nil {
+				r.mu.Lock()
+				r.metricsConfig = cfg
+				r.mu.Unlock()
+			}
+		case LeversConfigChanged:
+			if cfg, err := r.loader.LoadLeversConfig(); err == nil {
+				r.mu.Lock()
+				r.leversConfig = cfg
+				r.mu.Unlock()
+			}
+		case MetricsDataChanged:
+			if data, err := r.loader.LoadMetricsData(); err == nil {
+				r.mu.Lock()
+				r.metricsData = data
+				r.mu.Unlock()
+			}
+		}
+	}
+
+	return nil
+}
+
+func (r *Registry) reloadAll() error {
+	metricsConfig, err := r.loader.LoadMetricsConfig()
+	if err != nil {
+		return err
+	}
+
+	leversConfig, err := r.loader.LoadLeversConfig()
+	if err != nil {
+		return err
+	}
+
+	metricsData, err := r.loader.LoadMetricsData()
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	r.metricsConfig = metricsConfig
+	r.leversConfig = leversConfig
+	r.metricsData = metricsData
+	r.mu.Unlock()
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package pulse
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestConfigLoaderWatchDetectsExternalChange(t *testing.T) {
+	configDir := t.TempDir()
+	dataDir := t.TempDir()
+
+	if err := os.WriteFile(filepath.Join(dataDir, "app_sec.yaml"), []byte("metrics: []\n"), 0600); err != nil {
+		t.Fatalf("failed to seed metrics data file: %v", err)
+	}
+
+	configLoader := NewConfigLoader(configDir, dataDir)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := configLoader.Watch(ctx)
+	if err != nil {
+		t.Fatalf("failed to start watch: %v", err)
+	}
+
+	time.Sleep(2 * watchPollInterval)
+
+	if err := os.WriteFile(filepath.Join(dataDir, "app_sec.yaml"), []byte("metrics:\n  - reference: app_sec.KPI.coverage\n    value: 50\n"), 0600); err != nil {
+		t.Fatalf("failed to write external change: %v", err)
+	}
+
+	select {
+	case event, ok := <-events:
+		if !ok {
+			t.Fatal("events channel closed before a change was reported")
+		}
+		if event.Kind != MetricsDataChanged || event.SourceFile != "app_sec.yaml" {
+			t.Fatalf("unexpected event: %+v", event)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("timed out waiting for a change event")
+	}
+}
+
+func TestConfigLoaderWatchSuppressesOwnWrites(t *testing.T) {
+	configDir := t.TempDir()
+	dataDir := t.TempDir()
+
+	configLoader := NewConfigLoader(configDir, dataDir)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := configLoader.Watch(ctx)
+	if err != nil {
+		t.Fatalf("failed to start watch: %v", err)
+	}
+
+	time.Sleep(2 * watchPollInterval)
+
+	if err := configLoader.SaveMetricsData(&MetricsData{
+		Metrics: []Metric{{Reference: "app_sec.KPI.coverage", Value: 50, SourceFile: "app_sec.yaml"}},
+	}); err != nil {
+		t.Fatalf("failed to save metrics data: %v", err)
+	}
+
+	select {
+	case event, ok := <-events:
+		if ok {
+			t.Fatalf("expected no event for this ConfigLoader's own write, got %+v", event)
+		}
+	case <-time.After(2*watchPollInterval + 2*watchDebounce):
+		// No event arrived in the time an unsuppressed write takes to be
+		// seen by one poll and settle past the debounce window at the next -
+		// the write was correctly attributed to this ConfigLoader and
+		// suppressed.
+	}
+}